@@ -6,11 +6,29 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Plans a user can be on. They decide the user's summary and transcript
+// quotas in the video service.
+const (
+	PlanFree  = "free"
+	PlanPro   = "pro"
+	PlanAdmin = "admin"
+)
+
 type User struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
 	Username  string             `bson:"username"`
 	Email     string             `bson:"email"`
 	Password  string             `bson:"password"`
+	Plan      string             `bson:"plan,omitempty"`
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
 }
+
+// UserPlan returns the user's plan; users created before plans existed are
+// on the free plan.
+func (u *User) UserPlan() string {
+	if u.Plan == "" {
+		return PlanFree
+	}
+	return u.Plan
+}
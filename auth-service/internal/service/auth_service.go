@@ -62,13 +62,14 @@ func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		Username: req.Username,
 		Email:    req.Email,
 		Password: string(hashedPassword),
+		Plan:     models.PlanFree,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
-	token, err := s.generateToken(user.ID.Hex(), user.Username)
+	token, err := s.generateToken(user.ID.Hex(), user.Username, user.UserPlan())
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +78,7 @@ func (s *AuthService) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		Token:    token,
 		UserId:   user.ID.Hex(),
 		Username: user.Username,
+		Plan:     user.UserPlan(),
 	}, nil
 }
 
@@ -93,7 +95,7 @@ func (s *AuthService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Auth
 		return nil, errors.New("invalid credentials")
 	}
 
-	token, err := s.generateToken(user.ID.Hex(), user.Username)
+	token, err := s.generateToken(user.ID.Hex(), user.Username, user.UserPlan())
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +104,7 @@ func (s *AuthService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Auth
 		Token:    token,
 		UserId:   user.ID.Hex(),
 		Username: user.Username,
+		Plan:     user.UserPlan(),
 	}, nil
 }
 
@@ -128,17 +131,27 @@ func (s *AuthService) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 		return &pb.ValidateTokenResponse{Valid: false}, nil
 	}
 
+	// Tokens issued before plans existed carry none.
+	plan, _ := claims["plan"].(string)
+	if plan == "" {
+		plan = models.PlanFree
+	}
+
 	return &pb.ValidateTokenResponse{
 		Valid:    true,
 		UserId:   userID,
 		Username: username,
+		Plan:     plan,
 	}, nil
 }
 
-func (s *AuthService) generateToken(userID, username string) (string, error) {
+// generateToken issues a token for the user. The plan is carried in the token,
+// so a plan change takes effect at the user's next login.
+func (s *AuthService) generateToken(userID, username, plan string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":  userID,
 		"username": username,
+		"plan":     plan,
 		"exp":      time.Now().Add(time.Hour * 24).Unix(),
 	}
 
@@ -5,7 +5,9 @@ import (
 	"authservice/internal/models"
 	pb "shared/proto"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -60,6 +62,7 @@ func TestRegister(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 		assert.Equal(t, req.Username, resp.Username)
+		assert.Equal(t, models.PlanFree, resp.Plan)
 		assert.NotEmpty(t, resp.Token)
 		mockRepo.AssertExpectations(t)
 	})
@@ -211,7 +214,7 @@ func TestValidateToken(t *testing.T) {
 	t.Run("valid token", func(t *testing.T) {
 		userID := primitive.NewObjectID().Hex()
 		username := "testuser"
-		token, _ := s.generateToken(userID, username)
+		token, _ := s.generateToken(userID, username, models.PlanPro)
 
 		req := &pb.ValidateTokenRequest{Token: token}
 		resp, err := s.ValidateToken(context.Background(), req)
@@ -220,6 +223,21 @@ func TestValidateToken(t *testing.T) {
 		assert.True(t, resp.Valid)
 		assert.Equal(t, userID, resp.UserId)
 		assert.Equal(t, username, resp.Username)
+		assert.Equal(t, models.PlanPro, resp.Plan)
+	})
+
+	t.Run("token without plan", func(t *testing.T) {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id":  "user-1",
+			"username": "testuser",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(secret))
+
+		resp, err := s.ValidateToken(context.Background(), &pb.ValidateTokenRequest{Token: token})
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Valid)
+		assert.Equal(t, models.PlanFree, resp.Plan)
 	})
 
 	t.Run("invalid token", func(t *testing.T) {
@@ -81,8 +81,17 @@ func main() {
 	defer videoClient.Close()
 
 	h := handler.NewHandler(authClient)
-	vh := handler.NewVideoHandler(videoClient)
+	publicBaseURL := os.Getenv("PUBLIC_BASE_URL")
+	if publicBaseURL == "" {
+		log.Println("PUBLIC_BASE_URL is not set, feed links follow the X-Forwarded-Host and X-Forwarded-Proto headers")
+	}
+	vh := handler.NewVideoHandler(videoClient, publicBaseURL)
 	ssrh := handler.NewSSRHandler(authClient, videoClient)
+	websubSecret := os.Getenv("WEBSUB_SECRET")
+	if websubSecret == "" {
+		log.Println("WEBSUB_SECRET is not set, WebSub notifications are dropped")
+	}
+	wh := handler.NewWebSubHandler(videoClient, websubSecret)
 	m := middleware.NewMiddleware(authClient)
 
 	r := mux.NewRouter()
@@ -91,6 +100,10 @@ func main() {
 	r.HandleFunc("/health", h.HealthCheck).Methods("GET")
 	r.HandleFunc("/api/auth/register", h.Register).Methods("POST")
 	r.HandleFunc("/api/auth/login", h.Login).Methods("POST")
+	r.HandleFunc("/websub/youtube", wh.Verify).Methods("GET")
+	r.HandleFunc("/websub/youtube", wh.Notify).Methods("POST")
+	r.HandleFunc("/feeds/users/{token}", vh.UserSummaryFeed).Methods("GET")
+	r.HandleFunc("/feeds/channels/{channelId}", vh.ChannelSummaryFeed).Methods("GET")
 
 	// Swagger UI
 	r.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
@@ -108,6 +121,25 @@ func main() {
 	ssr.HandleFunc("/", ssrh.Home).Methods("GET")
 	ssr.HandleFunc("/video/{videoId}", ssrh.VideoDetail).Methods("GET")
 	ssr.HandleFunc("/video/{videoId}/summarize", ssrh.Summarize).Methods("POST")
+	ssr.HandleFunc("/video/{videoId}/summarize/stream", ssrh.SummarizeStream).Methods("GET")
+	ssr.HandleFunc("/video/{videoId}/summarize/job", ssrh.SummarizeJob).Methods("POST")
+	ssr.HandleFunc("/jobs/{jobId}", ssrh.Job).Methods("GET")
+	ssr.HandleFunc("/video/{videoId}/ask", ssrh.Ask).Methods("POST")
+	ssr.HandleFunc("/video/{videoId}/chapters", ssrh.Chapters).Methods("POST")
+	ssr.HandleFunc("/channel/{channelId}/digest", ssrh.ChannelDigest).Methods("GET")
+	ssr.HandleFunc("/channel/{channelId}/subscribe", ssrh.Subscribe).Methods("POST")
+	ssr.HandleFunc("/feed/token", ssrh.RotateFeedToken).Methods("POST")
+	ssr.HandleFunc("/video/{videoId}/save", ssrh.SaveToCollection).Methods("POST")
+	ssr.HandleFunc("/video/{videoId}/notes", ssrh.CreateNote).Methods("POST")
+	ssr.HandleFunc("/video/{videoId}/notes/{noteId}/delete", ssrh.DeleteNote).Methods("POST")
+	ssr.HandleFunc("/video/{videoId}/export", ssrh.ExportVideo).Methods("GET")
+	ssr.HandleFunc("/collections", ssrh.Collections).Methods("GET")
+	ssr.HandleFunc("/collections", ssrh.CreateCollection).Methods("POST")
+	ssr.HandleFunc("/collections/{collectionId}", ssrh.Collection).Methods("GET")
+	ssr.HandleFunc("/collections/{collectionId}", ssrh.UpdateCollection).Methods("POST")
+	ssr.HandleFunc("/collections/{collectionId}/export", ssrh.ExportCollection).Methods("GET")
+	ssr.HandleFunc("/collections/{collectionId}/videos/{videoId}/tags", ssrh.TagVideo).Methods("POST")
+	ssr.HandleFunc("/collections/{collectionId}/videos/{videoId}/remove", ssrh.RemoveFromCollection).Methods("POST")
 
 	// Protected JSON routes
 	protected := r.PathPrefix("/api").Subrouter()
@@ -117,9 +149,46 @@ func main() {
 	// Video routes (protected)
 	protected.HandleFunc("/videos/search", vh.SearchChannel).Methods("GET")
 	protected.HandleFunc("/videos/channel/{channelId}", vh.GetChannelVideos).Methods("GET")
+	protected.HandleFunc("/videos/channel/{channelId}/digest", vh.SummarizeChannel).Methods("GET")
+	protected.HandleFunc("/videos/compare", vh.SummarizeVideos).Methods("POST")
 	protected.HandleFunc("/videos/{videoId}", vh.GetVideoDetails).Methods("GET")
 	protected.HandleFunc("/videos/{videoId}/transcript", vh.GetVideoTranscript).Methods("GET")
 	protected.HandleFunc("/videos/{videoId}/summarize", vh.SummarizeVideo).Methods("GET")
+	protected.HandleFunc("/videos/{videoId}/summarize/stream", vh.SummarizeVideoStream).Methods("GET")
+	protected.HandleFunc("/videos/{videoId}/ask", vh.AskVideo).Methods("POST")
+	protected.HandleFunc("/videos/{videoId}/conversation", vh.GetConversation).Methods("GET")
+	protected.HandleFunc("/videos/{videoId}/chapters", vh.GenerateChapters).Methods("GET")
+	protected.HandleFunc("/videos/{videoId}/notes", vh.CreateNote).Methods("POST")
+	protected.HandleFunc("/videos/{videoId}/notes", vh.ListVideoNotes).Methods("GET")
+	protected.HandleFunc("/videos/{videoId}/export", vh.ExportVideo).Methods("GET")
+	protected.HandleFunc("/notes", vh.ListNotes).Methods("GET")
+	protected.HandleFunc("/notes/{noteId}", vh.DeleteNote).Methods("DELETE")
+	protected.HandleFunc("/search", vh.SemanticSearch).Methods("GET")
+	protected.HandleFunc("/usage", vh.GetUsage).Methods("GET")
+	protected.HandleFunc("/jobs", vh.SubmitJob).Methods("POST")
+	protected.HandleFunc("/jobs", vh.ListJobs).Methods("GET")
+	protected.HandleFunc("/jobs/{jobId}", vh.GetJob).Methods("GET")
+	protected.HandleFunc("/webhooks", vh.CreateWebhook).Methods("POST")
+	protected.HandleFunc("/webhooks", vh.ListWebhooks).Methods("GET")
+	protected.HandleFunc("/webhooks/deliveries", vh.ListWebhookDeliveries).Methods("GET")
+	protected.HandleFunc("/webhooks/deliveries/{deliveryId}/replay", vh.ReplayWebhookDelivery).Methods("POST")
+	protected.HandleFunc("/webhooks/{webhookId}", vh.DeleteWebhook).Methods("DELETE")
+	protected.HandleFunc("/subscriptions", vh.Subscribe).Methods("POST")
+	protected.HandleFunc("/subscriptions", vh.ListSubscriptions).Methods("GET")
+	protected.HandleFunc("/subscriptions/{channelId}", vh.Unsubscribe).Methods("DELETE")
+	protected.HandleFunc("/feed", vh.GetFeed).Methods("GET")
+	protected.HandleFunc("/feed/token", vh.GetFeedToken).Methods("GET")
+	protected.HandleFunc("/feed/token", vh.RotateFeedToken).Methods("POST")
+	protected.HandleFunc("/collections", vh.CreateCollection).Methods("POST")
+	protected.HandleFunc("/collections", vh.ListCollections).Methods("GET")
+	protected.HandleFunc("/collections/tags", vh.ListTags).Methods("GET")
+	protected.HandleFunc("/collections/tags/{videoId}", vh.SetVideoTags).Methods("PUT")
+	protected.HandleFunc("/collections/{collectionId}", vh.GetCollection).Methods("GET")
+	protected.HandleFunc("/collections/{collectionId}", vh.UpdateCollection).Methods("PUT")
+	protected.HandleFunc("/collections/{collectionId}", vh.DeleteCollection).Methods("DELETE")
+	protected.HandleFunc("/collections/{collectionId}/videos", vh.AddToCollection).Methods("POST")
+	protected.HandleFunc("/collections/{collectionId}/videos/{videoId}", vh.RemoveFromCollection).Methods("DELETE")
+	protected.HandleFunc("/collections/{collectionId}/export", vh.ExportCollection).Methods("GET")
 
 	// Wrap router with CORS and OpenTelemetry middleware
 	otelHandler := otelhttp.NewHandler(r, "gateway")
@@ -116,14 +116,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/profile": {
+        "/api/collections": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get current user's profile information using token",
+                "description": "List the current user's collections with the number of videos in each, without the videos.",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,27 +131,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "collections"
                 ],
-                "summary": "Get user profile",
+                "summary": "List collections",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ProfileResponse"
+                            "$ref": "#/definitions/handler.ListCollectionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/videos/channel/{channelId}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a list of videos from a specific channel ID",
+                "description": "Create a named list to save videos to. A user can have up to 100 collections.",
                 "consumes": [
                     "application/json"
                 ],
@@ -159,34 +163,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "videos"
+                    "collections"
                 ],
-                "summary": "Get videos from a channel",
+                "summary": "Create a collection",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Channel ID",
-                        "name": "channelId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Max Results",
-                        "name": "max_results",
-                        "in": "query"
+                        "description": "Name and description",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CollectionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SearchChannelResponse"
+                            "$ref": "#/definitions/handler.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -194,14 +199,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/videos/search": {
+        "/api/collections/tags": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Search for a channel by name and return its details and recent videos",
+                "description": "List the tags the current user gave videos with how many videos have each, and, when a tag is given, the videos with it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -209,27 +214,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "videos"
+                    "collections"
                 ],
-                "summary": "Search for a YouTube channel",
+                "summary": "List tags",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Channel Name",
-                        "name": "channel",
-                        "in": "query",
-                        "required": true
+                        "description": "Also list the videos with this tag",
+                        "name": "tag",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SearchChannelResponse"
+                            "$ref": "#/definitions/handler.ListTagsResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -237,14 +241,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/videos/{videoId}": {
-            "get": {
+        "/api/collections/tags/{videoId}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific video",
+                "description": "Replace the tags the current user gave a video, whether or not it is in a collection. Tags are trimmed, lowercased and deduplicated; a video can have up to 20 tags of up to 50 characters.",
                 "consumes": [
                     "application/json"
                 ],
@@ -252,9 +256,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "videos"
+                    "collections"
                 ],
-                "summary": "Get video details",
+                "summary": "Tag a video",
                 "parameters": [
                     {
                         "type": "string",
@@ -262,17 +266,32 @@ const docTemplate = `{
                         "name": "videoId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VideoTagsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.VideoDetailsResponse"
+                            "$ref": "#/definitions/handler.VideoTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -280,14 +299,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/videos/{videoId}/summarize": {
+        "/api/collections/{collectionId}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Generate a summary for a specific video",
+                "description": "Get a collection with its videos, most recently added first, and the tags the user gave them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -295,42 +314,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "videos"
+                    "collections"
                 ],
-                "summary": "Summarize a video",
+                "summary": "Get a collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Video ID",
-                        "name": "videoId",
+                        "description": "Collection ID",
+                        "name": "collectionId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list the videos with this tag",
+                        "name": "tag",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SummarizeResponse"
+                            "$ref": "#/definitions/handler.CollectionResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/videos/{videoId}/transcript": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get the transcript of a specific video",
+                "description": "Replace the name and description of a collection.",
                 "consumes": [
                     "application/json"
                 ],
@@ -338,27 +367,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "videos"
+                    "collections"
                 ],
-                "summary": "Get video transcript",
+                "summary": "Rename a collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Video ID",
-                        "name": "videoId",
+                        "description": "Collection ID",
+                        "name": "collectionId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Name and description",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CollectionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.TranscriptResponse"
+                            "$ref": "#/definitions/handler.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a collection. The tags of its videos are kept.",
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -366,9 +454,130 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/api/collections/{collectionId}/export": {
             "get": {
-                "description": "get the status of server.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download a collection with the default summary of each of its videos, as one Markdown document, a JSON bundle, standalone HTML laid out for printing to PDF or an EPUB book with a chapter per video. Cached summaries are reused; every other video counts against the summary quota. Videos that cannot be summarized are exported with the reason instead.",
+                "produces": [
+                    "text/markdown",
+                    "application/json",
+                    "text/html",
+                    "application/epub+zip"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Export a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "md",
+                            "json",
+                            "html",
+                            "epub"
+                        ],
+                        "type": "string",
+                        "default": "md",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include each video's transcript",
+                        "name": "transcript",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{collectionId}/videos": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Save a video to a collection with its current title, channel and thumbnail. Saving a video that is already in the collection changes nothing. A collection holds up to 500 videos.",
                 "consumes": [
                     "application/json"
                 ],
@@ -376,121 +585,3177 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "root"
+                    "collections"
+                ],
+                "summary": "Save a video to a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Video to save",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AddToCollectionRequest"
+                        }
+                    }
                 ],
-                "summary": "Show the status of server.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HealthResponse"
+                            "$ref": "#/definitions/handler.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.AuthResponse": {
-            "type": "object",
-            "properties": {
+        },
+        "/api/collections/{collectionId}/videos/{videoId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a video from a collection. The tags the user gave it are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Remove a video from a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collectionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CollectionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the new uploads from the channels the current user follows that they have not opened yet, and the videos they recently viewed, read the transcript of or summarized. Opening a video, reading its transcript or summarizing it is recorded in the history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get the personal feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of items in each list, 1-50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.FeedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feed/token": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the RSS and Atom URLs of the current user's summary feed: the recent uploads of the channels they follow that have been summarized, with the summaries as content. The URLs carry a secret token instead of credentials so that feed readers can fetch them; it is created on first use.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get the summary feed URLs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.FeedTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the secret token in the current user's summary feed URLs, for when they have leaked. The old URLs stop working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Replace the summary feed URLs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.FeedTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the current user's most recent jobs, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "List background jobs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "queued",
+                            "running",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Only jobs in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of jobs to return, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queue a summary to be generated in the background and return the job at once. Poll the job's URL, given in the Location header, for its status, progress and result. The summary counts against the quota when submitted and is given back if the job fails.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Submit a background job",
+                "parameters": [
+                    {
+                        "description": "Job",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.JobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JobResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            },
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Too many jobs are queued",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/jobs/{jobId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the status and progress of one of the current user's jobs, and its result once it has succeeded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get a background job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JobResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all of the current user's notes, most recent first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List notes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListNotesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{noteId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's notes.",
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Delete a note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Note ID",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get current user's profile information using token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProfileResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find the transcript passages most relevant to a natural-language query. Videos passed in video_id are indexed on first use; without video_id every indexed video is searched.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Semantic search over transcripts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of passages to return",
+                        "name": "top_k",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Restrict to these video IDs, at most 10",
+                        "name": "video_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SemanticSearchResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Requests allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Requests left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly transcript quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the channels the current user follows with the number of uploads found since the last visit, and those uploads newest first. With mark_visited the uploads returned no longer count as new afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "List followed channels",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Mark the new uploads as seen",
+                        "name": "mark_visited",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListSubscriptionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Follow a channel so its new uploads are picked up in the background and listed as new videos. Following a channel again only changes what is prefetched. A channel.video_published webhook event is sent for each new upload.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Follow a channel",
+                "parameters": [
+                    {
+                        "description": "Channel to follow",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscriptions/{channelId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stop following a channel. Uploads already found from it are no longer listed.",
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Unfollow a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the current user's LLM token usage and estimated cost over the last days, in total and per provider and model",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Get LLM usage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of days to report",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/channel/{channelId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of videos from a specific channel ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Get videos from a channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max Results",
+                        "name": "max_results",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page Token",
+                        "name": "page_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetChannelVideosResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/channel/{channelId}/digest": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Summarize the uploads of a channel in the last few days, newest first, and write a newsletter-style digest of them that cites each as [1], [2], ... in the order of the videos list. Summaries cached from earlier requests are reused; every other upload counts against the summary quota. With no uploads in the window the digest is empty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Digest a channel's recent uploads",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "How many days back to look, up to 31",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Most recent uploads to include, up to 20",
+                        "name": "max_videos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language to write the digest in",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChannelDigestResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "None of the uploads could be summarized, or the model's filters blocked the digest",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/compare": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Summarize between 2 and 10 videos and write a digest of where they agree and differ, optionally focused on a question. Claims in the digest cite the videos as [1], [2], ... in the order of the videos list. Summaries cached from earlier requests are reused; every other video counts against the summary quota. Videos that cannot be summarized carry an error, are left out of the digest and are not counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Compare several videos",
+                "parameters": [
+                    {
+                        "description": "Videos to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CompareVideosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CompareVideosResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "None of the videos could be summarized, or the model's filters blocked the digest",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Search for a channel by name and return its details and recent videos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Search for a YouTube channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel Name",
+                        "name": "channel",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SearchChannelResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific video",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Get video details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.VideoDetailsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/ask": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Answer a question from the video's transcript with citations to timestamps. The conversation is kept per user and video, so follow-up questions see earlier turns.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Ask a question about a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AskVideoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AskVideoResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Requests allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Requests left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The model's safety or recitation filters blocked the content",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly transcript or summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/chapters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Split a video into chapters from its timestamped transcript. Chapters are cached per video; pass refresh to regenerate them. With format=youtube the response is the plain-text \"0:00 Title\" list to paste into a YouTube description.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Generate chapter markers for a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Regenerate instead of using cached chapters",
+                        "name": "refresh",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "youtube"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChaptersResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Requests allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Requests left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The model's safety or recitation filters blocked the content",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly transcript or summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/conversation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the current user's question-and-answer history for a video",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Get the conversation about a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ConversationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download a video's default summary as a document: Markdown with YAML front matter for Obsidian, a Notion-style JSON page, standalone HTML laid out for printing to PDF, or an EPUB book. The document has the title, channel, URL, publish date and duration, the summary, the chapters, the current user's tags and notes and, when asked for, the transcript with timestamps. A cached summary is reused; otherwise summarizing counts against the summary quota.",
+                "produces": [
+                    "text/markdown",
+                    "application/json",
+                    "text/html",
+                    "application/epub+zip"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Export a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "md",
+                            "json",
+                            "html",
+                            "epub"
+                        ],
+                        "type": "string",
+                        "default": "md",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the full transcript",
+                        "name": "transcript",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the current user's notes on a video in transcript order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List a video's notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListNotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Highlight a stretch of a video's transcript with a quote of it, a note of your own, or both. Notes are private, shown on the video page, given as context when asking the video questions and included in collection exports. A video can have up to 200 notes per user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Add a note to a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Timestamp range, quote and text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/summarize": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate a summary for a specific video",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Summarize a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "tldr",
+                            "bullets",
+                            "detailed",
+                            "executive",
+                            "study_guide",
+                            "tweet_thread"
+                        ],
+                        "type": "string",
+                        "default": "detailed",
+                        "description": "Summary style",
+                        "name": "style",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "short",
+                            "medium",
+                            "long"
+                        ],
+                        "type": "string",
+                        "default": "medium",
+                        "description": "Target length",
+                        "name": "length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language to write the summary in; defaults to the transcript's language",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return title, TL;DR, chapters, key takeaways, entities, resources and action items as structured data",
+                        "name": "structured",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SummarizeResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The model's safety or recitation filters blocked the content",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/summarize/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generate a summary and stream it as Server-Sent Events. \"chunk\" events carry {\"delta\"} text to append, the final \"done\" event carries the complete {\"video_id\", \"summary\"}, and an \"error\" event carries {\"error\"} if generation fails.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Stream a video summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "tldr",
+                            "bullets",
+                            "detailed",
+                            "executive",
+                            "study_guide",
+                            "tweet_thread"
+                        ],
+                        "type": "string",
+                        "default": "detailed",
+                        "description": "Summary style",
+                        "name": "style",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "short",
+                            "medium",
+                            "long"
+                        ],
+                        "type": "string",
+                        "default": "medium",
+                        "description": "Target length",
+                        "name": "length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language to write the summary in; defaults to the transcript's language",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Generate a structured summary; it arrives in one piece with the done event",
+                        "name": "structured",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SummaryChunkEvent"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Summaries allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Summaries left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly summary quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{videoId}/transcript": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the transcript of a specific video",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "videos"
+                ],
+                "summary": "Get video transcript",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Video ID",
+                        "name": "videoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TranscriptResponse"
+                        },
+                        "headers": {
+                            "X-Quota-Limit": {
+                                "type": "integer",
+                                "description": "Requests allowed in the user's tightest quota window"
+                            },
+                            "X-Quota-Plan": {
+                                "type": "string",
+                                "description": "The user's plan"
+                            },
+                            "X-Quota-Remaining": {
+                                "type": "integer",
+                                "description": "Requests left in that window"
+                            },
+                            "X-Quota-Reset": {
+                                "type": "integer",
+                                "description": "When that window resets, in Unix seconds"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The daily or monthly transcript quota is used up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the current user's webhooks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListWebhooksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a URL to be sent a signed JSON POST when an event happens. The X-TextTube-Signature header of each request is \"sha256=\" followed by the hex HMAC-SHA256 of the body, keyed with the secret returned here; it is not shown again. Failed deliveries are retried with exponential backoff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the current user's most recent webhook deliveries with their status, attempts, last response and payload, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only deliveries to this webhook",
+                        "name": "webhook_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of deliveries to return, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListWebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries/{deliveryId}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send the payload of an earlier delivery to its webhook again, as a new delivery",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Replay a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookDeliveryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The webhook has been deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{webhookId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stop sending events to a webhook. Its delivery log is kept.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/channels/{channelId}": {
+            "get": {
+                "description": "Get the recent uploads of a channel that someone follows that have a summary, newest first, as an RSS or Atom feed with the summaries as item content. Nothing is summarized when the feed is fetched. Channels nobody follows have no feed. No authentication is needed.",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get a channel's summary feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel ID",
+                        "name": "channelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "default": "rss",
+                        "description": "Feed format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of items, 1-50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/users/{token}": {
+            "get": {
+                "description": "Get the recent uploads of the channels a user follows that have a summary, newest first, as an RSS or Atom feed with the summaries as item content. Nothing is summarized when the feed is fetched: summaries come from summarizing videos and from following channels with prefetch set to summary. The token comes from /api/feed/token; no other authentication is needed.",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get a user's summary feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "default": "rss",
+                        "description": "Feed format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of items, 1-50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "get the status of server.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "root"
+                ],
+                "summary": "Show the status of server.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HealthResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AddToCollectionRequest": {
+            "type": "object",
+            "properties": {
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AskVideoRequest": {
+            "type": "object",
+            "properties": {
+                "question": {
+                    "type": "string"
+                },
+                "reset_history": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.AskVideoResponse": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "citations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.Citation"
+                    }
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ChatMessage"
+                    }
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "plan": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ChannelDigestResponse": {
+            "type": "object",
+            "properties": {
+                "channel_id": {
+                    "type": "string"
+                },
+                "channel_title": {
+                    "type": "string"
+                },
+                "digest": {
+                    "description": "Newsletter-style Markdown citing the uploads as [1], [2], ... in the\norder of videos. Empty when there were no uploads.",
+                    "type": "string"
+                },
+                "generated_by": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ModelInfo"
+                    }
+                },
+                "since": {
+                    "description": "Start of the window, RFC 3339.",
+                    "type": "string"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VideoDigestItem"
+                    }
+                }
+            }
+        },
+        "handler.ChaptersResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean"
+                },
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VideoChapter"
+                    }
+                },
+                "video_id": {
+                    "type": "string"
+                },
+                "youtube_description": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ChatMessage": {
+            "type": "object",
+            "properties": {
+                "citations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.Citation"
+                    }
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.Citation": {
+            "type": "object",
+            "properties": {
+                "end_seconds": {
+                    "type": "number"
+                },
+                "start_seconds": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CollectionItemResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "channel_title": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CollectionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CollectionResponse": {
+            "type": "object",
+            "properties": {
+                "collection_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Most recently added first. Left out when collections are listed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CollectionItemResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "video_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CompareVideosRequest": {
+            "type": "object",
+            "properties": {
+                "focus": {
+                    "description": "An optional question or angle to compare the videos on.",
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "video_ids": {
+                    "description": "Between 2 and 10 video IDs.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.CompareVideosResponse": {
+            "type": "object",
+            "properties": {
+                "digest": {
+                    "description": "Markdown citing the videos as [1], [2], ... in the order of videos.",
+                    "type": "string"
+                },
+                "generated_by": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ModelInfo"
+                    }
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VideoDigestItem"
+                    }
+                }
+            }
+        },
+        "handler.ConversationResponse": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ChatMessage"
+                    }
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.FeedItemResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "description": "When the upload was found, RFC 3339.",
+                    "type": "string"
+                },
+                "channel_id": {
+                    "type": "string"
+                },
+                "channel_title": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.FeedResponse": {
+            "type": "object",
+            "properties": {
+                "continue_reading": {
+                    "description": "Videos recently viewed, read or summarized, most recent first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.HistoryItemResponse"
+                    }
+                },
+                "new_videos": {
+                    "description": "New uploads from followed channels not opened yet, newest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.FeedItemResponse"
+                    }
+                }
+            }
+        },
+        "handler.FeedTokenResponse": {
+            "type": "object",
+            "properties": {
+                "atom_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "RFC 3339.",
+                    "type": "string"
+                },
+                "rss_url": {
+                    "type": "string"
+                },
                 "token": {
+                    "description": "The secret in the feed URLs. Anyone with it can read the feed.",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GetChannelVideosResponse": {
+            "type": "object",
+            "properties": {
+                "next_page_token": {
+                    "type": "string"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VideoSummary"
+                    }
+                }
+            }
+        },
+        "handler.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "service": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HistoryItemResponse": {
+            "type": "object",
+            "properties": {
+                "channel_id": {
+                    "type": "string"
+                },
+                "channel_title": {
+                    "type": "string"
+                },
+                "last_activity_at": {
+                    "type": "string"
+                },
+                "summarized_at": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transcript_read_at": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                },
+                "viewed_at": {
+                    "description": "When the video was last opened, its transcript last read and its\nsummary last generated, RFC 3339; omitted for what was never done.",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.JobRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "length": {
+                    "type": "string"
+                },
+                "structured": {
+                    "type": "boolean"
+                },
+                "style": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Only \"summary\" is supported; it is the default.",
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.JobResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "queued",
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "summary": {
+                    "$ref": "#/definitions/handler.SummarizeResponse"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListCollectionsResponse": {
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "description": "Most recently updated first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CollectionResponse"
+                    }
+                }
+            }
+        },
+        "handler.ListJobsResponse": {
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.JobResponse"
+                    }
+                }
+            }
+        },
+        "handler.ListNotesResponse": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.NoteResponse"
+                    }
+                }
+            }
+        },
+        "handler.ListSubscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "new_videos": {
+                    "description": "Uploads found since the last visit, newest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.FeedItemResponse"
+                    }
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SubscriptionResponse"
+                    }
+                }
+            }
+        },
+        "handler.ListTagsResponse": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "description": "Most used first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.TagCountResponse"
+                    }
+                },
+                "videos": {
+                    "description": "The videos with the requested tag, most recently tagged first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VideoTagsResponse"
+                    }
+                }
+            }
+        },
+        "handler.ListWebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.WebhookDeliveryResponse"
+                    }
+                }
+            }
+        },
+        "handler.ListWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.WebhookResponse"
+                    }
+                }
+            }
+        },
+        "handler.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ModelInfo": {
+            "type": "object",
+            "properties": {
+                "model": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ModelUsage": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "cost_usd": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.NoteRequest": {
+            "type": "object",
+            "properties": {
+                "end_seconds": {
+                    "description": "Defaults to start_seconds.",
+                    "type": "number"
+                },
+                "quote": {
+                    "description": "The highlighted transcript text.",
+                    "type": "string"
+                },
+                "start_seconds": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.NoteResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "end_seconds": {
+                    "type": "number"
+                },
+                "note_id": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string"
+                },
+                "start_seconds": {
+                    "type": "number"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "plan": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SearchChannelResponse": {
+            "type": "object",
+            "properties": {
+                "channel_description": {
                     "type": "string"
                 },
-                "user_id": {
+                "channel_id": {
                     "type": "string"
                 },
-                "username": {
+                "channel_title": {
+                    "type": "string"
+                },
+                "next_page_token": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
                     "type": "string"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.VideoSummary"
+                    }
                 }
             }
         },
-        "handler.ErrorResponse": {
+        "handler.SemanticSearchResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SemanticSearchResult"
+                    }
                 }
             }
         },
-        "handler.HealthResponse": {
+        "handler.SemanticSearchResult": {
             "type": "object",
             "properties": {
-                "service": {
+                "chunk_index": {
+                    "type": "integer"
+                },
+                "end_seconds": {
+                    "type": "number"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "start_seconds": {
+                    "type": "number"
+                },
+                "text": {
                     "type": "string"
                 },
-                "status": {
+                "video_id": {
                     "type": "string"
                 }
             }
         },
-        "handler.LoginRequest": {
+        "handler.StructuredSummary": {
             "type": "object",
             "properties": {
-                "email": {
+                "action_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "chapters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SummaryChapter"
+                    }
+                },
+                "entities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SummaryEntity"
+                    }
+                },
+                "key_takeaways": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SummaryResource"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
-                "password": {
+                "tldr": {
                     "type": "string"
                 }
             }
         },
-        "handler.ProfileResponse": {
+        "handler.SubscriptionRequest": {
             "type": "object",
             "properties": {
-                "user_id": {
+                "channel_id": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string"
+                "prefetch": {
+                    "description": "What to prepare for each new upload before it is opened: nothing,\nthe transcript (indexed for search and questions) or the summary.\nSummaries count against the summary quota.",
+                    "type": "string",
+                    "enum": [
+                        "",
+                        "transcript",
+                        "summary"
+                    ]
                 }
             }
         },
-        "handler.RegisterRequest": {
+        "handler.SubscriptionResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "channel_id": {
                     "type": "string"
                 },
-                "password": {
+                "channel_title": {
                     "type": "string"
                 },
-                "username": {
+                "created_at": {
+                    "type": "string"
+                },
+                "new_videos": {
+                    "description": "Uploads found since the subscriptions were last visited.",
+                    "type": "integer"
+                },
+                "prefetch": {
                     "type": "string"
                 }
             }
         },
-        "handler.SearchChannelResponse": {
+        "handler.SummarizeResponse": {
             "type": "object",
             "properties": {
-                "channel_description": {
+                "generated_by": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ModelInfo"
+                    }
+                },
+                "language": {
                     "type": "string"
                 },
-                "channel_id": {
+                "length": {
                     "type": "string"
                 },
-                "channel_title": {
+                "structured": {
+                    "$ref": "#/definitions/handler.StructuredSummary"
+                },
+                "style": {
                     "type": "string"
                 },
-                "thumbnail_url": {
+                "summary": {
                     "type": "string"
                 },
-                "videos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.VideoSummary"
-                    }
+                "video_id": {
+                    "type": "string"
                 }
             }
         },
-        "handler.SummarizeResponse": {
+        "handler.SummaryChapter": {
             "type": "object",
             "properties": {
+                "start_seconds": {
+                    "type": "number"
+                },
                 "summary": {
                     "type": "string"
                 },
-                "video_id": {
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SummaryChunkEvent": {
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SummaryEntity": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.SummaryResource": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TagCountResponse": {
+            "type": "object",
+            "properties": {
+                "tag": {
                     "type": "string"
+                },
+                "videos": {
+                    "type": "integer"
                 }
             }
         },
@@ -522,6 +3787,46 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "by_model": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ModelUsage"
+                    }
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "cost_usd": {
+                    "type": "number"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.VideoChapter": {
+            "type": "object",
+            "properties": {
+                "start_seconds": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.VideoDetailsResponse": {
             "type": "object",
             "properties": {
@@ -530,6 +3835,39 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.VideoDigestItem": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Whether the summary was reused rather than generated for this digest.",
+                    "type": "boolean"
+                },
+                "channel_title": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "generated_by": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ModelInfo"
+                    }
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.VideoSummary": {
             "type": "object",
             "properties": {
@@ -555,6 +3893,119 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "handler.VideoTagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "description": "Free-form tags, stored trimmed and lowercased. An empty list removes\nthe video's tags.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.VideoTagsResponse": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "video_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ]
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events to deliver; empty subscribes to every event.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "summary.completed",
+                            "job.failed",
+                            "channel.video_published"
+                        ]
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Only returned when the webhook is created.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -569,7 +4020,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:9191",
+	Host:             "localhost:8080",
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "TextTube Gateway API",
@@ -41,12 +41,164 @@ func (c *VideoClient) GetVideoDetails(ctx context.Context, req *pb.GetVideoDetai
 	return c.client.GetVideoDetails(ctx, req)
 }
 
-func (c *VideoClient) GetVideoTranscript(ctx context.Context, req *pb.GetVideoTranscriptRequest) (*pb.GetVideoTranscriptResponse, error) {
-	return c.client.GetVideoTranscript(ctx, req)
+func (c *VideoClient) GetVideoTranscript(ctx context.Context, req *pb.GetVideoTranscriptRequest, opts ...grpc.CallOption) (*pb.GetVideoTranscriptResponse, error) {
+	return c.client.GetVideoTranscript(ctx, req, opts...)
 }
 
-func (c *VideoClient) SummarizeVideo(ctx context.Context, req *pb.SummarizeVideoRequest) (*pb.SummarizeVideoResponse, error) {
-	return c.client.SummarizeVideo(ctx, req)
+func (c *VideoClient) SummarizeVideo(ctx context.Context, req *pb.SummarizeVideoRequest, opts ...grpc.CallOption) (*pb.SummarizeVideoResponse, error) {
+	return c.client.SummarizeVideo(ctx, req, opts...)
+}
+
+func (c *VideoClient) SummarizeVideoStream(ctx context.Context, req *pb.SummarizeVideoRequest, opts ...grpc.CallOption) (pb.VideoService_SummarizeVideoStreamClient, error) {
+	return c.client.SummarizeVideoStream(ctx, req, opts...)
+}
+
+func (c *VideoClient) SemanticSearch(ctx context.Context, req *pb.SemanticSearchRequest, opts ...grpc.CallOption) (*pb.SemanticSearchResponse, error) {
+	return c.client.SemanticSearch(ctx, req, opts...)
+}
+
+func (c *VideoClient) AskVideo(ctx context.Context, req *pb.AskVideoRequest, opts ...grpc.CallOption) (*pb.AskVideoResponse, error) {
+	return c.client.AskVideo(ctx, req, opts...)
+}
+
+func (c *VideoClient) GetConversation(ctx context.Context, req *pb.GetConversationRequest) (*pb.GetConversationResponse, error) {
+	return c.client.GetConversation(ctx, req)
+}
+
+func (c *VideoClient) GenerateChapters(ctx context.Context, req *pb.GenerateChaptersRequest, opts ...grpc.CallOption) (*pb.GenerateChaptersResponse, error) {
+	return c.client.GenerateChapters(ctx, req, opts...)
+}
+
+func (c *VideoClient) SummarizeVideos(ctx context.Context, req *pb.SummarizeVideosRequest, opts ...grpc.CallOption) (*pb.SummarizeVideosResponse, error) {
+	return c.client.SummarizeVideos(ctx, req, opts...)
+}
+
+func (c *VideoClient) SummarizeChannel(ctx context.Context, req *pb.SummarizeChannelRequest, opts ...grpc.CallOption) (*pb.SummarizeChannelResponse, error) {
+	return c.client.SummarizeChannel(ctx, req, opts...)
+}
+
+func (c *VideoClient) GetUsage(ctx context.Context, req *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	return c.client.GetUsage(ctx, req)
+}
+
+func (c *VideoClient) SubmitSummaryJob(ctx context.Context, req *pb.SummarizeVideoRequest, opts ...grpc.CallOption) (*pb.Job, error) {
+	return c.client.SubmitSummaryJob(ctx, req, opts...)
+}
+
+func (c *VideoClient) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	return c.client.GetJob(ctx, req)
+}
+
+func (c *VideoClient) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	return c.client.ListJobs(ctx, req)
+}
+
+func (c *VideoClient) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.Webhook, error) {
+	return c.client.CreateWebhook(ctx, req)
+}
+
+func (c *VideoClient) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	return c.client.ListWebhooks(ctx, req)
+}
+
+func (c *VideoClient) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	return c.client.DeleteWebhook(ctx, req)
+}
+
+func (c *VideoClient) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	return c.client.ListWebhookDeliveries(ctx, req)
+}
+
+func (c *VideoClient) ReplayWebhookDelivery(ctx context.Context, req *pb.ReplayWebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	return c.client.ReplayWebhookDelivery(ctx, req)
+}
+
+func (c *VideoClient) Subscribe(ctx context.Context, req *pb.SubscribeRequest) (*pb.Subscription, error) {
+	return c.client.Subscribe(ctx, req)
+}
+
+func (c *VideoClient) Unsubscribe(ctx context.Context, req *pb.UnsubscribeRequest) (*pb.UnsubscribeResponse, error) {
+	return c.client.Unsubscribe(ctx, req)
+}
+
+func (c *VideoClient) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	return c.client.ListSubscriptions(ctx, req)
+}
+
+func (c *VideoClient) ConfirmPushSubscription(ctx context.Context, req *pb.ConfirmPushSubscriptionRequest) (*pb.ConfirmPushSubscriptionResponse, error) {
+	return c.client.ConfirmPushSubscription(ctx, req)
+}
+
+func (c *VideoClient) IngestChannelUploads(ctx context.Context, req *pb.IngestChannelUploadsRequest) (*pb.IngestChannelUploadsResponse, error) {
+	return c.client.IngestChannelUploads(ctx, req)
+}
+
+func (c *VideoClient) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.GetFeedResponse, error) {
+	return c.client.GetFeed(ctx, req)
+}
+
+func (c *VideoClient) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.Collection, error) {
+	return c.client.CreateCollection(ctx, req)
+}
+
+func (c *VideoClient) ListCollections(ctx context.Context, req *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	return c.client.ListCollections(ctx, req)
+}
+
+func (c *VideoClient) GetCollection(ctx context.Context, req *pb.GetCollectionRequest) (*pb.Collection, error) {
+	return c.client.GetCollection(ctx, req)
+}
+
+func (c *VideoClient) UpdateCollection(ctx context.Context, req *pb.UpdateCollectionRequest) (*pb.Collection, error) {
+	return c.client.UpdateCollection(ctx, req)
+}
+
+func (c *VideoClient) DeleteCollection(ctx context.Context, req *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	return c.client.DeleteCollection(ctx, req)
+}
+
+func (c *VideoClient) AddToCollection(ctx context.Context, req *pb.AddToCollectionRequest) (*pb.Collection, error) {
+	return c.client.AddToCollection(ctx, req)
+}
+
+func (c *VideoClient) RemoveFromCollection(ctx context.Context, req *pb.RemoveFromCollectionRequest) (*pb.Collection, error) {
+	return c.client.RemoveFromCollection(ctx, req)
+}
+
+func (c *VideoClient) SetVideoTags(ctx context.Context, req *pb.SetVideoTagsRequest) (*pb.VideoTags, error) {
+	return c.client.SetVideoTags(ctx, req)
+}
+
+func (c *VideoClient) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	return c.client.ListTags(ctx, req)
+}
+
+func (c *VideoClient) ExportCollection(ctx context.Context, req *pb.ExportCollectionRequest, opts ...grpc.CallOption) (*pb.ExportCollectionResponse, error) {
+	return c.client.ExportCollection(ctx, req, opts...)
+}
+
+func (c *VideoClient) CreateNote(ctx context.Context, req *pb.CreateNoteRequest) (*pb.Note, error) {
+	return c.client.CreateNote(ctx, req)
+}
+
+func (c *VideoClient) ListNotes(ctx context.Context, req *pb.ListNotesRequest) (*pb.ListNotesResponse, error) {
+	return c.client.ListNotes(ctx, req)
+}
+
+func (c *VideoClient) DeleteNote(ctx context.Context, req *pb.DeleteNoteRequest) (*pb.DeleteNoteResponse, error) {
+	return c.client.DeleteNote(ctx, req)
+}
+
+func (c *VideoClient) ExportVideo(ctx context.Context, req *pb.ExportVideoRequest, opts ...grpc.CallOption) (*pb.ExportVideoResponse, error) {
+	return c.client.ExportVideo(ctx, req, opts...)
+}
+
+func (c *VideoClient) GetFeedToken(ctx context.Context, req *pb.GetFeedTokenRequest) (*pb.FeedToken, error) {
+	return c.client.GetFeedToken(ctx, req)
+}
+
+func (c *VideoClient) GetSummaryFeed(ctx context.Context, req *pb.GetSummaryFeedRequest) (*pb.SummaryFeed, error) {
+	return c.client.GetSummaryFeed(ctx, req)
 }
 
 
@@ -0,0 +1,423 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type CollectionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type CollectionItemResponse struct {
+	VideoID      string   `json:"video_id"`
+	Title        string   `json:"title"`
+	ChannelID    string   `json:"channel_id"`
+	ChannelTitle string   `json:"channel_title"`
+	ThumbnailURL string   `json:"thumbnail_url"`
+	PublishedAt  string   `json:"published_at"`
+	AddedAt      string   `json:"added_at"`
+	Tags         []string `json:"tags"`
+}
+
+type CollectionResponse struct {
+	CollectionID string `json:"collection_id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	// Most recently added first. Left out when collections are listed.
+	Items      []CollectionItemResponse `json:"items"`
+	VideoCount int32                    `json:"video_count"`
+	CreatedAt  string                   `json:"created_at"`
+	UpdatedAt  string                   `json:"updated_at"`
+}
+
+type ListCollectionsResponse struct {
+	// Most recently updated first.
+	Collections []CollectionResponse `json:"collections"`
+}
+
+type AddToCollectionRequest struct {
+	VideoID string `json:"video_id"`
+}
+
+type VideoTagsRequest struct {
+	// Free-form tags, stored trimmed and lowercased. An empty list removes
+	// the video's tags.
+	Tags []string `json:"tags"`
+}
+
+type VideoTagsResponse struct {
+	VideoID   string   `json:"video_id"`
+	Tags      []string `json:"tags"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type TagCountResponse struct {
+	Tag    string `json:"tag"`
+	Videos int32  `json:"videos"`
+}
+
+type ListTagsResponse struct {
+	// Most used first.
+	Tags []TagCountResponse `json:"tags"`
+	// The videos with the requested tag, most recently tagged first.
+	Videos []VideoTagsResponse `json:"videos"`
+}
+
+// CreateCollection godoc
+// @Summary Create a collection
+// @Description Create a named list to save videos to. A user can have up to 100 collections.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param request body CollectionRequest true "Name and description"
+// @Success 200 {object} CollectionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/collections [post]
+func (h *VideoHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.videoClient.CreateCollection(r.Context(), &pb.CreateCollectionRequest{
+		UserId:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.sendCollectionError(w, "CreateCollection", err, "Failed to create collection")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collection)
+}
+
+// ListCollections godoc
+// @Summary List collections
+// @Description List the current user's collections with the number of videos in each, without the videos.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListCollectionsResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/collections [get]
+func (h *VideoHandler) ListCollections(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	resp, err := h.videoClient.ListCollections(r.Context(), &pb.ListCollectionsRequest{UserId: userID})
+	if err != nil {
+		log.Printf("ListCollections failure: %v", err)
+		h.sendJSONError(w, "Failed to list collections", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetCollection godoc
+// @Summary Get a collection
+// @Description Get a collection with its videos, most recently added first, and the tags the user gave them.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param collectionId path string true "Collection ID"
+// @Param tag query string false "Only list the videos with this tag"
+// @Success 200 {object} CollectionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/collections/{collectionId} [get]
+func (h *VideoHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	collection, err := h.videoClient.GetCollection(r.Context(), &pb.GetCollectionRequest{
+		UserId:       userID,
+		CollectionId: mux.Vars(r)["collectionId"],
+		Tag:          r.URL.Query().Get("tag"),
+	})
+	if err != nil {
+		h.sendCollectionError(w, "GetCollection", err, "Failed to get collection")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collection)
+}
+
+// UpdateCollection godoc
+// @Summary Rename a collection
+// @Description Replace the name and description of a collection.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param collectionId path string true "Collection ID"
+// @Param request body CollectionRequest true "Name and description"
+// @Success 200 {object} CollectionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/collections/{collectionId} [put]
+func (h *VideoHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.videoClient.UpdateCollection(r.Context(), &pb.UpdateCollectionRequest{
+		UserId:       userID,
+		CollectionId: mux.Vars(r)["collectionId"],
+		Name:         req.Name,
+		Description:  req.Description,
+	})
+	if err != nil {
+		h.sendCollectionError(w, "UpdateCollection", err, "Failed to update collection")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collection)
+}
+
+// DeleteCollection godoc
+// @Summary Delete a collection
+// @Description Delete a collection. The tags of its videos are kept.
+// @Tags collections
+// @Security ApiKeyAuth
+// @Param collectionId path string true "Collection ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/collections/{collectionId} [delete]
+func (h *VideoHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.DeleteCollection(r.Context(), &pb.DeleteCollectionRequest{
+		UserId:       userID,
+		CollectionId: mux.Vars(r)["collectionId"],
+	})
+	if err != nil {
+		h.sendCollectionError(w, "DeleteCollection", err, "Failed to delete collection")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddToCollection godoc
+// @Summary Save a video to a collection
+// @Description Save a video to a collection with its current title, channel and thumbnail. Saving a video that is already in the collection changes nothing. A collection holds up to 500 videos.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param collectionId path string true "Collection ID"
+// @Param request body AddToCollectionRequest true "Video to save"
+// @Success 200 {object} CollectionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/collections/{collectionId}/videos [post]
+func (h *VideoHandler) AddToCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req AddToCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.videoClient.AddToCollection(r.Context(), &pb.AddToCollectionRequest{
+		UserId:       userID,
+		CollectionId: mux.Vars(r)["collectionId"],
+		VideoId:      req.VideoID,
+	})
+	if err != nil {
+		h.sendCollectionError(w, "AddToCollection", err, "Failed to save video")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collection)
+}
+
+// RemoveFromCollection godoc
+// @Summary Remove a video from a collection
+// @Description Remove a video from a collection. The tags the user gave it are kept.
+// @Tags collections
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param collectionId path string true "Collection ID"
+// @Param videoId path string true "Video ID"
+// @Success 200 {object} CollectionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/collections/{collectionId}/videos/{videoId} [delete]
+func (h *VideoHandler) RemoveFromCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+	vars := mux.Vars(r)
+
+	collection, err := h.videoClient.RemoveFromCollection(r.Context(), &pb.RemoveFromCollectionRequest{
+		UserId:       userID,
+		CollectionId: vars["collectionId"],
+		VideoId:      vars["videoId"],
+	})
+	if err != nil {
+		h.sendCollectionError(w, "RemoveFromCollection", err, "Failed to remove video")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collection)
+}
+
+// ExportCollection godoc
+// @Summary Export a collection
+// @Description Download a collection with the default summary of each of its videos, as one Markdown document, a JSON bundle, standalone HTML laid out for printing to PDF or an EPUB book with a chapter per video. Cached summaries are reused; every other video counts against the summary quota. Videos that cannot be summarized are exported with the reason instead.
+// @Tags collections
+// @Produce  text/markdown,json,html,application/epub+zip
+// @Security ApiKeyAuth
+// @Param collectionId path string true "Collection ID"
+// @Param format query string false "Export format" Enums(md, json, html, epub) default(md)
+// @Param transcript query bool false "Include each video's transcript"
+// @Success 200 {file} file
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /api/collections/{collectionId}/export [get]
+func (h *VideoHandler) ExportCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	transcript, _ := strconv.ParseBool(r.URL.Query().Get("transcript"))
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.ExportCollection(r.Context(), &pb.ExportCollectionRequest{
+		UserId:       userID,
+		CollectionId: mux.Vars(r)["collectionId"],
+		Format:       r.URL.Query().Get("format"),
+		Transcript:   transcript,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		h.sendCollectionError(w, "ExportCollection", err, "Failed to export collection")
+		return
+	}
+
+	writeExport(w, resp.Filename, resp.ContentType, resp.Content)
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description List the tags the current user gave videos with how many videos have each, and, when a tag is given, the videos with it.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param tag query string false "Also list the videos with this tag"
+// @Success 200 {object} ListTagsResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/collections/tags [get]
+func (h *VideoHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	resp, err := h.videoClient.ListTags(r.Context(), &pb.ListTagsRequest{
+		UserId: userID,
+		Tag:    r.URL.Query().Get("tag"),
+	})
+	if err != nil {
+		log.Printf("ListTags failure: %v", err)
+		h.sendJSONError(w, "Failed to list tags", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SetVideoTags godoc
+// @Summary Tag a video
+// @Description Replace the tags the current user gave a video, whether or not it is in a collection. Tags are trimmed, lowercased and deduplicated; a video can have up to 20 tags of up to 50 characters.
+// @Tags collections
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Param request body VideoTagsRequest true "Tags"
+// @Success 200 {object} VideoTagsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/collections/tags/{videoId} [put]
+func (h *VideoHandler) SetVideoTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req VideoTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.videoClient.SetVideoTags(r.Context(), &pb.SetVideoTagsRequest{
+		UserId:  userID,
+		VideoId: mux.Vars(r)["videoId"],
+		Tags:    req.Tags,
+	})
+	if err != nil {
+		h.sendCollectionError(w, "SetVideoTags", err, "Failed to tag video")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+// sendCollectionError logs a failed collection RPC and answers with the
+// matching status, or with message for unexpected errors.
+func (h *VideoHandler) sendCollectionError(w http.ResponseWriter, rpc string, err error, message string) {
+	log.Printf("%s failure: %v", rpc, err)
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+	case codes.NotFound:
+		h.sendJSONError(w, status.Convert(err).Message(), http.StatusNotFound)
+	case codes.ResourceExhausted:
+		h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+	default:
+		h.sendJSONError(w, message, http.StatusInternalServerError)
+	}
+}
+
+// writeExport sends an exported document as a file download.
+func writeExport(w http.ResponseWriter, filename, contentType string, content []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Write(content)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type CompareVideosRequest struct {
+	// Between 2 and 10 video IDs.
+	VideoIDs []string `json:"video_ids"`
+	// An optional question or angle to compare the videos on.
+	Focus    string `json:"focus"`
+	Language string `json:"language"`
+}
+
+type VideoDigestItem struct {
+	VideoID      string `json:"video_id"`
+	Title        string `json:"title"`
+	ChannelTitle string `json:"channel_title"`
+	PublishedAt  string `json:"published_at,omitempty"`
+	Summary      string `json:"summary,omitempty"`
+	Error        string `json:"error,omitempty"`
+	// Whether the summary was reused rather than generated for this digest.
+	Cached      bool        `json:"cached,omitempty"`
+	GeneratedBy []ModelInfo `json:"generated_by,omitempty"`
+}
+
+type CompareVideosResponse struct {
+	// Markdown citing the videos as [1], [2], ... in the order of videos.
+	Digest      string            `json:"digest"`
+	Videos      []VideoDigestItem `json:"videos"`
+	GeneratedBy []ModelInfo       `json:"generated_by"`
+}
+
+// SummarizeVideos godoc
+// @Summary Compare several videos
+// @Description Summarize between 2 and 10 videos and write a digest of where they agree and differ, optionally focused on a question. Claims in the digest cite the videos as [1], [2], ... in the order of the videos list. Summaries cached from earlier requests are reused; every other video counts against the summary quota. Videos that cannot be summarized carry an error, are left out of the digest and are not counted.
+// @Tags videos
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param request body CompareVideosRequest true "Videos to compare"
+// @Success 200 {object} CompareVideosResponse
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "None of the videos could be summarized, or the model's filters blocked the digest"
+// @Failure 429 {object} ErrorResponse "The daily or monthly summary quota is used up"
+// @Router /api/videos/compare [post]
+func (h *VideoHandler) SummarizeVideos(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req CompareVideosRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.SummarizeVideos(r.Context(), &pb.SummarizeVideosRequest{
+		VideoIds: req.VideoIDs,
+		UserId:   userID,
+		Focus:    req.Focus,
+		Language: req.Language,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("SummarizeVideos failure: %v", err)
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		case codes.InvalidArgument:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		case codes.FailedPrecondition:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			return
+		}
+		h.sendJSONError(w, "Failed to compare videos", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+type ChannelDigestResponse struct {
+	ChannelID    string `json:"channel_id"`
+	ChannelTitle string `json:"channel_title"`
+	// Start of the window, RFC 3339.
+	Since string `json:"since"`
+	// Newsletter-style Markdown citing the uploads as [1], [2], ... in the
+	// order of videos. Empty when there were no uploads.
+	Digest      string            `json:"digest"`
+	Videos      []VideoDigestItem `json:"videos"`
+	GeneratedBy []ModelInfo       `json:"generated_by"`
+}
+
+// SummarizeChannel godoc
+// @Summary Digest a channel's recent uploads
+// @Description Summarize the uploads of a channel in the last few days, newest first, and write a newsletter-style digest of them that cites each as [1], [2], ... in the order of the videos list. Summaries cached from earlier requests are reused; every other upload counts against the summary quota. With no uploads in the window the digest is empty.
+// @Tags videos
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param channelId path string true "Channel ID"
+// @Param days query int false "How many days back to look, up to 31" default(7)
+// @Param max_videos query int false "Most recent uploads to include, up to 20" default(10)
+// @Param language query string false "Language to write the digest in"
+// @Success 200 {object} ChannelDigestResponse
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "None of the uploads could be summarized, or the model's filters blocked the digest"
+// @Failure 429 {object} ErrorResponse "The daily or monthly summary quota is used up"
+// @Router /api/videos/channel/{channelId}/digest [get]
+func (h *VideoHandler) SummarizeChannel(w http.ResponseWriter, r *http.Request) {
+	channelID := mux.Vars(r)["channelId"]
+	userID := r.Context().Value("user_id").(string)
+
+	req, err := channelDigestRequest(r, channelID, userID)
+	if err != nil {
+		h.sendJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.SummarizeChannel(r.Context(), req, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("SummarizeChannel failure: %v", err)
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		case codes.InvalidArgument:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		case codes.FailedPrecondition:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			return
+		}
+		h.sendJSONError(w, "Failed to summarize channel", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// channelDigestRequest reads the digest options shared by the API and the
+// SSR page from the query string.
+func channelDigestRequest(r *http.Request, channelID, userID string) (*pb.SummarizeChannelRequest, error) {
+	req := &pb.SummarizeChannelRequest{
+		ChannelId: channelID,
+		UserId:    userID,
+		Language:  r.URL.Query().Get("language"),
+	}
+	for param, dst := range map[string]*int32{"days": &req.Days, "max_videos": &req.MaxVideos} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", param)
+		}
+		*dst = int32(n)
+	}
+	return req, nil
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ExportVideo godoc
+// @Summary Export a video
+// @Description Download a video's default summary as a document: Markdown with YAML front matter for Obsidian, a Notion-style JSON page, standalone HTML laid out for printing to PDF, or an EPUB book. The document has the title, channel, URL, publish date and duration, the summary, the chapters, the current user's tags and notes and, when asked for, the transcript with timestamps. A cached summary is reused; otherwise summarizing counts against the summary quota.
+// @Tags videos
+// @Produce  text/markdown,json,html,application/epub+zip
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Param format query string false "Export format" Enums(md, json, html, epub) default(md)
+// @Param transcript query bool false "Include the full transcript"
+// @Success 200 {file} file
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/videos/{videoId}/export [get]
+func (h *VideoHandler) ExportVideo(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.ExportVideo(r.Context(), exportVideoRequest(r, userID), grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		h.sendCollectionError(w, "ExportVideo", err, "Failed to export video")
+		return
+	}
+
+	writeExport(w, resp.Filename, resp.ContentType, resp.Content)
+}
+
+// ExportVideo downloads a video's summary as a document, returning to the
+// video's page with the reason when it fails.
+func (h *SSRHandler) ExportVideo(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var header, trailer metadata.MD
+	req := exportVideoRequest(r, userID)
+	resp, err := h.videoClient.ExportVideo(r.Context(), req, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("Export video error: %v", err)
+		target := "/video/" + req.VideoId + "?export_error=" + url.QueryEscape(status.Convert(err).Message())
+		http.Redirect(w, r, target, http.StatusSeeOther)
+		return
+	}
+	writeExport(w, resp.Filename, resp.ContentType, resp.Content)
+}
+
+// exportVideoRequest reads the export format and transcript flag from the
+// query string.
+func exportVideoRequest(r *http.Request, userID string) *pb.ExportVideoRequest {
+	transcript, _ := strconv.ParseBool(r.URL.Query().Get("transcript"))
+	return &pb.ExportVideoRequest{
+		UserId:     userID,
+		VideoId:    mux.Vars(r)["videoId"],
+		Format:     r.URL.Query().Get("format"),
+		Transcript: transcript,
+	}
+}
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Summary feed formats.
+const (
+	feedRSS  = "rss"
+	feedAtom = "atom"
+)
+
+type FeedTokenResponse struct {
+	// The secret in the feed URLs. Anyone with it can read the feed.
+	Token   string `json:"token"`
+	RSSURL  string `json:"rss_url"`
+	AtomURL string `json:"atom_url"`
+	// RFC 3339.
+	CreatedAt string `json:"created_at"`
+}
+
+// GetFeedToken godoc
+// @Summary Get the summary feed URLs
+// @Description Get the RSS and Atom URLs of the current user's summary feed: the recent uploads of the channels they follow that have been summarized, with the summaries as content. The URLs carry a secret token instead of credentials so that feed readers can fetch them; it is created on first use.
+// @Tags subscriptions
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} FeedTokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/feed/token [get]
+func (h *VideoHandler) GetFeedToken(w http.ResponseWriter, r *http.Request) {
+	h.feedToken(w, r, false)
+}
+
+// RotateFeedToken godoc
+// @Summary Replace the summary feed URLs
+// @Description Replace the secret token in the current user's summary feed URLs, for when they have leaked. The old URLs stop working.
+// @Tags subscriptions
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} FeedTokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/feed/token [post]
+func (h *VideoHandler) RotateFeedToken(w http.ResponseWriter, r *http.Request) {
+	h.feedToken(w, r, true)
+}
+
+func (h *VideoHandler) feedToken(w http.ResponseWriter, r *http.Request, rotate bool) {
+	userID := r.Context().Value("user_id").(string)
+
+	token, err := h.videoClient.GetFeedToken(r.Context(), &pb.GetFeedTokenRequest{UserId: userID, Rotate: rotate})
+	if err != nil {
+		log.Printf("GetFeedToken failure: %v", err)
+		h.sendJSONError(w, "Failed to get feed token", http.StatusInternalServerError)
+		return
+	}
+
+	feedURL := h.baseURL(r) + "/feeds/users/" + url.PathEscape(token.Token)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(FeedTokenResponse{
+		Token:     token.Token,
+		RSSURL:    feedURL,
+		AtomURL:   feedURL + "?format=atom",
+		CreatedAt: token.CreatedAt,
+	})
+}
+
+// UserSummaryFeed godoc
+// @Summary Get a user's summary feed
+// @Description Get the recent uploads of the channels a user follows that have a summary, newest first, as an RSS or Atom feed with the summaries as item content. Nothing is summarized when the feed is fetched: summaries come from summarizing videos and from following channels with prefetch set to summary. The token comes from /api/feed/token; no other authentication is needed.
+// @Tags feeds
+// @Produce  application/rss+xml,application/atom+xml
+// @Param token path string true "Feed token"
+// @Param format query string false "Feed format" Enums(rss, atom) default(rss)
+// @Param limit query int false "Maximum number of items, 1-50" default(20)
+// @Success 200 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Router /feeds/users/{token} [get]
+func (h *VideoHandler) UserSummaryFeed(w http.ResponseWriter, r *http.Request) {
+	h.summaryFeed(w, r, &pb.GetSummaryFeedRequest{FeedToken: mux.Vars(r)["token"]})
+}
+
+// ChannelSummaryFeed godoc
+// @Summary Get a channel's summary feed
+// @Description Get the recent uploads of a channel that someone follows that have a summary, newest first, as an RSS or Atom feed with the summaries as item content. Nothing is summarized when the feed is fetched. Channels nobody follows have no feed. No authentication is needed.
+// @Tags feeds
+// @Produce  application/rss+xml,application/atom+xml
+// @Param channelId path string true "Channel ID"
+// @Param format query string false "Feed format" Enums(rss, atom) default(rss)
+// @Param limit query int false "Maximum number of items, 1-50" default(20)
+// @Success 200 {string} string
+// @Failure 400 {string} string
+// @Failure 404 {string} string
+// @Router /feeds/channels/{channelId} [get]
+func (h *VideoHandler) ChannelSummaryFeed(w http.ResponseWriter, r *http.Request) {
+	h.summaryFeed(w, r, &pb.GetSummaryFeedRequest{ChannelId: mux.Vars(r)["channelId"]})
+}
+
+func (h *VideoHandler) summaryFeed(w http.ResponseWriter, r *http.Request, req *pb.GetSummaryFeedRequest) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = feedRSS
+	}
+	if format != feedRSS && format != feedAtom {
+		http.Error(w, "Unknown format", http.StatusBadRequest)
+		return
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		req.Limit = int32(n)
+	}
+
+	feed, err := h.videoClient.GetSummaryFeed(r.Context(), req)
+	if err != nil {
+		log.Printf("GetSummaryFeed failure: %v", err)
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		case codes.NotFound:
+			http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to load feed", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	site := h.baseURL(r)
+	info := feedInfo{
+		Title:       "TextTube: your channels",
+		Description: "Summaries of the latest uploads of the channels you follow on TextTube.",
+		SelfURL:     site + r.URL.RequestURI(),
+		SiteURL:     site,
+	}
+	if req.ChannelId != "" {
+		name := feed.ChannelTitle
+		if name == "" {
+			name = feed.ChannelId
+		}
+		info.Title = "TextTube: " + name
+		info.Description = "Summaries of the latest uploads of " + name + " on TextTube."
+		info.SiteURL = site + "/channel/" + feed.ChannelId + "/digest"
+	}
+
+	var body []byte
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == feedAtom {
+		body, err = renderAtom(info, site, feed.Items)
+		contentType = "application/atom+xml; charset=utf-8"
+	} else {
+		body, err = renderRSS(info, site, feed.Items)
+	}
+	if err != nil {
+		log.Printf("Feed rendering error: %v", err)
+		http.Error(w, "Failed to render feed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	// A user's feed is only for whoever holds its token, so shared caches
+	// must not keep it.
+	if req.FeedToken != "" {
+		w.Header().Set("Cache-Control", "private, max-age=300")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+	}
+	w.Write(body)
+}
+
+// baseURL returns the configured public base URL or, without one, the
+// scheme and host the gateway was reached at, as seen by the client when
+// behind a proxy. Forwarded headers are trusted only then, as anyone can set
+// them and feeds are cached by shared caches.
+func (h *VideoHandler) baseURL(r *http.Request) string {
+	if h.publicBaseURL != "" {
+		return h.publicBaseURL
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	host := r.Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = r.Host
+	}
+	return scheme + "://" + host
+}
+
+// feedInfo describes a summary feed apart from its items.
+type feedInfo struct {
+	Title       string
+	Description string
+	// SelfURL is where the feed is fetched from, SiteURL the page it is
+	// the feed of.
+	SelfURL string
+	SiteURL string
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name   `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string     `xml:"title"`
+	ID      string     `xml:"id"`
+	Updated string     `xml:"updated"`
+	Links   []atomLink `xml:"link"`
+	Entries []atomItem `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomItem struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Published string      `xml:"published,omitempty"`
+	Updated   string      `xml:"updated"`
+	Link      atomLink    `xml:"link"`
+	Author    atomAuthor  `xml:"author"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// renderRSS writes an RSS 2.0 feed of items. site is the gateway's URL,
+// for links to TextTube.
+func renderRSS(info feedInfo, site string, items []*pb.SummaryFeedItem) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       info.Title,
+			Link:        info.SiteURL,
+			Description: info.Description,
+			Self:        atomLink{Href: info.SelfURL, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if updated := feedUpdated(items); !updated.IsZero() {
+		doc.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+	for _, item := range items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        youtubeURL(item.VideoId),
+			GUID:        rssGUID{Value: "texttube:video:" + item.VideoId},
+			Category:    item.ChannelTitle,
+			Description: feedItemHTML(site, item),
+		}
+		if published, err := time.Parse(time.RFC3339, item.PublishedAt); err == nil {
+			entry.PubDate = published.Format(time.RFC1123Z)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+	return marshalFeed(doc)
+}
+
+// renderAtom writes an Atom feed of items. site is the gateway's URL, for
+// links to TextTube.
+func renderAtom(info feedInfo, site string, items []*pb.SummaryFeedItem) ([]byte, error) {
+	updated := feedUpdated(items)
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	feed := atomDocument{
+		Title:   info.Title,
+		ID:      info.SelfURL,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: info.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: info.SiteURL, Rel: "alternate", Type: "text/html"},
+		},
+	}
+	for _, item := range items {
+		entry := atomItem{
+			Title:   item.Title,
+			ID:      "urn:texttube:video:" + item.VideoId,
+			Updated: item.SummarizedAt,
+			Link:    atomLink{Href: youtubeURL(item.VideoId), Rel: "alternate", Type: "text/html"},
+			Author:  atomAuthor{Name: item.ChannelTitle},
+			Content: atomContent{Type: "html", Value: feedItemHTML(site, item)},
+		}
+		if _, err := time.Parse(time.RFC3339, item.PublishedAt); err == nil {
+			entry.Published = item.PublishedAt
+		}
+		if entry.Updated == "" {
+			entry.Updated = feed.Updated
+		}
+		if entry.Author.Name == "" {
+			entry.Author.Name = "YouTube"
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return marshalFeed(feed)
+}
+
+func marshalFeed(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// feedUpdated returns when the newest summary in a feed was generated, or
+// the zero time for an empty feed.
+func feedUpdated(items []*pb.SummaryFeedItem) time.Time {
+	var updated time.Time
+	for _, item := range items {
+		if t, err := time.Parse(time.RFC3339, item.SummarizedAt); err == nil && t.After(updated) {
+			updated = t
+		}
+	}
+	return updated
+}
+
+func youtubeURL(videoID string) string {
+	return "https://www.youtube.com/watch?v=" + url.QueryEscape(videoID)
+}
+
+var feedItemTemplate = template.Must(template.New("item").Parse(
+	`{{if .Item.ThumbnailUrl}}<p><img src="{{.Item.ThumbnailUrl}}" alt=""></p>{{end}}` +
+		`{{.Summary}}` +
+		`<p><a href="{{.Site}}/video/{{.Item.VideoId}}">Open in TextTube</a> · <a href="{{.Watch}}">Watch on YouTube</a></p>`))
+
+// feedItemHTML renders the content of a feed item: the video's thumbnail,
+// its summary as sanitized HTML and links to it.
+func feedItemHTML(site string, item *pb.SummaryFeedItem) string {
+	var buf bytes.Buffer
+	err := feedItemTemplate.Execute(&buf, map[string]interface{}{
+		"Item":    item,
+		"Summary": renderMarkdown(item.Summary),
+		"Site":    site,
+		"Watch":   youtubeURL(item.VideoId),
+	})
+	if err != nil {
+		log.Printf("Feed item rendering error: %v", err)
+		return template.HTMLEscapeString(item.Summary)
+	}
+	return buf.String()
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+)
+
+var testFeedItems = []*pb.SummaryFeedItem{
+	{
+		VideoId:      "dQw4w9WgXcQ",
+		Title:        "Rock & roll",
+		ChannelId:    testChannelID,
+		ChannelTitle: "Test Channel",
+		ThumbnailUrl: "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg",
+		PublishedAt:  "2026-10-18T09:00:00Z",
+		Summary:      "## Key points\n\n- **Never** gonna give you up\n\n<script>alert(1)</script>",
+		SummarizedAt: "2026-10-18T10:00:00Z",
+	},
+	{VideoId: "undatedVid1", Title: "Undated", Summary: "Short"},
+}
+
+var testFeedInfo = feedInfo{
+	Title:       "TextTube: your channels",
+	Description: "Summaries",
+	SelfURL:     "https://texttube.example/feeds/users/secret",
+	SiteURL:     "https://texttube.example",
+}
+
+func TestRenderRSS(t *testing.T) {
+	body, err := renderRSS(testFeedInfo, "https://texttube.example", testFeedItems)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc struct {
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Self          struct {
+				Href string `xml:"href,attr"`
+			} `xml:"http://www.w3.org/2005/Atom link"`
+			Items []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				GUID        string `xml:"guid"`
+				PubDate     string `xml:"pubDate"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("Expected valid XML, got %v\n%s", err, body)
+	}
+	if doc.Channel.Title != "TextTube: your channels" || doc.Channel.Self.Href != testFeedInfo.SelfURL {
+		t.Errorf("Expected the feed's title and self link, got %+v", doc.Channel)
+	}
+	if doc.Channel.LastBuildDate != "Sun, 18 Oct 2026 10:00:00 +0000" {
+		t.Errorf("Expected the newest summary's date, got %q", doc.Channel.LastBuildDate)
+	}
+	if len(doc.Channel.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(doc.Channel.Items))
+	}
+	item := doc.Channel.Items[0]
+	if item.Title != "Rock & roll" || item.Link != "https://www.youtube.com/watch?v=dQw4w9WgXcQ" || item.GUID != "texttube:video:dQw4w9WgXcQ" {
+		t.Errorf("Expected the video's title, link and GUID, got %+v", item)
+	}
+	if item.PubDate != "Sun, 18 Oct 2026 09:00:00 +0000" {
+		t.Errorf("Expected the publish date, got %q", item.PubDate)
+	}
+	for _, want := range []string{"<strong>Never</strong>", `<img src="https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg"`, `href="https://texttube.example/video/dQw4w9WgXcQ"`} {
+		if !strings.Contains(item.Description, want) {
+			t.Errorf("Expected the content to contain %q, got %s", want, item.Description)
+		}
+	}
+	if strings.Contains(item.Description, "<script>") {
+		t.Errorf("Expected scripts removed from the summary, got %s", item.Description)
+	}
+	if doc.Channel.Items[1].PubDate != "" {
+		t.Errorf("Expected no date for an undated video, got %q", doc.Channel.Items[1].PubDate)
+	}
+}
+
+func TestRenderAtom(t *testing.T) {
+	body, err := renderAtom(testFeedInfo, "https://texttube.example", testFeedItems)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var feed struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Published string `xml:"published"`
+			Updated   string `xml:"updated"`
+			Author    string `xml:"author>name"`
+			Content   struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("Expected an Atom feed, got %v\n%s", err, body)
+	}
+	if feed.ID != testFeedInfo.SelfURL || feed.Updated != "2026-10-18T10:00:00Z" || len(feed.Entries) != 2 {
+		t.Fatalf("Expected the feed's id, date and entries, got %+v", feed)
+	}
+	entry := feed.Entries[0]
+	if entry.ID != "urn:texttube:video:dQw4w9WgXcQ" || entry.Published != "2026-10-18T09:00:00Z" || entry.Updated != "2026-10-18T10:00:00Z" || entry.Author != "Test Channel" {
+		t.Errorf("Expected the video's entry, got %+v", entry)
+	}
+	if entry.Content.Type != "html" || !strings.Contains(entry.Content.Value, "<strong>Never</strong>") {
+		t.Errorf("Expected the summary as HTML, got %+v", entry.Content)
+	}
+	if undated := feed.Entries[1]; undated.Published != "" || undated.Updated != feed.Updated || undated.Author == "" {
+		t.Errorf("Expected required fields filled in for an undated video, got %+v", undated)
+	}
+}
+
+// fakeFeedService stands in for the video service's summary feed RPC.
+type fakeFeedService struct {
+	pb.UnimplementedVideoServiceServer
+}
+
+func (f *fakeFeedService) GetSummaryFeed(ctx context.Context, req *pb.GetSummaryFeedRequest) (*pb.SummaryFeed, error) {
+	return &pb.SummaryFeed{ChannelId: req.ChannelId, Items: testFeedItems}, nil
+}
+
+func TestSummaryFeedCaching(t *testing.T) {
+	vh := NewVideoHandler(newTestVideoClient(t, &fakeFeedService{}), "")
+	r := mux.NewRouter()
+	r.HandleFunc("/feeds/users/{token}", vh.UserSummaryFeed).Methods("GET")
+	r.HandleFunc("/feeds/channels/{channelId}", vh.ChannelSummaryFeed).Methods("GET")
+
+	for path, want := range map[string]string{
+		"/feeds/users/secret":              "private, max-age=300",
+		"/feeds/channels/" + testChannelID: "public, max-age=300",
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200 for %s, got %d: %s", path, w.Code, w.Body)
+		}
+		if got := w.Header().Get("Cache-Control"); got != want {
+			t.Errorf("Expected Cache-Control %q for %s, got %q", want, path, got)
+		}
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	h := &VideoHandler{}
+	r := httptest.NewRequest("GET", "http://gateway:8080/feeds/users/secret", nil)
+	if got := h.baseURL(r); got != "http://gateway:8080" {
+		t.Errorf("Expected the request's host, got %q", got)
+	}
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "texttube.example")
+	if got := h.baseURL(r); got != "https://texttube.example" {
+		t.Errorf("Expected the proxy's scheme and host, got %q", got)
+	}
+
+	h = NewVideoHandler(nil, "https://texttube.example.com/")
+	r.Header.Set("X-Forwarded-Host", "attacker.example")
+	if got := h.baseURL(r); got != "https://texttube.example.com" {
+		t.Errorf("Expected the configured base URL, got %q", got)
+	}
+}
+
+func TestSummaryFeedPublicBaseURL(t *testing.T) {
+	vh := NewVideoHandler(newTestVideoClient(t, &fakeFeedService{}), "https://texttube.example.com")
+	r := httptest.NewRequest("GET", "/feeds/channels/"+testChannelID, nil)
+	r.Header.Set("X-Forwarded-Host", "attacker.example")
+	router := mux.NewRouter()
+	router.HandleFunc("/feeds/channels/{channelId}", vh.ChannelSummaryFeed)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if body := w.Body.String(); strings.Contains(body, "attacker.example") || !strings.Contains(body, "https://texttube.example.com/") {
+		t.Errorf("Expected links to the configured base URL, got %s", body)
+	}
+}
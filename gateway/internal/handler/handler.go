@@ -32,6 +32,7 @@ type AuthResponse struct {
 	Token    string `json:"token"`
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
+	Plan     string `json:"plan"`
 }
 
 type HealthResponse struct {
@@ -46,6 +47,7 @@ type ErrorResponse struct {
 type ProfileResponse struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
+	Plan     string `json:"plan"`
 }
 
 // HealthCheck godoc
@@ -103,6 +105,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		"token":    resp.Token,
 		"user_id":  resp.UserId,
 		"username": resp.Username,
+		"plan":     resp.Plan,
 	})
 }
 
@@ -140,6 +143,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		"token":    resp.Token,
 		"user_id":  resp.UserId,
 		"username": resp.Username,
+		"plan":     resp.Plan,
 	})
 }
 
@@ -155,10 +159,12 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(string)
 	username := r.Context().Value("username").(string)
+	plan, _ := r.Context().Value("plan").(string)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"user_id":  userID,
 		"username": username,
+		"plan":     plan,
 	})
 }
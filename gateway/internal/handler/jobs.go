@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type JobRequest struct {
+	// Only "summary" is supported; it is the default.
+	Type       string `json:"type"`
+	VideoID    string `json:"video_id"`
+	Style      string `json:"style"`
+	Length     string `json:"length"`
+	Language   string `json:"language"`
+	Structured bool   `json:"structured"`
+}
+
+type JobResponse struct {
+	JobID     string             `json:"job_id"`
+	Type      string             `json:"type"`
+	VideoID   string             `json:"video_id"`
+	Status    string             `json:"status" enums:"queued,running,succeeded,failed"`
+	Progress  int32              `json:"progress"`
+	Attempts  int32              `json:"attempts"`
+	Error     string             `json:"error,omitempty"`
+	Summary   *SummarizeResponse `json:"summary,omitempty"`
+	CreatedAt string             `json:"created_at"`
+	UpdatedAt string             `json:"updated_at"`
+}
+
+type ListJobsResponse struct {
+	Jobs []JobResponse `json:"jobs"`
+}
+
+// SubmitJob godoc
+// @Summary Submit a background job
+// @Description Queue a summary to be generated in the background and return the job at once. Poll the job's URL, given in the Location header, for its status, progress and result. The summary counts against the quota when submitted and is given back if the job fails.
+// @Tags jobs
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param request body JobRequest true "Job"
+// @Success 202 {object} JobResponse
+// @Header 202 {string} Location "URL of the job"
+// @Header 202,429 {string} X-Quota-Plan "The user's plan"
+// @Header 202,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 202,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 202,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse "The daily or monthly summary quota is used up"
+// @Failure 503 {object} ErrorResponse "Too many jobs are queued"
+// @Router /api/jobs [post]
+func (h *VideoHandler) SubmitJob(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Type != "" && req.Type != "summary" {
+		h.sendJSONError(w, "Unsupported job type "+strconv.Quote(req.Type), http.StatusBadRequest)
+		return
+	}
+
+	var header, trailer metadata.MD
+	job, err := h.videoClient.SubmitSummaryJob(r.Context(), &pb.SummarizeVideoRequest{
+		VideoId:    req.VideoID,
+		UserId:     userID,
+		Style:      req.Style,
+		Length:     req.Length,
+		Language:   req.Language,
+		Structured: req.Structured,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("SubmitJob failure: %v", err)
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		case codes.InvalidArgument:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		case codes.Unavailable:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusServiceUnavailable)
+			return
+		}
+		h.sendJSONError(w, "Failed to submit job", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/jobs/"+job.JobId)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// GetJob godoc
+// @Summary Get a background job
+// @Description Get the status and progress of one of the current user's jobs, and its result once it has succeeded
+// @Tags jobs
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} JobResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/jobs/{jobId} [get]
+func (h *VideoHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["jobId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	job, err := h.videoClient.GetJob(r.Context(), &pb.GetJobRequest{
+		JobId:  jobID,
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("GetJob failure: %v", err)
+		if status.Code(err) == codes.NotFound {
+			h.sendJSONError(w, "Job not found", http.StatusNotFound)
+			return
+		}
+		h.sendJSONError(w, "Failed to get job", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// ListJobs godoc
+// @Summary List background jobs
+// @Description List the current user's most recent jobs, newest first
+// @Tags jobs
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param status query string false "Only jobs in this status" Enums(queued, running, succeeded, failed)
+// @Param limit query int false "Number of jobs to return, at most 100" default(20)
+// @Success 200 {object} ListJobsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/jobs [get]
+func (h *VideoHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var limit int
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			h.sendJSONError(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.videoClient.ListJobs(r.Context(), &pb.ListJobsRequest{
+		UserId: userID,
+		Status: r.URL.Query().Get("status"),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		log.Printf("ListJobs failure: %v", err)
+		if status.Code(err) == codes.InvalidArgument {
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		h.sendJSONError(w, "Failed to list jobs", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
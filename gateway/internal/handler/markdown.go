@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// markdown renders the GitHub-flavoured Markdown the LLMs write. Raw HTML in
+// the source is dropped rather than passed through.
+var markdown = goldmark.New(goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify))
+
+// markdownPolicy is the allowlist the rendered HTML is sanitized with:
+// formatting, lists, tables, code and links, but no scripts, styles, images
+// or event handlers. Links may only be http(s) or mailto and open in a new
+// tab without access to the page.
+var markdownPolicy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"strong", "em", "del", "blockquote", "ul", "ol", "li", "pre", "code",
+		"table", "thead", "tbody", "tr", "th", "td")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("align").Matching(bluemonday.CellAlign).OnElements("th", "td")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}()
+
+// renderMarkdown turns Markdown from an LLM into sanitized HTML that
+// templates can output as is.
+func renderMarkdown(src string) template.HTML {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(src), &buf); err != nil {
+		log.Printf("Markdown rendering error: %v", err)
+		return template.HTML(template.HTMLEscapeString(src))
+	}
+	return template.HTML(markdownPolicy.SanitizeBytes(buf.Bytes()))
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	t.Run("Formatting", func(t *testing.T) {
+		got := string(renderMarkdown("## Key points\n\n- **Go** is *fast*\n- see https://go.dev\n\n| a | b |\n|---|---|\n| 1 | 2 |"))
+		for _, want := range []string{"<h2>Key points</h2>", "<li><strong>Go</strong> is <em>fast</em></li>", `<a href="https://go.dev" rel="noreferrer noopener" target="_blank">`, "<td>1</td>"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("Expected %q in:\n%s", want, got)
+			}
+		}
+	})
+
+	t.Run("Sanitized", func(t *testing.T) {
+		got := string(renderMarkdown("<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>\n\n[click](javascript:alert(1)) ![x](https://evil.example/p.gif) <b onclick=\"x()\">bold</b>"))
+		for _, bad := range []string{"<script", "<img", "onerror", "onclick", "javascript:"} {
+			if strings.Contains(got, bad) {
+				t.Errorf("Expected %q removed, got:\n%s", bad, got)
+			}
+		}
+		if !strings.Contains(got, "click") {
+			t.Errorf("Expected the link text kept, got:\n%s", got)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+)
+
+type NoteRequest struct {
+	StartSeconds float64 `json:"start_seconds"`
+	// Defaults to start_seconds.
+	EndSeconds float64 `json:"end_seconds"`
+	// The highlighted transcript text.
+	Quote string `json:"quote"`
+	Text  string `json:"text"`
+}
+
+type NoteResponse struct {
+	NoteID       string  `json:"note_id"`
+	VideoID      string  `json:"video_id"`
+	StartSeconds float64 `json:"start_seconds"`
+	EndSeconds   float64 `json:"end_seconds"`
+	Quote        string  `json:"quote"`
+	Text         string  `json:"text"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+type ListNotesResponse struct {
+	Notes []NoteResponse `json:"notes"`
+}
+
+// CreateNote godoc
+// @Summary Add a note to a video
+// @Description Highlight a stretch of a video's transcript with a quote of it, a note of your own, or both. Notes are private, shown on the video page, given as context when asking the video questions and included in collection exports. A video can have up to 200 notes per user.
+// @Tags notes
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Param request body NoteRequest true "Timestamp range, quote and text"
+// @Success 200 {object} NoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/videos/{videoId}/notes [post]
+func (h *VideoHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req NoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	note, err := h.videoClient.CreateNote(r.Context(), &pb.CreateNoteRequest{
+		UserId:       userID,
+		VideoId:      mux.Vars(r)["videoId"],
+		StartSeconds: req.StartSeconds,
+		EndSeconds:   req.EndSeconds,
+		Quote:        req.Quote,
+		Text:         req.Text,
+	})
+	if err != nil {
+		h.sendCollectionError(w, "CreateNote", err, "Failed to save note")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(note)
+}
+
+// ListVideoNotes godoc
+// @Summary List a video's notes
+// @Description List the current user's notes on a video in transcript order.
+// @Tags notes
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Success 200 {object} ListNotesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/videos/{videoId}/notes [get]
+func (h *VideoHandler) ListVideoNotes(w http.ResponseWriter, r *http.Request) {
+	h.listNotes(w, r, mux.Vars(r)["videoId"])
+}
+
+// ListNotes godoc
+// @Summary List notes
+// @Description List all of the current user's notes, most recent first.
+// @Tags notes
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListNotesResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/notes [get]
+func (h *VideoHandler) ListNotes(w http.ResponseWriter, r *http.Request) {
+	h.listNotes(w, r, "")
+}
+
+func (h *VideoHandler) listNotes(w http.ResponseWriter, r *http.Request, videoID string) {
+	userID := r.Context().Value("user_id").(string)
+
+	resp, err := h.videoClient.ListNotes(r.Context(), &pb.ListNotesRequest{UserId: userID, VideoId: videoID})
+	if err != nil {
+		h.sendCollectionError(w, "ListNotes", err, "Failed to list notes")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteNote godoc
+// @Summary Delete a note
+// @Description Delete one of the current user's notes.
+// @Tags notes
+// @Security ApiKeyAuth
+// @Param noteId path string true "Note ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/notes/{noteId} [delete]
+func (h *VideoHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.DeleteNote(r.Context(), &pb.DeleteNoteRequest{
+		UserId: userID,
+		NoteId: mux.Vars(r)["noteId"],
+	})
+	if err != nil {
+		h.sendCollectionError(w, "DeleteNote", err, "Failed to delete note")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
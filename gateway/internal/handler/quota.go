@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// quotaHeaders maps the video service's quota metadata to response headers.
+var quotaHeaders = map[string]string{
+	"x-quota-plan":      "X-Quota-Plan",
+	"x-quota-limit":     "X-Quota-Limit",
+	"x-quota-remaining": "X-Quota-Remaining",
+	"x-quota-reset":     "X-Quota-Reset",
+}
+
+// writeQuotaHeaders copies the caller's quota state from the gRPC headers or
+// trailers of a video service call to the response. When the call failed
+// because the quota is used up it also sets Retry-After.
+func writeQuotaHeaders(w http.ResponseWriter, err error, mds ...metadata.MD) {
+	for _, md := range mds {
+		for key, header := range quotaHeaders {
+			if values := md.Get(key); len(values) > 0 {
+				w.Header().Set(header, values[0])
+			}
+		}
+	}
+
+	// A call can succeed with nothing remaining, and its response must not
+	// tell the client to back off.
+	if status.Code(err) != codes.ResourceExhausted {
+		return
+	}
+	reset, err := strconv.ParseInt(w.Header().Get("X-Quota-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+	}
+}
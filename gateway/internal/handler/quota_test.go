@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestWriteQuotaHeaders(t *testing.T) {
+	md := metadata.Pairs(
+		"x-quota-plan", "free",
+		"x-quota-limit", "10",
+		"x-quota-remaining", "0",
+		"x-quota-reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+	)
+
+	w := httptest.NewRecorder()
+	writeQuotaHeaders(w, nil, md)
+	if w.Header().Get("X-Quota-Remaining") != "0" || w.Header().Get("X-Quota-Plan") != "free" {
+		t.Errorf("Expected the quota headers, got %v", w.Header())
+	}
+	if got := w.Header().Get("Retry-After"); got != "" {
+		t.Errorf("Expected no Retry-After when the call used the last of the quota, got %q", got)
+	}
+
+	w = httptest.NewRecorder()
+	writeQuotaHeaders(w, status.Error(codes.ResourceExhausted, "quota exceeded"), nil, md)
+	if seconds, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || seconds < 3500 || seconds > 3601 {
+		t.Errorf("Expected Retry-After of about an hour, got %q", w.Header().Get("Retry-After"))
+	}
+}
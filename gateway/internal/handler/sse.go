@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"gateway/internal/client"
+	"io"
+	"log"
+	"net/http"
+
+	pb "shared/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type SummaryChunkEvent struct {
+	Delta string `json:"delta"`
+}
+
+type SummaryDoneEvent struct {
+	VideoID     string                `json:"video_id"`
+	Summary     string                `json:"summary"`
+	SummaryHTML string                `json:"summary_html"` // Summary rendered to sanitized HTML
+	Structured  *pb.StructuredSummary `json:"structured,omitempty"`
+	GeneratedBy []*pb.ModelInfo       `json:"generated_by"`
+}
+
+// streamSummary relays SummarizeVideoStream to the browser as Server-Sent
+// Events: "chunk" events carry new text, a final "done" event carries the
+// complete summary and an "error" event ends the stream on failure. A used up
+// quota is answered with a plain 429 instead, before the stream starts.
+func streamSummary(w http.ResponseWriter, r *http.Request, videoClient *client.VideoClient, req *pb.SummarizeVideoRequest) {
+	stream, err := videoClient.SummarizeVideoStream(r.Context(), req)
+	if err == nil {
+		// Header is nil when the call failed before sending any headers,
+		// which is how an exhausted quota is reported.
+		var header metadata.MD
+		if header, err = stream.Header(); header == nil && err == nil {
+			_, err = stream.Recv()
+			header = stream.Trailer()
+		}
+		writeQuotaHeaders(w, err, header)
+	}
+	if status.Code(err) == codes.ResourceExhausted {
+		log.Printf("SummarizeVideoStream failure: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(ErrorResponse{Error: status.Convert(err).Message()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+
+	if err != nil {
+		log.Printf("SummarizeVideoStream failure: %v", err)
+		writeSSE(w, rc, "error", ErrorResponse{Error: summaryErrorMessage(err)})
+		return
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Printf("SummarizeVideoStream failure: %v", err)
+			writeSSE(w, rc, "error", ErrorResponse{Error: summaryErrorMessage(err)})
+			return
+		}
+
+		if chunk.Done {
+			writeSSE(w, rc, "done", SummaryDoneEvent{VideoID: chunk.VideoId, Summary: chunk.Summary, SummaryHTML: string(renderMarkdown(chunk.Summary)), Structured: chunk.Structured, GeneratedBy: chunk.GeneratedBy})
+			return
+		}
+		if err := writeSSE(w, rc, "chunk", SummaryChunkEvent{Delta: chunk.Delta}); err != nil {
+			log.Printf("SummarizeVideoStream client went away: %v", err)
+			return
+		}
+	}
+}
+
+// summaryErrorMessage explains a failed summary to the user when the video
+// service says why, and hides internal errors.
+func summaryErrorMessage(err error) string {
+	if code := status.Code(err); code == codes.InvalidArgument || code == codes.FailedPrecondition {
+		return status.Convert(err).Message()
+	}
+	return "Failed to summarize video"
+}
+
+func writeSSE(w http.ResponseWriter, rc *http.ResponseController, event string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Collections lists the user's collections and tags, with a form to create
+// a collection.
+func (h *SSRHandler) Collections(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	data := map[string]interface{}{
+		"Title":         "Collections - TextTube",
+		"Authenticated": true,
+		"Error":         r.URL.Query().Get("error"),
+	}
+	if resp, err := h.videoClient.ListCollections(r.Context(), &pb.ListCollectionsRequest{UserId: userID}); err == nil {
+		data["Collections"] = resp.Collections
+	} else {
+		log.Printf("Collections error: %v", err)
+	}
+	if resp, err := h.videoClient.ListTags(r.Context(), &pb.ListTagsRequest{UserId: userID}); err == nil {
+		data["Tags"] = resp.Tags
+	} else {
+		log.Printf("Tags error: %v", err)
+	}
+
+	if err := h.templates["collections"].ExecuteTemplate(w, "layout.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *SSRHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	collection, err := h.videoClient.CreateCollection(r.Context(), &pb.CreateCollectionRequest{
+		UserId:      userID,
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+	})
+	if err != nil {
+		log.Printf("Create collection error: %v", err)
+		http.Redirect(w, r, "/collections?error="+url.QueryEscape(status.Convert(err).Message()), http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/collections/"+collection.CollectionId, http.StatusSeeOther)
+}
+
+// Collection shows a collection's videos, optionally only those with a tag,
+// with forms to tag or remove them and to rename or delete the collection.
+func (h *SSRHandler) Collection(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+	tag := r.URL.Query().Get("tag")
+
+	collection, err := h.videoClient.GetCollection(r.Context(), &pb.GetCollectionRequest{
+		UserId:       userID,
+		CollectionId: mux.Vars(r)["collectionId"],
+		Tag:          tag,
+	})
+	if err != nil {
+		log.Printf("Collection error: %v", err)
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":         collection.Name + " - TextTube",
+		"Authenticated": true,
+		"Collection":    collection,
+		"Tag":           tag,
+		"Error":         r.URL.Query().Get("error"),
+	}
+	if err := h.templates["collection"].ExecuteTemplate(w, "layout.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// UpdateCollection renames a collection, or deletes it when the form has
+// delete.
+func (h *SSRHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID := mux.Vars(r)["collectionId"]
+	userID := r.Context().Value("user_id").(string)
+
+	if r.FormValue("delete") != "" {
+		_, err := h.videoClient.DeleteCollection(r.Context(), &pb.DeleteCollectionRequest{UserId: userID, CollectionId: collectionID})
+		if err != nil && status.Code(err) != codes.NotFound {
+			log.Printf("Delete collection error: %v", err)
+			http.Error(w, "Failed to delete collection", http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, "/collections", http.StatusSeeOther)
+		return
+	}
+
+	_, err := h.videoClient.UpdateCollection(r.Context(), &pb.UpdateCollectionRequest{
+		UserId:       userID,
+		CollectionId: collectionID,
+		Name:         r.FormValue("name"),
+		Description:  r.FormValue("description"),
+	})
+	h.redirectToCollection(w, r, collectionID, "", err)
+}
+
+// SaveToCollection saves the video of the page it is posted from to the
+// collection picked in the form.
+func (h *SSRHandler) SaveToCollection(w http.ResponseWriter, r *http.Request) {
+	videoID := mux.Vars(r)["videoId"]
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.AddToCollection(r.Context(), &pb.AddToCollectionRequest{
+		UserId:       userID,
+		CollectionId: r.FormValue("collection_id"),
+		VideoId:      videoID,
+	})
+	if err != nil {
+		log.Printf("Save to collection error: %v", err)
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/video/"+videoID+"?saved=1", http.StatusSeeOther)
+}
+
+func (h *SSRHandler) RemoveFromCollection(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.RemoveFromCollection(r.Context(), &pb.RemoveFromCollectionRequest{
+		UserId:       userID,
+		CollectionId: vars["collectionId"],
+		VideoId:      vars["videoId"],
+	})
+	if status.Code(err) == codes.NotFound {
+		err = nil
+	}
+	h.redirectToCollection(w, r, vars["collectionId"], r.FormValue("tag"), err)
+}
+
+// TagVideo replaces the tags of a video in a collection with the
+// comma-separated tags in the form.
+func (h *SSRHandler) TagVideo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.SetVideoTags(r.Context(), &pb.SetVideoTagsRequest{
+		UserId:  userID,
+		VideoId: vars["videoId"],
+		Tags:    strings.Split(r.FormValue("tags"), ","),
+	})
+	h.redirectToCollection(w, r, vars["collectionId"], r.FormValue("tag"), err)
+}
+
+// ExportCollection downloads a collection as Markdown, JSON, HTML or EPUB.
+func (h *SSRHandler) ExportCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID := mux.Vars(r)["collectionId"]
+	userID := r.Context().Value("user_id").(string)
+	transcript, _ := strconv.ParseBool(r.URL.Query().Get("transcript"))
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.ExportCollection(r.Context(), &pb.ExportCollectionRequest{
+		UserId:       userID,
+		CollectionId: collectionID,
+		Format:       r.URL.Query().Get("format"),
+		Transcript:   transcript,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("Export collection error: %v", err)
+		h.redirectToCollection(w, r, collectionID, "", err)
+		return
+	}
+	writeExport(w, resp.Filename, resp.ContentType, resp.Content)
+}
+
+// redirectToCollection returns to a collection's page after a change,
+// showing why it failed if err is set.
+func (h *SSRHandler) redirectToCollection(w http.ResponseWriter, r *http.Request, collectionID, tag string, err error) {
+	q := url.Values{}
+	if tag != "" {
+		q.Set("tag", tag)
+	}
+	if err != nil {
+		log.Printf("Collection error: %v", err)
+		q.Set("error", status.Convert(err).Message())
+	}
+	target := "/collections/" + collectionID
+	if len(q) > 0 {
+		target += "?" + q.Encode()
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
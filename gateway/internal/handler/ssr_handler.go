@@ -1,15 +1,23 @@
 package handler
 
 import (
+	"context"
 	"embed"
+	"encoding/json"
+	"fmt"
 	"gateway/internal/client"
 	"html/template"
 	"log"
 	"net/http"
+	"strings"
 
 	pb "shared/proto"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 //go:embed templates/*.html
@@ -31,13 +39,63 @@ func NewSSRHandler(authClient *client.AuthClient, videoClient *client.VideoClien
 	return s
 }
 
+type summaryChoice struct {
+	Value string
+	Label string
+}
+
+// summaryStyles and summaryLengths are the choices offered on the video page;
+// the video service rejects anything else.
+var summaryStyles = []summaryChoice{
+	{"detailed", "Detailed notes"},
+	{"tldr", "TL;DR"},
+	{"bullets", "Bullet points"},
+	{"executive", "Executive brief"},
+	{"study_guide", "Study guide"},
+	{"tweet_thread", "Tweet thread"},
+}
+
+var summaryLengths = []summaryChoice{
+	{"medium", "Medium"},
+	{"short", "Short"},
+	{"long", "Long"},
+}
+
+// digestDays are the windows offered on the channel digest page.
+var digestDays = []int32{1, 7, 14, 30}
+
+// prefetchChoices are what a followed channel's new uploads can be prepared
+// with.
+var prefetchChoices = []summaryChoice{
+	{"", "Nothing"},
+	{"transcript", "Transcript"},
+	{"summary", "Summary"},
+}
+
+var templateFuncs = template.FuncMap{
+	"timestamp": formatTimestamp,
+	"seconds":   func(s float64) int { return int(s) },
+	"markdown":  renderMarkdown,
+	"join":      strings.Join,
+}
+
+// formatTimestamp renders seconds as h:mm:ss or m:ss, the way YouTube does.
+func formatTimestamp(seconds float64) string {
+	total := int(seconds)
+	h, m, s := total/3600, (total%3600)/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
 func (h *SSRHandler) parseTemplates() {
 	layoutPath := "templates/layout.html"
-	pages := []string{"login", "register", "home", "video_detail"}
+	pages := []string{"login", "register", "home", "video_detail", "channel_digest", "collections", "collection"}
 
 	for _, page := range pages {
 		pagePath := "templates/" + page + ".html"
-		tmpl, err := template.ParseFS(templateFS, layoutPath, pagePath)
+		tmpl, err := template.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, layoutPath, pagePath)
 		if err != nil {
 			log.Fatalf("Error parsing template %s: %v", page, err)
 		}
@@ -159,6 +217,19 @@ func (h *SSRHandler) Home(w http.ResponseWriter, r *http.Request) {
 		} else {
 			log.Printf("Search error: %v", err)
 		}
+	} else {
+		feed, err := h.videoClient.GetFeed(r.Context(), &pb.GetFeedRequest{UserId: userID})
+		if err == nil {
+			data["NewVideos"] = feed.NewVideos
+			data["ContinueReading"] = feed.ContinueReading
+		} else {
+			log.Printf("Feed error: %v", err)
+		}
+		if token, err := h.videoClient.GetFeedToken(r.Context(), &pb.GetFeedTokenRequest{UserId: userID}); err == nil {
+			data["FeedToken"] = token.Token
+		} else {
+			log.Printf("Feed token error: %v", err)
+		}
 	}
 
 	if err := h.templates["home"].ExecuteTemplate(w, "layout.html", data); err != nil {
@@ -184,6 +255,50 @@ func (h *SSRHandler) VideoDetail(w http.ResponseWriter, r *http.Request) {
 		"Title":         resp.Video.Title + " - TextTube",
 		"Authenticated": true,
 		"Video":         resp.Video,
+		"Conversation":  h.loadConversation(r.Context(), videoID, userID),
+		"Notes":         h.loadNotes(r.Context(), videoID, userID),
+		"Styles":        summaryStyles,
+		"Lengths":       summaryLengths,
+		"Saved":         r.URL.Query().Get("saved") != "",
+		"NoteError":     r.URL.Query().Get("note_error"),
+		"ExportError":   r.URL.Query().Get("export_error"),
+	}
+	if collections, err := h.videoClient.ListCollections(r.Context(), &pb.ListCollectionsRequest{UserId: userID}); err == nil {
+		data["Collections"] = collections.Collections
+	} else {
+		log.Printf("Collections error: %v", err)
+	}
+	// Chapters generated earlier are shown without generating new ones.
+	if chapters, err := h.videoClient.GenerateChapters(r.Context(), &pb.GenerateChaptersRequest{
+		VideoId:    videoID,
+		UserId:     userID,
+		CachedOnly: true,
+	}); err == nil {
+		data["Chapters"] = chapters.Chapters
+		data["YoutubeDescription"] = chapters.YoutubeDescription
+	} else {
+		log.Printf("Chapters error: %v", err)
+	}
+
+	// The page polls a summary job and reloads with its ID once it is done.
+	if jobID := r.URL.Query().Get("job"); jobID != "" {
+		job, err := h.videoClient.GetJob(r.Context(), &pb.GetJobRequest{
+			JobId:  jobID,
+			UserId: userID,
+		})
+		switch {
+		case err != nil:
+			log.Printf("Job error: %v", err)
+		case job.Status == "failed":
+			data["SummaryError"] = job.Error
+		case job.Summary != nil && job.VideoId == videoID:
+			data["Summary"] = job.Summary.Summary
+			data["Structured"] = job.Summary.Structured
+			data["GeneratedBy"] = job.Summary.GeneratedBy
+			data["Style"] = job.Summary.Style
+			data["Length"] = job.Summary.Length
+			data["Language"] = job.Summary.Language
+		}
 	}
 
 	if err := h.templates["video_detail"].ExecuteTemplate(w, "layout.html", data); err != nil {
@@ -196,17 +311,164 @@ func (h *SSRHandler) Summarize(w http.ResponseWriter, r *http.Request) {
 	videoID := vars["videoId"]
 	userID := r.Context().Value("user_id").(string)
 
-	resp, err := h.videoClient.SummarizeVideo(r.Context(), &pb.SummarizeVideoRequest{
+	var header, trailer metadata.MD
+	resp, sumErr := h.videoClient.SummarizeVideo(r.Context(), summarizeRequest(r, videoID, userID), grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, sumErr, header, trailer)
+	if sumErr != nil {
+		log.Printf("Summarize error: %v", sumErr)
+		// Show why when retrying will not help; otherwise go back to the
+		// video page.
+		if code := status.Code(sumErr); code != codes.ResourceExhausted && code != codes.FailedPrecondition {
+			http.Redirect(w, r, "/video/"+videoID, http.StatusSeeOther)
+			return
+		}
+	}
+
+	// Fetch video details to render the full page
+	videoResp, err := h.videoClient.GetVideoDetails(r.Context(), &pb.GetVideoDetailsRequest{
 		VideoId: videoID,
 		UserId:  userID,
 	})
 	if err != nil {
-		log.Printf("Summarize error: %v", err)
-		http.Redirect(w, r, "/video/"+videoID, http.StatusSeeOther)
+		http.Error(w, "Video not found", http.StatusNotFound)
 		return
 	}
 
-	// Fetch video details to render the full page
+	data := map[string]interface{}{
+		"Title":         videoResp.Video.Title + " - TextTube",
+		"Authenticated": true,
+		"Video":         videoResp.Video,
+		"Conversation":  h.loadConversation(r.Context(), videoID, userID),
+		"Notes":         h.loadNotes(r.Context(), videoID, userID),
+		"Styles":        summaryStyles,
+		"Lengths":       summaryLengths,
+	}
+	if sumErr != nil {
+		data["SummaryError"] = status.Convert(sumErr).Message()
+		if status.Code(sumErr) == codes.ResourceExhausted {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	} else {
+		data["Summary"] = resp.Summary
+		data["Structured"] = resp.Structured
+		data["GeneratedBy"] = resp.GeneratedBy
+		data["Style"] = resp.Style
+		data["Length"] = resp.Length
+		data["Language"] = resp.Language
+	}
+
+	if err := h.templates["video_detail"].ExecuteTemplate(w, "layout.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *SSRHandler) SummarizeStream(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+	userID := r.Context().Value("user_id").(string)
+
+	streamSummary(w, r, h.videoClient, summarizeRequest(r, videoID, userID))
+}
+
+// SummarizeJob queues a summary from the video page's form and responds with
+// the job as JSON, for the page to poll.
+func (h *SSRHandler) SummarizeJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+	userID := r.Context().Value("user_id").(string)
+
+	var header, trailer metadata.MD
+	job, err := h.videoClient.SubmitSummaryJob(r.Context(), summarizeRequest(r, videoID, userID), grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("Summary job error: %v", err)
+		code := http.StatusInternalServerError
+		message := "Failed to start summarizing"
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			code, message = http.StatusTooManyRequests, status.Convert(err).Message()
+		case codes.InvalidArgument:
+			code, message = http.StatusBadRequest, status.Convert(err).Message()
+		case codes.Unavailable:
+			code, message = http.StatusServiceUnavailable, status.Convert(err).Message()
+		}
+		writeJSON(w, code, map[string]string{"error": message})
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// Job responds with one of the user's jobs as JSON.
+func (h *SSRHandler) Job(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["jobId"]
+	userID := r.Context().Value("user_id").(string)
+
+	job, err := h.videoClient.GetJob(r.Context(), &pb.GetJobRequest{
+		JobId:  jobID,
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("Job error: %v", err)
+		if status.Code(err) == codes.NotFound {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Job not found"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to get job"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (h *SSRHandler) Ask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+	userID := r.Context().Value("user_id").(string)
+
+	videoResp, err := h.videoClient.GetVideoDetails(r.Context(), &pb.GetVideoDetailsRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+	if err != nil {
+		http.Error(w, "Video not found", http.StatusNotFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":         videoResp.Video.Title + " - TextTube",
+		"Authenticated": true,
+		"Video":         videoResp.Video,
+		"Notes":         h.loadNotes(r.Context(), videoID, userID),
+		"Styles":        summaryStyles,
+		"Lengths":       summaryLengths,
+	}
+
+	resp, err := h.videoClient.AskVideo(r.Context(), &pb.AskVideoRequest{
+		VideoId:      videoID,
+		UserId:       userID,
+		Question:     r.FormValue("question"),
+		ResetHistory: r.FormValue("reset") != "",
+	})
+	if err != nil {
+		log.Printf("Ask error: %v", err)
+		data["AskError"] = llmErrorMessage(err, "Could not answer that question")
+		data["Conversation"] = h.loadConversation(r.Context(), videoID, userID)
+	} else {
+		data["Conversation"] = resp.History
+	}
+
+	if err := h.templates["video_detail"].ExecuteTemplate(w, "layout.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *SSRHandler) Chapters(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+	userID := r.Context().Value("user_id").(string)
+
 	videoResp, err := h.videoClient.GetVideoDetails(r.Context(), &pb.GetVideoDetailsRequest{
 		VideoId: videoID,
 		UserId:  userID,
@@ -220,10 +482,173 @@ func (h *SSRHandler) Summarize(w http.ResponseWriter, r *http.Request) {
 		"Title":         videoResp.Video.Title + " - TextTube",
 		"Authenticated": true,
 		"Video":         videoResp.Video,
-		"Summary":       resp.Summary,
+		"Conversation":  h.loadConversation(r.Context(), videoID, userID),
+		"Notes":         h.loadNotes(r.Context(), videoID, userID),
+		"Styles":        summaryStyles,
+		"Lengths":       summaryLengths,
+	}
+
+	resp, err := h.videoClient.GenerateChapters(r.Context(), &pb.GenerateChaptersRequest{
+		VideoId: videoID,
+		UserId:  userID,
+		Refresh: r.FormValue("refresh") != "",
+	})
+	if err != nil {
+		log.Printf("Chapters error: %v", err)
+		data["ChaptersError"] = llmErrorMessage(err, "Could not generate chapters for this video")
+	} else {
+		data["Chapters"] = resp.Chapters
+		data["YoutubeDescription"] = resp.YoutubeDescription
 	}
 
 	if err := h.templates["video_detail"].ExecuteTemplate(w, "layout.html", data); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// llmErrorMessage returns the video service's explanation when the model
+// refused to generate the content or a quota is used up, and fallback for
+// any other failure.
+func llmErrorMessage(err error, fallback string) string {
+	if code := status.Code(err); code == codes.FailedPrecondition || code == codes.ResourceExhausted {
+		return status.Convert(err).Message()
+	}
+	return fallback
+}
+
+// ChannelDigest shows the digest form for a channel and, once it is
+// submitted with a window, the digest of the channel's uploads in it.
+func (h *SSRHandler) ChannelDigest(w http.ResponseWriter, r *http.Request) {
+	channelID := mux.Vars(r)["channelId"]
+	userID := r.Context().Value("user_id").(string)
+
+	data := map[string]interface{}{
+		"Title":         "Channel Digest - TextTube",
+		"Authenticated": true,
+		"ChannelID":     channelID,
+		"DayChoices":    digestDays,
+		"Days":          int32(7),
+		"Language":      r.URL.Query().Get("language"),
+		"Prefetches":    prefetchChoices,
+	}
+	if sub := h.subscription(r.Context(), userID, channelID); sub != nil {
+		data["Subscription"] = sub
+	}
+
+	if r.URL.Query().Has("days") {
+		req, err := channelDigestRequest(r, channelID, userID)
+		if err == nil {
+			data["Days"] = req.Days
+			var header, trailer metadata.MD
+			var resp *pb.SummarizeChannelResponse
+			resp, err = h.videoClient.SummarizeChannel(r.Context(), req, grpc.Header(&header), grpc.Trailer(&trailer))
+			writeQuotaHeaders(w, err, header, trailer)
+			if err == nil {
+				data["Ran"] = true
+				data["Title"] = resp.ChannelTitle + " Digest - TextTube"
+				data["ChannelTitle"] = resp.ChannelTitle
+				data["Since"] = resp.Since
+				data["Digest"] = resp.Digest
+				data["Videos"] = resp.Videos
+				data["GeneratedBy"] = resp.GeneratedBy
+			}
+		}
+		if err != nil {
+			log.Printf("Channel digest error: %v", err)
+			data["DigestError"] = status.Convert(err).Message()
+			if status.Code(err) == codes.ResourceExhausted {
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}
+	}
+
+	if err := h.templates["channel_digest"].ExecuteTemplate(w, "layout.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Subscribe follows or unfollows the channel from its digest page.
+func (h *SSRHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	channelID := mux.Vars(r)["channelId"]
+	userID := r.Context().Value("user_id").(string)
+
+	var err error
+	if r.FormValue("unsubscribe") != "" {
+		_, err = h.videoClient.Unsubscribe(r.Context(), &pb.UnsubscribeRequest{UserId: userID, ChannelId: channelID})
+	} else {
+		_, err = h.videoClient.Subscribe(r.Context(), &pb.SubscribeRequest{
+			UserId:    userID,
+			ChannelId: channelID,
+			Prefetch:  r.FormValue("prefetch"),
+		})
+	}
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Printf("Subscription error: %v", err)
+		http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/channel/"+channelID+"/digest", http.StatusSeeOther)
+}
+
+// RotateFeedToken replaces the token in the user's summary feed URLs and
+// returns to the home page, which shows the new ones.
+func (h *SSRHandler) RotateFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	if _, err := h.videoClient.GetFeedToken(r.Context(), &pb.GetFeedTokenRequest{UserId: userID, Rotate: true}); err != nil {
+		log.Printf("Feed token error: %v", err)
+		http.Error(w, "Failed to replace feed URL", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// subscription returns the user's subscription to a channel, or nil when
+// they don't follow it or it cannot be loaded.
+func (h *SSRHandler) subscription(ctx context.Context, userID, channelID string) *pb.Subscription {
+	resp, err := h.videoClient.ListSubscriptions(ctx, &pb.ListSubscriptionsRequest{UserId: userID})
+	if err != nil {
+		log.Printf("Subscriptions error: %v", err)
+		return nil
+	}
+	for _, sub := range resp.Subscriptions {
+		if sub.ChannelId == channelID {
+			return sub
+		}
+	}
+	return nil
+}
+
+// loadConversation returns the user's chat history for a video, or nil when
+// it cannot be loaded; the page still renders without it.
+func (h *SSRHandler) loadConversation(ctx context.Context, videoID, userID string) []*pb.ChatMessage {
+	resp, err := h.videoClient.GetConversation(ctx, &pb.GetConversationRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+	if err != nil {
+		log.Printf("Conversation error: %v", err)
+		return nil
+	}
+	return resp.History
+}
+
+// loadNotes returns the user's notes on a video in transcript order, or nil
+// when they cannot be loaded.
+func (h *SSRHandler) loadNotes(ctx context.Context, videoID, userID string) []*pb.Note {
+	resp, err := h.videoClient.ListNotes(ctx, &pb.ListNotesRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+	if err != nil {
+		log.Printf("Notes error: %v", err)
+		return nil
+	}
+	return resp.Notes
+}
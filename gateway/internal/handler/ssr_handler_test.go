@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeSummaryService stands in for the video service when its summary
+// quota is used up.
+type fakeSummaryService struct {
+	pb.UnimplementedVideoServiceServer
+}
+
+func (f *fakeSummaryService) SummarizeVideo(ctx context.Context, req *pb.SummarizeVideoRequest) (*pb.SummarizeVideoResponse, error) {
+	grpc.SetTrailer(ctx, metadata.Pairs(
+		"x-quota-remaining", "0",
+		"x-quota-reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+	))
+	return nil, status.Error(codes.ResourceExhausted, "daily summary quota used up")
+}
+
+func (f *fakeSummaryService) GetVideoDetails(ctx context.Context, req *pb.GetVideoDetailsRequest) (*pb.GetVideoDetailsResponse, error) {
+	return &pb.GetVideoDetailsResponse{Video: &pb.VideoInfo{VideoId: req.VideoId, Title: "Test video"}}, nil
+}
+
+func TestSSRSummarize_QuotaExceeded(t *testing.T) {
+	h := NewSSRHandler(nil, newTestVideoClient(t, &fakeSummaryService{}))
+	r := mux.NewRouter()
+	r.HandleFunc("/video/{videoId}/summarize", h.Summarize).Methods("POST")
+
+	req := httptest.NewRequest("POST", "/video/dQw4w9WgXcQ/summarize", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", "user-1"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "daily summary quota used up") {
+		t.Errorf("Expected the quota error on the page, got %s", w.Body)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After on a 429")
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateNote adds a note to the video of the page it is posted from. The
+// form's start and end are timestamps like 1:15 or plain seconds.
+func (h *SSRHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
+	videoID := mux.Vars(r)["videoId"]
+	userID := r.Context().Value("user_id").(string)
+
+	start, err := parseTimestamp(r.FormValue("start"))
+	if err != nil {
+		redirectToVideoNotes(w, r, videoID, err.Error())
+		return
+	}
+	end, err := parseTimestamp(r.FormValue("end"))
+	if err != nil {
+		redirectToVideoNotes(w, r, videoID, err.Error())
+		return
+	}
+
+	_, err = h.videoClient.CreateNote(r.Context(), &pb.CreateNoteRequest{
+		UserId:       userID,
+		VideoId:      videoID,
+		StartSeconds: start,
+		EndSeconds:   end,
+		Quote:        r.FormValue("quote"),
+		Text:         r.FormValue("text"),
+	})
+	if err != nil {
+		log.Printf("Create note error: %v", err)
+		redirectToVideoNotes(w, r, videoID, status.Convert(err).Message())
+		return
+	}
+	redirectToVideoNotes(w, r, videoID, "")
+}
+
+func (h *SSRHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.DeleteNote(r.Context(), &pb.DeleteNoteRequest{UserId: userID, NoteId: vars["noteId"]})
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Printf("Delete note error: %v", err)
+		redirectToVideoNotes(w, r, vars["videoId"], status.Convert(err).Message())
+		return
+	}
+	redirectToVideoNotes(w, r, vars["videoId"], "")
+}
+
+// redirectToVideoNotes returns to the notes on a video's page, showing
+// message if a change failed.
+func redirectToVideoNotes(w http.ResponseWriter, r *http.Request, videoID, message string) {
+	target := "/video/" + videoID
+	if message != "" {
+		target += "?note_error=" + url.QueryEscape(message)
+	}
+	http.Redirect(w, r, target+"#notes", http.StatusSeeOther)
+}
+
+// parseTimestamp reads h:mm:ss, m:ss or plain seconds; empty is 0.
+func parseTimestamp(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	var seconds float64
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", value)
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, nil
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SubscriptionRequest struct {
+	ChannelID string `json:"channel_id"`
+	// What to prepare for each new upload before it is opened: nothing,
+	// the transcript (indexed for search and questions) or the summary.
+	// Summaries count against the summary quota.
+	Prefetch string `json:"prefetch" enums:",transcript,summary"`
+}
+
+type SubscriptionResponse struct {
+	ChannelID    string `json:"channel_id"`
+	ChannelTitle string `json:"channel_title"`
+	Prefetch     string `json:"prefetch,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	// Uploads found since the subscriptions were last visited.
+	NewVideos int32 `json:"new_videos,omitempty"`
+}
+
+type FeedItemResponse struct {
+	VideoID      string `json:"video_id"`
+	Title        string `json:"title"`
+	ChannelID    string `json:"channel_id"`
+	ChannelTitle string `json:"channel_title"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	PublishedAt  string `json:"published_at"`
+	// When the upload was found, RFC 3339.
+	AddedAt string `json:"added_at"`
+}
+
+type ListSubscriptionsResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+	// Uploads found since the last visit, newest first.
+	NewVideos []FeedItemResponse `json:"new_videos"`
+}
+
+type HistoryItemResponse struct {
+	VideoID      string `json:"video_id"`
+	Title        string `json:"title"`
+	ChannelID    string `json:"channel_id"`
+	ChannelTitle string `json:"channel_title"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	// When the video was last opened, its transcript last read and its
+	// summary last generated, RFC 3339; omitted for what was never done.
+	ViewedAt         string `json:"viewed_at,omitempty"`
+	TranscriptReadAt string `json:"transcript_read_at,omitempty"`
+	SummarizedAt     string `json:"summarized_at,omitempty"`
+	LastActivityAt   string `json:"last_activity_at"`
+}
+
+type FeedResponse struct {
+	// New uploads from followed channels not opened yet, newest first.
+	NewVideos []FeedItemResponse `json:"new_videos"`
+	// Videos recently viewed, read or summarized, most recent first.
+	ContinueReading []HistoryItemResponse `json:"continue_reading"`
+}
+
+// Subscribe godoc
+// @Summary Follow a channel
+// @Description Follow a channel so its new uploads are picked up in the background and listed as new videos. Following a channel again only changes what is prefetched. A channel.video_published webhook event is sent for each new upload.
+// @Tags subscriptions
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param request body SubscriptionRequest true "Channel to follow"
+// @Success 200 {object} SubscriptionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/subscriptions [post]
+func (h *VideoHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req SubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.videoClient.Subscribe(r.Context(), &pb.SubscribeRequest{
+		UserId:    userID,
+		ChannelId: req.ChannelID,
+		Prefetch:  req.Prefetch,
+	})
+	if err != nil {
+		log.Printf("Subscribe failure: %v", err)
+		if status.Code(err) == codes.InvalidArgument {
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		h.sendJSONError(w, "Failed to follow channel", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sub)
+}
+
+// ListSubscriptions godoc
+// @Summary List followed channels
+// @Description List the channels the current user follows with the number of uploads found since the last visit, and those uploads newest first. With mark_visited the uploads returned no longer count as new afterwards.
+// @Tags subscriptions
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param mark_visited query bool false "Mark the new uploads as seen"
+// @Success 200 {object} ListSubscriptionsResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/subscriptions [get]
+func (h *VideoHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	resp, err := h.videoClient.ListSubscriptions(r.Context(), &pb.ListSubscriptionsRequest{
+		UserId:      userID,
+		MarkVisited: r.URL.Query().Get("mark_visited") == "true",
+	})
+	if err != nil {
+		log.Printf("ListSubscriptions failure: %v", err)
+		h.sendJSONError(w, "Failed to list subscriptions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Unsubscribe godoc
+// @Summary Unfollow a channel
+// @Description Stop following a channel. Uploads already found from it are no longer listed.
+// @Tags subscriptions
+// @Security ApiKeyAuth
+// @Param channelId path string true "Channel ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/subscriptions/{channelId} [delete]
+func (h *VideoHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	channelID := vars["channelId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.Unsubscribe(r.Context(), &pb.UnsubscribeRequest{
+		UserId:    userID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		log.Printf("Unsubscribe failure: %v", err)
+		if status.Code(err) == codes.NotFound {
+			h.sendJSONError(w, "Subscription not found", http.StatusNotFound)
+			return
+		}
+		h.sendJSONError(w, "Failed to unfollow channel", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFeed godoc
+// @Summary Get the personal feed
+// @Description Get the new uploads from the channels the current user follows that they have not opened yet, and the videos they recently viewed, read the transcript of or summarized. Opening a video, reading its transcript or summarizing it is recorded in the history.
+// @Tags subscriptions
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param limit query int false "Maximum number of items in each list, 1-50" default(10)
+// @Success 200 {object} FeedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/feed [get]
+func (h *VideoHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			h.sendJSONError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	resp, err := h.videoClient.GetFeed(r.Context(), &pb.GetFeedRequest{
+		UserId: userID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		log.Printf("GetFeed failure: %v", err)
+		if status.Code(err) == codes.InvalidArgument {
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		h.sendJSONError(w, "Failed to get feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -6,18 +6,26 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	pb "shared/proto"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type VideoHandler struct {
 	videoClient *client.VideoClient
+	// publicBaseURL is the scheme and host feed links are built from. When
+	// it is empty they follow the request and the proxy's headers.
+	publicBaseURL string
 }
 
-func NewVideoHandler(videoClient *client.VideoClient) *VideoHandler {
-	return &VideoHandler{videoClient: videoClient}
+func NewVideoHandler(videoClient *client.VideoClient, publicBaseURL string) *VideoHandler {
+	return &VideoHandler{videoClient: videoClient, publicBaseURL: strings.TrimSuffix(publicBaseURL, "/")}
 }
 
 type VideoThumbnail struct {
@@ -66,8 +74,118 @@ type TranscriptResponse struct {
 }
 
 type SummarizeResponse struct {
-	VideoID string `json:"video_id"`
-	Summary string `json:"summary"`
+	VideoID     string             `json:"video_id"`
+	Summary     string             `json:"summary"`
+	Style       string             `json:"style"`
+	Length      string             `json:"length"`
+	Language    string             `json:"language"`
+	Structured  *StructuredSummary `json:"structured,omitempty"`
+	GeneratedBy []ModelInfo        `json:"generated_by"`
+}
+
+type ModelInfo struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+}
+
+type StructuredSummary struct {
+	Title        string            `json:"title"`
+	Tldr         string            `json:"tldr"`
+	Chapters     []SummaryChapter  `json:"chapters"`
+	KeyTakeaways []string          `json:"key_takeaways"`
+	Entities     []SummaryEntity   `json:"entities"`
+	Resources    []SummaryResource `json:"resources"`
+	ActionItems  []string          `json:"action_items"`
+}
+
+type SummaryChapter struct {
+	Title        string  `json:"title"`
+	StartSeconds float64 `json:"start_seconds"`
+	Summary      string  `json:"summary"`
+}
+
+type SummaryEntity struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type SummaryResource struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type VideoChapter struct {
+	Title        string  `json:"title"`
+	StartSeconds float64 `json:"start_seconds"`
+}
+
+type ChaptersResponse struct {
+	VideoID            string         `json:"video_id"`
+	Chapters           []VideoChapter `json:"chapters"`
+	YoutubeDescription string         `json:"youtube_description"`
+	Cached             bool           `json:"cached"`
+}
+
+type AskVideoRequest struct {
+	Question     string `json:"question"`
+	ResetHistory bool   `json:"reset_history"`
+}
+
+type Citation struct {
+	StartSeconds float64 `json:"start_seconds"`
+	EndSeconds   float64 `json:"end_seconds"`
+	Text         string  `json:"text"`
+}
+
+type ChatMessage struct {
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	Citations []Citation `json:"citations"`
+	CreatedAt string     `json:"created_at"`
+}
+
+type AskVideoResponse struct {
+	VideoID   string        `json:"video_id"`
+	Answer    string        `json:"answer"`
+	Citations []Citation    `json:"citations"`
+	History   []ChatMessage `json:"history"`
+}
+
+type ConversationResponse struct {
+	VideoID string        `json:"video_id"`
+	History []ChatMessage `json:"history"`
+}
+
+type ModelUsage struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	Calls            int64   `json:"calls"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+type UsageResponse struct {
+	UserID           string       `json:"user_id"`
+	Since            string       `json:"since"`
+	Calls            int64        `json:"calls"`
+	PromptTokens     int64        `json:"prompt_tokens"`
+	CompletionTokens int64        `json:"completion_tokens"`
+	CostUSD          float64      `json:"cost_usd"`
+	ByModel          []ModelUsage `json:"by_model"`
+}
+
+type SemanticSearchResult struct {
+	VideoID      string  `json:"video_id"`
+	ChunkIndex   int32   `json:"chunk_index"`
+	Text         string  `json:"text"`
+	StartSeconds float64 `json:"start_seconds"`
+	EndSeconds   float64 `json:"end_seconds"`
+	Score        float64 `json:"score"`
+}
+
+type SemanticSearchResponse struct {
+	Results []SemanticSearchResult `json:"results"`
 }
 
 func (h *VideoHandler) sendJSONError(w http.ResponseWriter, message string, code int) {
@@ -194,7 +312,12 @@ func (h *VideoHandler) GetVideoDetails(w http.ResponseWriter, r *http.Request) {
 // @Security ApiKeyAuth
 // @Param videoId path string true "Video ID"
 // @Success 200 {object} TranscriptResponse
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Requests allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Requests left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
 // @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse "The daily or monthly transcript quota is used up"
 // @Router /api/videos/{videoId}/transcript [get]
 func (h *VideoHandler) GetVideoTranscript(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -202,12 +325,18 @@ func (h *VideoHandler) GetVideoTranscript(w http.ResponseWriter, r *http.Request
 
 	userID := r.Context().Value("user_id").(string)
 
+	var header, trailer metadata.MD
 	resp, err := h.videoClient.GetVideoTranscript(r.Context(), &pb.GetVideoTranscriptRequest{
 		VideoId: videoID,
 		UserId:  userID,
-	})
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
 	if err != nil {
 		log.Printf("GetVideoTranscript failure: %v", err)
+		if status.Code(err) == codes.ResourceExhausted {
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		}
 		h.sendJSONError(w, "Failed to get video transcript", http.StatusInternalServerError)
 		return
 	}
@@ -224,8 +353,19 @@ func (h *VideoHandler) GetVideoTranscript(w http.ResponseWriter, r *http.Request
 // @Produce  json
 // @Security ApiKeyAuth
 // @Param videoId path string true "Video ID"
+// @Param style query string false "Summary style" Enums(tldr, bullets, detailed, executive, study_guide, tweet_thread) default(detailed)
+// @Param length query string false "Target length" Enums(short, medium, long) default(medium)
+// @Param language query string false "Language to write the summary in; defaults to the transcript's language"
+// @Param structured query bool false "Also return title, TL;DR, chapters, key takeaways, entities, resources and action items as structured data"
 // @Success 200 {object} SummarizeResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 422 {object} ErrorResponse "The model's safety or recitation filters blocked the content"
+// @Failure 429 {object} ErrorResponse "The daily or monthly summary quota is used up"
 // @Router /api/videos/{videoId}/summarize [get]
 func (h *VideoHandler) SummarizeVideo(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -233,13 +373,214 @@ func (h *VideoHandler) SummarizeVideo(w http.ResponseWriter, r *http.Request) {
 
 	userID := r.Context().Value("user_id").(string)
 
-	resp, err := h.videoClient.SummarizeVideo(r.Context(), &pb.SummarizeVideoRequest{
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.SummarizeVideo(r.Context(), summarizeRequest(r, videoID, userID), grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("SummarizeVideo failure: %v", err)
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		case codes.InvalidArgument:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		case codes.FailedPrecondition:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			return
+		}
+		h.sendJSONError(w, "Failed to summarize video", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SummarizeVideoStream godoc
+// @Summary Stream a video summary
+// @Description Generate a summary and stream it as Server-Sent Events. "chunk" events carry {"delta"} text to append, the final "done" event carries the complete {"video_id", "summary"}, and an "error" event carries {"error"} if generation fails.
+// @Tags videos
+// @Produce  text/event-stream
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Param style query string false "Summary style" Enums(tldr, bullets, detailed, executive, study_guide, tweet_thread) default(detailed)
+// @Param length query string false "Target length" Enums(short, medium, long) default(medium)
+// @Param language query string false "Language to write the summary in; defaults to the transcript's language"
+// @Param structured query bool false "Generate a structured summary; it arrives in one piece with the done event"
+// @Success 200 {object} SummaryChunkEvent
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Summaries allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Summaries left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse "The daily or monthly summary quota is used up"
+// @Router /api/videos/{videoId}/summarize/stream [get]
+func (h *VideoHandler) SummarizeVideoStream(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	streamSummary(w, r, h.videoClient, summarizeRequest(r, videoID, userID))
+}
+
+// summarizeRequest reads the optional style, length, language and structured
+// flag from the query string or a submitted form.
+func summarizeRequest(r *http.Request, videoID, userID string) *pb.SummarizeVideoRequest {
+	structured, _ := strconv.ParseBool(r.FormValue("structured"))
+	return &pb.SummarizeVideoRequest{
+		VideoId:    videoID,
+		UserId:     userID,
+		Style:      r.FormValue("style"),
+		Length:     r.FormValue("length"),
+		Language:   r.FormValue("language"),
+		Structured: structured,
+	}
+}
+
+// SemanticSearch godoc
+// @Summary Semantic search over transcripts
+// @Description Find the transcript passages most relevant to a natural-language query. Videos passed in video_id are indexed on first use; without video_id every indexed video is searched.
+// @Tags videos
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param q query string true "Search query"
+// @Param top_k query int false "Number of passages to return" default(5)
+// @Param video_id query []string false "Restrict to these video IDs, at most 10" collectionFormat(multi)
+// @Success 200 {object} SemanticSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Requests allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Requests left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 429 {object} ErrorResponse "The daily or monthly transcript quota is used up"
+// @Router /api/search [get]
+func (h *VideoHandler) SemanticSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		h.sendJSONError(w, "q parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("user_id").(string)
+
+	topK := int32(5)
+	if k := r.URL.Query().Get("top_k"); k != "" {
+		if val, err := strconv.Atoi(k); err == nil {
+			topK = int32(val)
+		}
+	}
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.SemanticSearch(r.Context(), &pb.SemanticSearchRequest{
+		Query:    query,
+		UserId:   userID,
+		TopK:     topK,
+		VideoIds: r.URL.Query()["video_id"],
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("SemanticSearch failure: %v", err)
+		switch status.Code(err) {
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		case codes.InvalidArgument:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		h.sendJSONError(w, "Failed to search transcripts", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// AskVideo godoc
+// @Summary Ask a question about a video
+// @Description Answer a question from the video's transcript with citations to timestamps. The conversation is kept per user and video, so follow-up questions see earlier turns.
+// @Tags videos
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Param request body AskVideoRequest true "Question"
+// @Success 200 {object} AskVideoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "The model's safety or recitation filters blocked the content"
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Requests allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Requests left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 429 {object} ErrorResponse "The daily or monthly transcript or summary quota is used up"
+// @Router /api/videos/{videoId}/ask [post]
+func (h *VideoHandler) AskVideo(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+
+	var req AskVideoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Question == "" {
+		h.sendJSONError(w, "question is required", http.StatusBadRequest)
+		return
+	}
+
+	userID := r.Context().Value("user_id").(string)
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.AskVideo(r.Context(), &pb.AskVideoRequest{
+		VideoId:      videoID,
+		UserId:       userID,
+		Question:     req.Question,
+		ResetHistory: req.ResetHistory,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("AskVideo failure: %v", err)
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			return
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		}
+		h.sendJSONError(w, "Failed to answer question", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetConversation godoc
+// @Summary Get the conversation about a video
+// @Description Get the current user's question-and-answer history for a video
+// @Tags videos
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Success 200 {object} ConversationResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/videos/{videoId}/conversation [get]
+func (h *VideoHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	resp, err := h.videoClient.GetConversation(r.Context(), &pb.GetConversationRequest{
 		VideoId: videoID,
 		UserId:  userID,
 	})
 	if err != nil {
-		log.Printf("SummarizeVideo failure: %v", err)
-		h.sendJSONError(w, "Failed to summarize video", http.StatusInternalServerError)
+		log.Printf("GetConversation failure: %v", err)
+		h.sendJSONError(w, "Failed to get conversation", http.StatusInternalServerError)
 		return
 	}
 
@@ -247,3 +588,108 @@ func (h *VideoHandler) SummarizeVideo(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// GenerateChapters godoc
+// @Summary Generate chapter markers for a video
+// @Description Split a video into chapters from its timestamped transcript. Chapters are cached per video; pass refresh to regenerate them. With format=youtube the response is the plain-text "0:00 Title" list to paste into a YouTube description.
+// @Tags videos
+// @Accept  json
+// @Produce  json,plain
+// @Security ApiKeyAuth
+// @Param videoId path string true "Video ID"
+// @Param refresh query bool false "Regenerate instead of using cached chapters"
+// @Param format query string false "Response format" Enums(json, youtube) default(json)
+// @Success 200 {object} ChaptersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "The model's safety or recitation filters blocked the content"
+// @Header 200,429 {string} X-Quota-Plan "The user's plan"
+// @Header 200,429 {integer} X-Quota-Limit "Requests allowed in the user's tightest quota window"
+// @Header 200,429 {integer} X-Quota-Remaining "Requests left in that window"
+// @Header 200,429 {integer} X-Quota-Reset "When that window resets, in Unix seconds"
+// @Failure 429 {object} ErrorResponse "The daily or monthly transcript or summary quota is used up"
+// @Router /api/videos/{videoId}/chapters [get]
+func (h *VideoHandler) GenerateChapters(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	videoID := vars["videoId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "youtube" {
+		h.sendJSONError(w, "format must be json or youtube", http.StatusBadRequest)
+		return
+	}
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+
+	var header, trailer metadata.MD
+	resp, err := h.videoClient.GenerateChapters(r.Context(), &pb.GenerateChaptersRequest{
+		VideoId: videoID,
+		UserId:  userID,
+		Refresh: refresh,
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	writeQuotaHeaders(w, err, header, trailer)
+	if err != nil {
+		log.Printf("GenerateChapters failure: %v", err)
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusUnprocessableEntity)
+			return
+		case codes.ResourceExhausted:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		}
+		h.sendJSONError(w, "Failed to generate chapters", http.StatusInternalServerError)
+		return
+	}
+
+	if format == "youtube" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(resp.YoutubeDescription))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetUsage godoc
+// @Summary Get LLM usage
+// @Description Get the current user's LLM token usage and estimated cost over the last days, in total and per provider and model
+// @Tags usage
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param days query int false "Number of days to report" default(30)
+// @Success 200 {object} UsageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/usage [get]
+func (h *VideoHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var days int
+	if d := r.URL.Query().Get("days"); d != "" {
+		var err error
+		if days, err = strconv.Atoi(d); err != nil || days <= 0 {
+			h.sendJSONError(w, "days must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.videoClient.GetUsage(r.Context(), &pb.GetUsageRequest{
+		UserId: userID,
+		Days:   int32(days),
+	})
+	if err != nil {
+		log.Printf("GetUsage failure: %v", err)
+		if status.Code(err) == codes.InvalidArgument {
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		h.sendJSONError(w, "Failed to get usage", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
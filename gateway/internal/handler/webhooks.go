@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type WebhookRequest struct {
+	URL string `json:"url"`
+	// Events to deliver; empty subscribes to every event.
+	Events []string `json:"events" enums:"summary.completed,job.failed,channel.video_published"`
+}
+
+type WebhookResponse struct {
+	WebhookID string   `json:"webhook_id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	// Only returned when the webhook is created.
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryID    string `json:"delivery_id"`
+	WebhookID     string `json:"webhook_id"`
+	Event         string `json:"event"`
+	Status        string `json:"status" enums:"pending,delivered,failed"`
+	Attempts      int32  `json:"attempts"`
+	ResponseCode  int32  `json:"response_code"`
+	Error         string `json:"error,omitempty"`
+	Payload       string `json:"payload"`
+	CreatedAt     string `json:"created_at"`
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook
+// @Description Register a URL to be sent a signed JSON POST when an event happens. The X-TextTube-Signature header of each request is "sha256=" followed by the hex HMAC-SHA256 of the body, keyed with the secret returned here; it is not shown again. Failed deliveries are retried with exponential backoff.
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param request body WebhookRequest true "Webhook"
+// @Success 201 {object} WebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/webhooks [post]
+func (h *VideoHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendJSONError(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+
+	hook, err := h.videoClient.CreateWebhook(r.Context(), &pb.CreateWebhookRequest{
+		UserId: userID,
+		Url:    req.URL,
+		Events: req.Events,
+	})
+	if err != nil {
+		log.Printf("CreateWebhook failure: %v", err)
+		if status.Code(err) == codes.InvalidArgument {
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		h.sendJSONError(w, "Failed to create webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(hook)
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description List the current user's webhooks
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object} ListWebhooksResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/webhooks [get]
+func (h *VideoHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	resp, err := h.videoClient.ListWebhooks(r.Context(), &pb.ListWebhooksRequest{UserId: userID})
+	if err != nil {
+		log.Printf("ListWebhooks failure: %v", err)
+		h.sendJSONError(w, "Failed to list webhooks", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Stop sending events to a webhook. Its delivery log is kept.
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Param webhookId path string true "Webhook ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/webhooks/{webhookId} [delete]
+func (h *VideoHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	webhookID := vars["webhookId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	_, err := h.videoClient.DeleteWebhook(r.Context(), &pb.DeleteWebhookRequest{
+		UserId:    userID,
+		WebhookId: webhookID,
+	})
+	if err != nil {
+		log.Printf("DeleteWebhook failure: %v", err)
+		if status.Code(err) == codes.NotFound {
+			h.sendJSONError(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		h.sendJSONError(w, "Failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary List webhook deliveries
+// @Description List the current user's most recent webhook deliveries with their status, attempts, last response and payload, newest first
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param webhook_id query string false "Only deliveries to this webhook"
+// @Param limit query int false "Number of deliveries to return, at most 100" default(20)
+// @Success 200 {object} ListWebhookDeliveriesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/webhooks/deliveries [get]
+func (h *VideoHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(string)
+
+	var limit int
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			h.sendJSONError(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := h.videoClient.ListWebhookDeliveries(r.Context(), &pb.ListWebhookDeliveriesRequest{
+		UserId:    userID,
+		WebhookId: r.URL.Query().Get("webhook_id"),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		log.Printf("ListWebhookDeliveries failure: %v", err)
+		h.sendJSONError(w, "Failed to list deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ReplayWebhookDelivery godoc
+// @Summary Replay a webhook delivery
+// @Description Send the payload of an earlier delivery to its webhook again, as a new delivery
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202 {object} WebhookDeliveryResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The webhook has been deleted"
+// @Router /api/webhooks/deliveries/{deliveryId}/replay [post]
+func (h *VideoHandler) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	deliveryID := vars["deliveryId"]
+
+	userID := r.Context().Value("user_id").(string)
+
+	delivery, err := h.videoClient.ReplayWebhookDelivery(r.Context(), &pb.ReplayWebhookDeliveryRequest{
+		UserId:     userID,
+		DeliveryId: deliveryID,
+	})
+	if err != nil {
+		log.Printf("ReplayWebhookDelivery failure: %v", err)
+		switch status.Code(err) {
+		case codes.NotFound:
+			h.sendJSONError(w, "Delivery not found", http.StatusNotFound)
+			return
+		case codes.FailedPrecondition:
+			h.sendJSONError(w, status.Convert(err).Message(), http.StatusConflict)
+			return
+		}
+		h.sendJSONError(w, "Failed to replay delivery", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(delivery)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/xml"
+	"gateway/internal/client"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	pb "shared/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxNotificationSize caps the Atom documents accepted from the hub.
+const maxNotificationSize = 1 << 20
+
+// WebSubHandler is the callback of the WebSub (PubSubHubbub) hub that pushes
+// the uploads of followed channels. The video service asks the hub for the
+// subscriptions; this handler answers the hub's verification of them and
+// passes the notified uploads on.
+type WebSubHandler struct {
+	videoClient *client.VideoClient
+	secret      string
+}
+
+// NewWebSubHandler returns a handler that drops notifications not signed
+// with secret. With an empty secret every notification is dropped.
+func NewWebSubHandler(videoClient *client.VideoClient, secret string) *WebSubHandler {
+	return &WebSubHandler{videoClient: videoClient, secret: secret}
+}
+
+// Verify echoes the hub's challenge when the video service confirms that it
+// asked to subscribe to or unsubscribe from the topic, with the verify token
+// the hub echoes from the request.
+func (h *WebSubHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("hub.mode") == "denied" {
+		log.Printf("WebSub hub denied subscription to %s: %s", q.Get("hub.topic"), q.Get("hub.reason"))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	channelID := topicChannel(q.Get("hub.topic"))
+	challenge := q.Get("hub.challenge")
+	if channelID == "" || challenge == "" {
+		http.Error(w, "Unknown topic", http.StatusNotFound)
+		return
+	}
+	lease, _ := strconv.Atoi(q.Get("hub.lease_seconds"))
+
+	_, err := h.videoClient.ConfirmPushSubscription(r.Context(), &pb.ConfirmPushSubscriptionRequest{
+		ChannelId:    channelID,
+		Mode:         q.Get("hub.mode"),
+		LeaseSeconds: int32(lease),
+		VerifyToken:  q.Get("hub.verify_token"),
+	})
+	if err != nil {
+		log.Printf("ConfirmPushSubscription failure: %v", err)
+		switch status.Code(err) {
+		case codes.NotFound, codes.InvalidArgument, codes.PermissionDenied:
+			http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to verify subscription", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, challenge)
+}
+
+// Notify passes the uploads in the hub's Atom notification on to the video
+// service. Notifications with a bad signature are acknowledged, as WebSub
+// requires, but dropped.
+func (h *WebSubHandler) Notify(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxNotificationSize))
+	if err != nil {
+		http.Error(w, "Notification too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !validHubSignature(h.secret, r.Header.Get("X-Hub-Signature"), body) {
+		log.Printf("Dropping WebSub notification with an invalid signature")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	uploads, err := parseUploads(body)
+	if err != nil {
+		log.Printf("Invalid WebSub notification: %v", err)
+		http.Error(w, "Invalid Atom feed", http.StatusBadRequest)
+		return
+	}
+	for channelID, videos := range uploads {
+		_, err := h.videoClient.IngestChannelUploads(r.Context(), &pb.IngestChannelUploadsRequest{
+			ChannelId: channelID,
+			Videos:    videos,
+		})
+		if err != nil {
+			// The hub retries notifications that fail.
+			log.Printf("IngestChannelUploads failure: %v", err)
+			http.Error(w, "Failed to ingest uploads", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// topicChannel returns the channel ID of a YouTube upload feed topic, or ""
+// for any other topic.
+func topicChannel(topic string) string {
+	u, err := url.Parse(topic)
+	if err != nil || u.Host != "www.youtube.com" || u.Path != "/xml/feeds/videos.xml" {
+		return ""
+	}
+	return u.Query().Get("channel_id")
+}
+
+// validHubSignature checks an X-Hub-Signature header, "method=hex digest"
+// of the body keyed with secret, for any method WebSub allows. Nothing is
+// valid without a secret.
+func validHubSignature(secret, header string, body []byte) bool {
+	if secret == "" {
+		return false
+	}
+	method, digest, ok := strings.Cut(header, "=")
+	if !ok {
+		return false
+	}
+	var newHash func() hash.Hash
+	switch method {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// atomFeed is the part of YouTube's upload notifications that is used.
+// Deleted videos come as at:deleted-entry elements, which are ignored.
+type atomFeed struct {
+	Entries []struct {
+		VideoID   string `xml:"videoId"`
+		ChannelID string `xml:"channelId"`
+		Title     string `xml:"title"`
+		Author    struct {
+			Name string `xml:"name"`
+		} `xml:"author"`
+		Published string `xml:"published"`
+	} `xml:"entry"`
+}
+
+// parseUploads returns the uploads in a notification by channel.
+func parseUploads(body []byte) (map[string][]*pb.VideoInfo, error) {
+	var feed atomFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, err
+	}
+	uploads := make(map[string][]*pb.VideoInfo)
+	for _, entry := range feed.Entries {
+		if entry.VideoID == "" || entry.ChannelID == "" {
+			continue
+		}
+		uploads[entry.ChannelID] = append(uploads[entry.ChannelID], &pb.VideoInfo{
+			VideoId:      entry.VideoID,
+			Title:        entry.Title,
+			ThumbnailUrl: "https://i.ytimg.com/vi/" + entry.VideoID + "/default.jpg",
+			PublishedAt:  entry.Published,
+			ChannelId:    entry.ChannelID,
+			ChannelTitle: entry.Author.Name,
+		})
+	}
+	return uploads, nil
+}
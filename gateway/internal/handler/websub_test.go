@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"gateway/internal/client"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "shared/proto"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testChannelID = "UCabcdefghijklmnopqrstuv"
+
+const uploadNotification = `<?xml version='1.0' encoding='UTF-8'?>
+<feed xmlns:yt="http://www.youtube.com/xml/schemas/2015" xmlns="http://www.w3.org/2005/Atom">
+  <link rel="hub" href="https://pubsubhubbub.appspot.com"/>
+  <link rel="self" href="https://www.youtube.com/xml/feeds/videos.xml?channel_id=UCabcdefghijklmnopqrstuv"/>
+  <title>YouTube video feed</title>
+  <updated>2026-10-18T09:05:24.552394234+00:00</updated>
+  <entry>
+    <id>yt:video:dQw4w9WgXcQ</id>
+    <yt:videoId>dQw4w9WgXcQ</yt:videoId>
+    <yt:channelId>UCabcdefghijklmnopqrstuv</yt:channelId>
+    <title>New upload</title>
+    <link rel="alternate" href="https://www.youtube.com/watch?v=dQw4w9WgXcQ"/>
+    <author>
+      <name>Test Channel</name>
+      <uri>https://www.youtube.com/channel/UCabcdefghijklmnopqrstuv</uri>
+    </author>
+    <published>2026-10-18T09:00:00+00:00</published>
+    <updated>2026-10-18T09:05:24.552394234+00:00</updated>
+  </entry>
+</feed>`
+
+// fakeVideoService stands in for the video service's push RPCs.
+type fakeVideoService struct {
+	pb.UnimplementedVideoServiceServer
+	mu        sync.Mutex
+	confirmed []*pb.ConfirmPushSubscriptionRequest
+	ingested  []*pb.IngestChannelUploadsRequest
+}
+
+func (f *fakeVideoService) ConfirmPushSubscription(ctx context.Context, req *pb.ConfirmPushSubscriptionRequest) (*pb.ConfirmPushSubscriptionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.ChannelId != testChannelID {
+		return nil, status.Error(codes.NotFound, "not requested")
+	}
+	if req.VerifyToken != "token-1" {
+		return nil, status.Error(codes.PermissionDenied, "verify token does not match")
+	}
+	f.confirmed = append(f.confirmed, req)
+	return &pb.ConfirmPushSubscriptionResponse{}, nil
+}
+
+func (f *fakeVideoService) IngestChannelUploads(ctx context.Context, req *pb.IngestChannelUploadsRequest) (*pb.IngestChannelUploadsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ingested = append(f.ingested, req)
+	return &pb.IngestChannelUploadsResponse{}, nil
+}
+
+// newTestVideoClient serves videoService over gRPC and returns a client of
+// it.
+func newTestVideoClient(t *testing.T, videoService pb.VideoServiceServer) *client.VideoClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterVideoServiceServer(grpcServer, videoService)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	videoClient, err := client.NewVideoClient(lis.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { videoClient.Close() })
+	return videoClient
+}
+
+func newWebSubServer(t *testing.T, secret string) (*httptest.Server, *fakeVideoService) {
+	videoService := &fakeVideoService{}
+	wh := NewWebSubHandler(newTestVideoClient(t, videoService), secret)
+	r := mux.NewRouter()
+	r.HandleFunc("/websub/youtube", wh.Verify).Methods("GET")
+	r.HandleFunc("/websub/youtube", wh.Notify).Methods("POST")
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server, videoService
+}
+
+// hubVerify calls the callback the way a hub verifies a subscription,
+// returning the status and body of the response.
+func hubVerify(t *testing.T, callback, mode, channelID, token string) (int, string) {
+	q := url.Values{
+		"hub.mode":          {mode},
+		"hub.topic":         {"https://www.youtube.com/xml/feeds/videos.xml?channel_id=" + channelID},
+		"hub.challenge":     {"challenge-123"},
+		"hub.lease_seconds": {"432000"},
+		"hub.verify_token":  {token},
+	}
+	resp, err := http.Get(callback + "?" + q.Encode())
+	if err != nil {
+		t.Fatalf("Verification failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+// hubNotify posts a notification the way a hub does, signed with secret.
+func hubNotify(t *testing.T, callback, secret, body string) int {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(body))
+	req, _ := http.NewRequest(http.MethodPost, callback, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/atom+xml")
+	req.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Notification failed: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestWebSubVerify(t *testing.T) {
+	server, videoService := newWebSubServer(t, "s3cret")
+	callback := server.URL + "/websub/youtube"
+
+	code, body := hubVerify(t, callback, "subscribe", testChannelID, "token-1")
+	if code != http.StatusOK || body != "challenge-123" {
+		t.Errorf("Expected the challenge echoed, got %d %q", code, body)
+	}
+	if len(videoService.confirmed) != 1 || videoService.confirmed[0].Mode != "subscribe" || videoService.confirmed[0].LeaseSeconds != 432000 {
+		t.Errorf("Expected the subscription confirmed with its lease, got %v", videoService.confirmed)
+	}
+
+	if code, body := hubVerify(t, callback, "subscribe", testChannelID, "forged"); code != http.StatusNotFound || strings.Contains(body, "challenge-123") {
+		t.Errorf("Expected a verification with the wrong token refused, got %d %q", code, body)
+	}
+	if code, body := hubVerify(t, callback, "subscribe", "UCunrequestedchannel0000", "token-1"); code != http.StatusNotFound || strings.Contains(body, "challenge-123") {
+		t.Errorf("Expected an unrequested subscription refused, got %d %q", code, body)
+	}
+
+	resp, err := http.Get(callback + "?hub.mode=subscribe&hub.topic=https://example.com/feed&hub.challenge=x")
+	if err != nil {
+		t.Fatalf("Verification failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected other topics refused, got %d", resp.StatusCode)
+	}
+}
+
+func TestWebSubNotify(t *testing.T) {
+	server, videoService := newWebSubServer(t, "s3cret")
+	callback := server.URL + "/websub/youtube"
+
+	if code := hubNotify(t, callback, "s3cret", uploadNotification); code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", code)
+	}
+	if len(videoService.ingested) != 1 {
+		t.Fatalf("Expected the uploads ingested, got %v", videoService.ingested)
+	}
+	req := videoService.ingested[0]
+	if req.ChannelId != testChannelID || len(req.Videos) != 1 {
+		t.Fatalf("Expected one upload of the channel, got %v", req)
+	}
+	video := req.Videos[0]
+	if video.VideoId != "dQw4w9WgXcQ" || video.Title != "New upload" || video.ChannelTitle != "Test Channel" || video.PublishedAt != "2026-10-18T09:00:00+00:00" {
+		t.Errorf("Expected the entry's details, got %v", video)
+	}
+
+	if code := hubNotify(t, callback, "wrong", uploadNotification); code != http.StatusAccepted {
+		t.Errorf("Expected a forged notification acknowledged, got %d", code)
+	}
+	if code := hubNotify(t, callback, "s3cret", "not xml"); code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", code)
+	}
+	if len(videoService.ingested) != 1 {
+		t.Errorf("Expected forged and invalid notifications dropped, got %v", videoService.ingested)
+	}
+}
+
+func TestWebSubNotify_NoSecret(t *testing.T) {
+	server, videoService := newWebSubServer(t, "")
+	callback := server.URL + "/websub/youtube"
+
+	if code := hubNotify(t, callback, "", uploadNotification); code != http.StatusAccepted {
+		t.Errorf("Expected the notification acknowledged, got %d", code)
+	}
+	if len(videoService.ingested) != 0 {
+		t.Errorf("Expected notifications dropped without a secret, got %v", videoService.ingested)
+	}
+}
+
+func TestValidHubSignature(t *testing.T) {
+	body := []byte("<feed/>")
+	if validHubSignature("", "", body) || validHubSignature("", "sha1="+hex.EncodeToString(hmac.New(sha1.New, nil).Sum(nil)), body) {
+		t.Error("Expected every notification rejected without a secret")
+	}
+	for _, header := range []string{"", "sha1", "md5=abc", "sha1=zz", "sha256=" + hex.EncodeToString(make([]byte, 32))} {
+		if validHubSignature("s3cret", header, body) {
+			t.Errorf("Expected %q rejected", header)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"gateway/internal/client"
 	pb "shared/proto"
+
+	"google.golang.org/grpc/metadata"
 )
 
 type Middleware struct {
@@ -57,6 +59,7 @@ func (m *Middleware) AuthMiddleware(next http.Handler) http.Handler {
 
 		ctx := context.WithValue(r.Context(), "user_id", resp.UserId)
 		ctx = context.WithValue(ctx, "username", resp.Username)
+		ctx = withPlan(ctx, resp.Plan)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -89,6 +92,7 @@ func (m *Middleware) SSRAuthMiddleware(next http.Handler) http.Handler {
 		// Set context values
 		ctx := context.WithValue(r.Context(), "user_id", resp.UserId)
 		ctx = context.WithValue(ctx, "username", resp.Username)
+		ctx = withPlan(ctx, resp.Plan)
 
 		// Set Authorization header for internal calls (re-using bearer auth pattern)
 		r.Header.Set("Authorization", "Bearer "+token)
@@ -96,3 +100,11 @@ func (m *Middleware) SSRAuthMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// withPlan stores the user's plan in ctx and forwards it to the backend
+// services as x-user-plan metadata, which the video service enforces quotas
+// by.
+func withPlan(ctx context.Context, plan string) context.Context {
+	ctx = context.WithValue(ctx, "plan", plan)
+	return metadata.AppendToOutgoingContext(ctx, "x-user-plan", plan)
+}
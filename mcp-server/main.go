@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -147,6 +148,10 @@ func registerTools(s *server.MCPServer, videoClient pb.VideoServiceClient) {
 	s.AddTool(mcp.NewTool("summarize_video",
 		mcp.WithDescription("Generate an AI summary for a YouTube video based on its transcript"),
 		mcp.WithString("video_id", mcp.Required(), mcp.Description("YouTube Video ID")),
+		mcp.WithString("style", mcp.Enum("tldr", "bullets", "detailed", "executive", "study_guide", "tweet_thread"), mcp.Description("Summary style (default detailed)")),
+		mcp.WithString("length", mcp.Enum("short", "medium", "long"), mcp.Description("Target length (default medium)")),
+		mcp.WithString("language", mcp.Description("Language to write the summary in, e.g. Spanish (default: the video's language)")),
+		mcp.WithBoolean("structured", mcp.Description("Also return title, TL;DR, chapters with timestamps, key takeaways, entities, resources and action items as JSON")),
 	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		videoID, err := request.RequireString("video_id")
 		if err != nil {
@@ -154,13 +159,212 @@ func registerTools(s *server.MCPServer, videoClient pb.VideoServiceClient) {
 		}
 
 		resp, err := videoClient.SummarizeVideo(ctx, &pb.SummarizeVideoRequest{
-			VideoId: videoID,
-			UserId:  "mcp-user",
+			VideoId:    videoID,
+			UserId:     "mcp-user",
+			Style:      request.GetString("style", ""),
+			Length:     request.GetString("length", ""),
+			Language:   request.GetString("language", ""),
+			Structured: request.GetBool("structured", false),
 		})
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Error generating summary: %v", err)), nil
 		}
 
+		if resp.Structured != nil {
+			data, err := json.MarshalIndent(resp.Structured, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Error encoding summary: %v", err)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Summary for Video [%s]:\n\n%s\n\nStructured summary (JSON):\n%s", resp.VideoId, resp.Summary, data)), nil
+		}
+
 		return mcp.NewToolResultText(fmt.Sprintf("Summary for Video [%s]:\n\n%s", resp.VideoId, resp.Summary)), nil
 	})
+
+	// 6. Semantic Search
+	s.AddTool(mcp.NewTool("semantic_search",
+		mcp.WithDescription("Search video transcripts by meaning and return the most relevant passages with timestamps"),
+		mcp.WithString("query", mcp.Required(), mcp.Description("Natural-language search query")),
+		mcp.WithNumber("top_k", mcp.Description("Number of passages to return (default 5)")),
+		mcp.WithArray("video_ids", mcp.WithStringItems(), mcp.Description("Restrict the search to these YouTube Video IDs; they are indexed on first use")),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing argument: %v", err)), nil
+		}
+
+		resp, err := videoClient.SemanticSearch(ctx, &pb.SemanticSearchRequest{
+			Query:    query,
+			TopK:     int32(request.GetFloat("top_k", 5)),
+			VideoIds: request.GetStringSlice("video_ids", nil),
+			UserId:   "mcp-user",
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error searching transcripts: %v", err)), nil
+		}
+
+		if len(resp.Results) == 0 {
+			return mcp.NewToolResultText("No matching passages found."), nil
+		}
+
+		var resultText string
+		for _, r := range resp.Results {
+			resultText += fmt.Sprintf("- [%s @ %s] (score %.2f) %s\n", r.VideoId, formatTimestamp(r.StartSeconds), r.Score, r.Text)
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	})
+
+	// 7. Ask Video
+	s.AddTool(mcp.NewTool("ask_video",
+		mcp.WithDescription("Ask a question about a YouTube video and get an answer grounded in its transcript, with timestamp citations. Follow-up questions continue the same conversation."),
+		mcp.WithString("video_id", mcp.Required(), mcp.Description("YouTube Video ID")),
+		mcp.WithString("question", mcp.Required(), mcp.Description("Question about the video")),
+		mcp.WithBoolean("reset_history", mcp.Description("Start a new conversation instead of continuing the previous one")),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		videoID, err := request.RequireString("video_id")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing argument: %v", err)), nil
+		}
+		question, err := request.RequireString("question")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing argument: %v", err)), nil
+		}
+
+		resp, err := videoClient.AskVideo(ctx, &pb.AskVideoRequest{
+			VideoId:      videoID,
+			Question:     question,
+			ResetHistory: request.GetBool("reset_history", false),
+			UserId:       "mcp-user",
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error answering question: %v", err)), nil
+		}
+
+		resultText := resp.Answer
+		if len(resp.Citations) > 0 {
+			resultText += "\n\nSources:\n"
+			for _, c := range resp.Citations {
+				resultText += fmt.Sprintf("- https://www.youtube.com/watch?v=%s&t=%ds [%s]\n", resp.VideoId, int(c.StartSeconds), formatTimestamp(c.StartSeconds))
+			}
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	})
+
+	// 8. Generate Chapters
+	s.AddTool(mcp.NewTool("generate_chapters",
+		mcp.WithDescription("Split a YouTube video into chapters with start timestamps, ready to paste into the video description"),
+		mcp.WithString("video_id", mcp.Required(), mcp.Description("YouTube Video ID")),
+		mcp.WithBoolean("refresh", mcp.Description("Regenerate instead of returning cached chapters")),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		videoID, err := request.RequireString("video_id")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing argument: %v", err)), nil
+		}
+
+		resp, err := videoClient.GenerateChapters(ctx, &pb.GenerateChaptersRequest{
+			VideoId: videoID,
+			Refresh: request.GetBool("refresh", false),
+			UserId:  "mcp-user",
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error generating chapters: %v", err)), nil
+		}
+
+		if resp.YoutubeDescription != "" {
+			return mcp.NewToolResultText(fmt.Sprintf("Chapters for Video [%s]:\n\n%s", resp.VideoId, resp.YoutubeDescription)), nil
+		}
+
+		resultText := fmt.Sprintf("Chapters for Video [%s] (too few for YouTube chapters):\n\n", resp.VideoId)
+		for _, c := range resp.Chapters {
+			resultText += fmt.Sprintf("%s %s\n", formatTimestamp(c.StartSeconds), c.Title)
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	})
+
+	// 9. Compare Videos
+	s.AddTool(mcp.NewTool("compare_videos",
+		mcp.WithDescription("Summarize 2 to 10 YouTube videos and compare where they agree and disagree, citing each video as [n]"),
+		mcp.WithArray("video_ids", mcp.Required(), mcp.WithStringItems(), mcp.Description("YouTube Video IDs to compare")),
+		mcp.WithString("focus", mcp.Description("Optional question or angle to compare the videos on")),
+		mcp.WithString("language", mcp.Description("Language to write the digest in (default English)")),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		videoIDs, err := request.RequireStringSlice("video_ids")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing argument: %v", err)), nil
+		}
+
+		resp, err := videoClient.SummarizeVideos(ctx, &pb.SummarizeVideosRequest{
+			VideoIds: videoIDs,
+			Focus:    request.GetString("focus", ""),
+			Language: request.GetString("language", ""),
+			UserId:   "mcp-user",
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error comparing videos: %v", err)), nil
+		}
+
+		resultText := resp.Digest + "\n\nVideos:\n"
+		for i, v := range resp.Videos {
+			resultText += fmt.Sprintf("[%d] %s (https://www.youtube.com/watch?v=%s)", i+1, v.Title, v.VideoId)
+			if v.Error != "" {
+				resultText += fmt.Sprintf(" - not included: %s", v.Error)
+			}
+			resultText += "\n"
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	})
+
+	// 10. Summarize Channel
+	s.AddTool(mcp.NewTool("summarize_channel",
+		mcp.WithDescription("Write a newsletter-style digest of a YouTube channel's recent uploads, citing each video as [n]"),
+		mcp.WithString("channel_id", mcp.Required(), mcp.Description("YouTube Channel ID")),
+		mcp.WithNumber("days", mcp.Description("How many days back to look, up to 31 (default 7)")),
+		mcp.WithNumber("max_videos", mcp.Description("Most recent uploads to include, up to 20 (default 10)")),
+		mcp.WithString("language", mcp.Description("Language to write the digest in (default English)")),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelID, err := request.RequireString("channel_id")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Missing argument: %v", err)), nil
+		}
+
+		resp, err := videoClient.SummarizeChannel(ctx, &pb.SummarizeChannelRequest{
+			ChannelId: channelID,
+			Days:      int32(request.GetFloat("days", 7)),
+			MaxVideos: int32(request.GetFloat("max_videos", 10)),
+			Language:  request.GetString("language", ""),
+			UserId:    "mcp-user",
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error summarizing channel: %v", err)), nil
+		}
+
+		if len(resp.Videos) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("No uploads from channel [%s] since %s", resp.ChannelId, resp.Since)), nil
+		}
+
+		resultText := resp.Digest + "\n\nVideos:\n"
+		for i, v := range resp.Videos {
+			resultText += fmt.Sprintf("[%d] %s (https://www.youtube.com/watch?v=%s)", i+1, v.Title, v.VideoId)
+			if v.Error != "" {
+				resultText += fmt.Sprintf(" - not included: %s", v.Error)
+			}
+			resultText += "\n"
+		}
+
+		return mcp.NewToolResultText(resultText), nil
+	})
+}
+
+// formatTimestamp renders seconds as h:mm:ss or m:ss, the way YouTube does.
+func formatTimestamp(seconds float64) string {
+	total := int(seconds)
+	h, m, sec := total/3600, (total%3600)/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
 }
@@ -20,6 +20,11 @@ type MockVideoClient struct {
 	GetVideoDetailsFunc    func(ctx context.Context, in *pb.GetVideoDetailsRequest, opts ...grpc.CallOption) (*pb.GetVideoDetailsResponse, error)
 	GetVideoTranscriptFunc func(ctx context.Context, in *pb.GetVideoTranscriptRequest, opts ...grpc.CallOption) (*pb.GetVideoTranscriptResponse, error)
 	SummarizeVideoFunc     func(ctx context.Context, in *pb.SummarizeVideoRequest, opts ...grpc.CallOption) (*pb.SummarizeVideoResponse, error)
+	SemanticSearchFunc     func(ctx context.Context, in *pb.SemanticSearchRequest, opts ...grpc.CallOption) (*pb.SemanticSearchResponse, error)
+	AskVideoFunc           func(ctx context.Context, in *pb.AskVideoRequest, opts ...grpc.CallOption) (*pb.AskVideoResponse, error)
+	GenerateChaptersFunc   func(ctx context.Context, in *pb.GenerateChaptersRequest, opts ...grpc.CallOption) (*pb.GenerateChaptersResponse, error)
+	SummarizeVideosFunc    func(ctx context.Context, in *pb.SummarizeVideosRequest, opts ...grpc.CallOption) (*pb.SummarizeVideosResponse, error)
+	SummarizeChannelFunc   func(ctx context.Context, in *pb.SummarizeChannelRequest, opts ...grpc.CallOption) (*pb.SummarizeChannelResponse, error)
 }
 
 func (m *MockVideoClient) SearchChannel(ctx context.Context, in *pb.SearchChannelRequest, opts ...grpc.CallOption) (*pb.SearchChannelResponse, error) {
@@ -42,6 +47,26 @@ func (m *MockVideoClient) SummarizeVideo(ctx context.Context, in *pb.SummarizeVi
 	return m.SummarizeVideoFunc(ctx, in, opts...)
 }
 
+func (m *MockVideoClient) SemanticSearch(ctx context.Context, in *pb.SemanticSearchRequest, opts ...grpc.CallOption) (*pb.SemanticSearchResponse, error) {
+	return m.SemanticSearchFunc(ctx, in, opts...)
+}
+
+func (m *MockVideoClient) AskVideo(ctx context.Context, in *pb.AskVideoRequest, opts ...grpc.CallOption) (*pb.AskVideoResponse, error) {
+	return m.AskVideoFunc(ctx, in, opts...)
+}
+
+func (m *MockVideoClient) GenerateChapters(ctx context.Context, in *pb.GenerateChaptersRequest, opts ...grpc.CallOption) (*pb.GenerateChaptersResponse, error) {
+	return m.GenerateChaptersFunc(ctx, in, opts...)
+}
+
+func (m *MockVideoClient) SummarizeVideos(ctx context.Context, in *pb.SummarizeVideosRequest, opts ...grpc.CallOption) (*pb.SummarizeVideosResponse, error) {
+	return m.SummarizeVideosFunc(ctx, in, opts...)
+}
+
+func (m *MockVideoClient) SummarizeChannel(ctx context.Context, in *pb.SummarizeChannelRequest, opts ...grpc.CallOption) (*pb.SummarizeChannelResponse, error) {
+	return m.SummarizeChannelFunc(ctx, in, opts...)
+}
+
 func TestSearchChannelTool(t *testing.T) {
 	s := server.NewMCPServer("Test", "1.0.0")
 	mock := &MockVideoClient{
@@ -228,6 +253,265 @@ func TestSummarizeVideoTool(t *testing.T) {
 	}
 }
 
+func TestSummarizeVideoToolStyle(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	var got *pb.SummarizeVideoRequest
+	mock := &MockVideoClient{
+		SummarizeVideoFunc: func(ctx context.Context, in *pb.SummarizeVideoRequest, opts ...grpc.CallOption) (*pb.SummarizeVideoResponse, error) {
+			got = in
+			return &pb.SummarizeVideoResponse{VideoId: in.VideoId, Summary: "1/ A thread."}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("summarize_video").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"video_id": "vid789",
+		"style":    "tweet_thread",
+		"length":   "short",
+		"language": "French",
+	}
+
+	if _, err := handler(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if got.Style != "tweet_thread" || got.Length != "short" || got.Language != "French" {
+		t.Errorf("expected style options to be forwarded, got %q/%q/%q", got.Style, got.Length, got.Language)
+	}
+}
+
+func TestSummarizeVideoToolStructured(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	mock := &MockVideoClient{
+		SummarizeVideoFunc: func(ctx context.Context, in *pb.SummarizeVideoRequest, opts ...grpc.CallOption) (*pb.SummarizeVideoResponse, error) {
+			if !in.Structured {
+				t.Error("expected structured flag to be forwarded")
+			}
+			return &pb.SummarizeVideoResponse{
+				VideoId: in.VideoId,
+				Summary: "# A Talk",
+				Structured: &pb.StructuredSummary{
+					Title:    "A Talk",
+					Chapters: []*pb.SummaryChapter{{Title: "Intro", StartSeconds: 0}},
+				},
+			}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("summarize_video").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"video_id": "vid789", "structured": true}
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok || !strings.Contains(text.Text, `"chapters"`) {
+		t.Errorf("expected structured JSON in result, got %+v", result.Content)
+	}
+}
+
+func TestSemanticSearchTool(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	mock := &MockVideoClient{
+		SemanticSearchFunc: func(ctx context.Context, in *pb.SemanticSearchRequest, opts ...grpc.CallOption) (*pb.SemanticSearchResponse, error) {
+			if len(in.VideoIds) != 1 || in.VideoIds[0] != "vid123" {
+				t.Errorf("expected video_ids [vid123], got %v", in.VideoIds)
+			}
+			return &pb.SemanticSearchResponse{
+				Results: []*pb.SemanticSearchResult{
+					{VideoId: "vid123", Text: "gradient descent explained", StartSeconds: 3725, Score: 0.91},
+				},
+			}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("semantic_search").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"query": "optimization", "video_ids": []any{"vid123"}}
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			if strings.Contains(text.Text, "[vid123 @ 1:02:05]") && strings.Contains(text.Text, "gradient descent explained") {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected timestamped passage in result, got %+v", result.Content)
+	}
+}
+
+func TestAskVideoTool(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	mock := &MockVideoClient{
+		AskVideoFunc: func(ctx context.Context, in *pb.AskVideoRequest, opts ...grpc.CallOption) (*pb.AskVideoResponse, error) {
+			if in.Question != "What is covered?" {
+				t.Errorf("expected question to be forwarded, got %q", in.Question)
+			}
+			return &pb.AskVideoResponse{
+				VideoId:   in.VideoId,
+				Answer:    "Mostly cats [1:15].",
+				Citations: []*pb.Citation{{StartSeconds: 75}},
+			}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("ask_video").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"video_id": "vid123", "question": "What is covered?"}
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			if strings.Contains(text.Text, "Mostly cats") && strings.Contains(text.Text, "watch?v=vid123&t=75s") {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected answer with source link in result, got %+v", result.Content)
+	}
+}
+
+func TestGenerateChaptersTool(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	mock := &MockVideoClient{
+		GenerateChaptersFunc: func(ctx context.Context, in *pb.GenerateChaptersRequest, opts ...grpc.CallOption) (*pb.GenerateChaptersResponse, error) {
+			if !in.Refresh {
+				t.Error("expected refresh to be forwarded")
+			}
+			return &pb.GenerateChaptersResponse{
+				VideoId:            in.VideoId,
+				Chapters:           []*pb.VideoChapter{{Title: "Intro"}, {Title: "Cats", StartSeconds: 75}, {Title: "Outro", StartSeconds: 300}},
+				YoutubeDescription: "0:00 Intro\n1:15 Cats\n5:00 Outro",
+			}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("generate_chapters").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"video_id": "vid123", "refresh": true}
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			if strings.Contains(text.Text, "0:00 Intro\n1:15 Cats") {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected YouTube chapter list in result, got %+v", result.Content)
+	}
+}
+
+func TestCompareVideosTool(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	mock := &MockVideoClient{
+		SummarizeVideosFunc: func(ctx context.Context, in *pb.SummarizeVideosRequest, opts ...grpc.CallOption) (*pb.SummarizeVideosResponse, error) {
+			if len(in.VideoIds) != 2 || in.Focus != "pricing" {
+				t.Errorf("expected both videos and the focus forwarded, got %v %q", in.VideoIds, in.Focus)
+			}
+			return &pb.SummarizeVideosResponse{
+				Digest: "Both agree on pricing [1][2].",
+				Videos: []*pb.VideoDigestItem{
+					{VideoId: "v1", Title: "Talk one", Summary: "..."},
+					{VideoId: "v2", Title: "Talk two", Error: "transcript not available"},
+				},
+			}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("compare_videos").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"video_ids": []any{"v1", "v2"}, "focus": "pricing"}
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			if strings.Contains(text.Text, "[1] Talk one") && strings.Contains(text.Text, "[2] Talk two (https://www.youtube.com/watch?v=v2) - not included") {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected digest with numbered video list in result, got %+v", result.Content)
+	}
+}
+
+func TestSummarizeChannelTool(t *testing.T) {
+	s := server.NewMCPServer("Test", "1.0.0")
+	mock := &MockVideoClient{
+		SummarizeChannelFunc: func(ctx context.Context, in *pb.SummarizeChannelRequest, opts ...grpc.CallOption) (*pb.SummarizeChannelResponse, error) {
+			if in.ChannelId != "UC123" || in.Days != 14 || in.MaxVideos != 10 {
+				t.Errorf("expected channel, days and default max_videos forwarded, got %+v", in)
+			}
+			return &pb.SummarizeChannelResponse{
+				ChannelId: in.ChannelId,
+				Digest:    "# This fortnight\n\nA new lens [1].",
+				Videos:    []*pb.VideoDigestItem{{VideoId: "v1", Title: "Lens review"}},
+			}, nil
+		},
+	}
+	registerTools(s, mock)
+
+	handler := s.GetTool("summarize_channel").Handler
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"channel_id": "UC123", "days": 14}
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			if strings.Contains(text.Text, "A new lens [1].") && strings.Contains(text.Text, "[1] Lens review (https://www.youtube.com/watch?v=v1)") {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected digest with numbered video list in result, got %+v", result.Content)
+	}
+}
+
 func TestToolMissingArgument(t *testing.T) {
 	s := server.NewMCPServer("Test", "1.0.0")
 	mock := &MockVideoClient{}
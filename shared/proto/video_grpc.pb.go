@@ -19,11 +19,49 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	VideoService_SearchChannel_FullMethodName      = "/video.VideoService/SearchChannel"
-	VideoService_GetChannelVideos_FullMethodName   = "/video.VideoService/GetChannelVideos"
-	VideoService_GetVideoDetails_FullMethodName    = "/video.VideoService/GetVideoDetails"
-	VideoService_GetVideoTranscript_FullMethodName = "/video.VideoService/GetVideoTranscript"
-	VideoService_SummarizeVideo_FullMethodName     = "/video.VideoService/SummarizeVideo"
+	VideoService_SearchChannel_FullMethodName           = "/video.VideoService/SearchChannel"
+	VideoService_GetChannelVideos_FullMethodName        = "/video.VideoService/GetChannelVideos"
+	VideoService_GetVideoDetails_FullMethodName         = "/video.VideoService/GetVideoDetails"
+	VideoService_GetVideoTranscript_FullMethodName      = "/video.VideoService/GetVideoTranscript"
+	VideoService_SummarizeVideo_FullMethodName          = "/video.VideoService/SummarizeVideo"
+	VideoService_SummarizeVideoStream_FullMethodName    = "/video.VideoService/SummarizeVideoStream"
+	VideoService_SemanticSearch_FullMethodName          = "/video.VideoService/SemanticSearch"
+	VideoService_AskVideo_FullMethodName                = "/video.VideoService/AskVideo"
+	VideoService_GetConversation_FullMethodName         = "/video.VideoService/GetConversation"
+	VideoService_GenerateChapters_FullMethodName        = "/video.VideoService/GenerateChapters"
+	VideoService_GetUsage_FullMethodName                = "/video.VideoService/GetUsage"
+	VideoService_SubmitSummaryJob_FullMethodName        = "/video.VideoService/SubmitSummaryJob"
+	VideoService_GetJob_FullMethodName                  = "/video.VideoService/GetJob"
+	VideoService_ListJobs_FullMethodName                = "/video.VideoService/ListJobs"
+	VideoService_CreateWebhook_FullMethodName           = "/video.VideoService/CreateWebhook"
+	VideoService_ListWebhooks_FullMethodName            = "/video.VideoService/ListWebhooks"
+	VideoService_DeleteWebhook_FullMethodName           = "/video.VideoService/DeleteWebhook"
+	VideoService_ListWebhookDeliveries_FullMethodName   = "/video.VideoService/ListWebhookDeliveries"
+	VideoService_ReplayWebhookDelivery_FullMethodName   = "/video.VideoService/ReplayWebhookDelivery"
+	VideoService_SummarizeVideos_FullMethodName         = "/video.VideoService/SummarizeVideos"
+	VideoService_SummarizeChannel_FullMethodName        = "/video.VideoService/SummarizeChannel"
+	VideoService_Subscribe_FullMethodName               = "/video.VideoService/Subscribe"
+	VideoService_Unsubscribe_FullMethodName             = "/video.VideoService/Unsubscribe"
+	VideoService_ListSubscriptions_FullMethodName       = "/video.VideoService/ListSubscriptions"
+	VideoService_ConfirmPushSubscription_FullMethodName = "/video.VideoService/ConfirmPushSubscription"
+	VideoService_IngestChannelUploads_FullMethodName    = "/video.VideoService/IngestChannelUploads"
+	VideoService_GetFeed_FullMethodName                 = "/video.VideoService/GetFeed"
+	VideoService_CreateCollection_FullMethodName        = "/video.VideoService/CreateCollection"
+	VideoService_ListCollections_FullMethodName         = "/video.VideoService/ListCollections"
+	VideoService_GetCollection_FullMethodName           = "/video.VideoService/GetCollection"
+	VideoService_UpdateCollection_FullMethodName        = "/video.VideoService/UpdateCollection"
+	VideoService_DeleteCollection_FullMethodName        = "/video.VideoService/DeleteCollection"
+	VideoService_AddToCollection_FullMethodName         = "/video.VideoService/AddToCollection"
+	VideoService_RemoveFromCollection_FullMethodName    = "/video.VideoService/RemoveFromCollection"
+	VideoService_SetVideoTags_FullMethodName            = "/video.VideoService/SetVideoTags"
+	VideoService_ListTags_FullMethodName                = "/video.VideoService/ListTags"
+	VideoService_ExportCollection_FullMethodName        = "/video.VideoService/ExportCollection"
+	VideoService_CreateNote_FullMethodName              = "/video.VideoService/CreateNote"
+	VideoService_ListNotes_FullMethodName               = "/video.VideoService/ListNotes"
+	VideoService_DeleteNote_FullMethodName              = "/video.VideoService/DeleteNote"
+	VideoService_ExportVideo_FullMethodName             = "/video.VideoService/ExportVideo"
+	VideoService_GetFeedToken_FullMethodName            = "/video.VideoService/GetFeedToken"
+	VideoService_GetSummaryFeed_FullMethodName          = "/video.VideoService/GetSummaryFeed"
 )
 
 // VideoServiceClient is the client API for VideoService service.
@@ -35,6 +73,44 @@ type VideoServiceClient interface {
 	GetVideoDetails(ctx context.Context, in *GetVideoDetailsRequest, opts ...grpc.CallOption) (*GetVideoDetailsResponse, error)
 	GetVideoTranscript(ctx context.Context, in *GetVideoTranscriptRequest, opts ...grpc.CallOption) (*GetVideoTranscriptResponse, error)
 	SummarizeVideo(ctx context.Context, in *SummarizeVideoRequest, opts ...grpc.CallOption) (*SummarizeVideoResponse, error)
+	SummarizeVideoStream(ctx context.Context, in *SummarizeVideoRequest, opts ...grpc.CallOption) (VideoService_SummarizeVideoStreamClient, error)
+	SemanticSearch(ctx context.Context, in *SemanticSearchRequest, opts ...grpc.CallOption) (*SemanticSearchResponse, error)
+	AskVideo(ctx context.Context, in *AskVideoRequest, opts ...grpc.CallOption) (*AskVideoResponse, error)
+	GetConversation(ctx context.Context, in *GetConversationRequest, opts ...grpc.CallOption) (*GetConversationResponse, error)
+	GenerateChapters(ctx context.Context, in *GenerateChaptersRequest, opts ...grpc.CallOption) (*GenerateChaptersResponse, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	SubmitSummaryJob(ctx context.Context, in *SummarizeVideoRequest, opts ...grpc.CallOption) (*Job, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	SummarizeVideos(ctx context.Context, in *SummarizeVideosRequest, opts ...grpc.CallOption) (*SummarizeVideosResponse, error)
+	SummarizeChannel(ctx context.Context, in *SummarizeChannelRequest, opts ...grpc.CallOption) (*SummarizeChannelResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*Subscription, error)
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error)
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	ConfirmPushSubscription(ctx context.Context, in *ConfirmPushSubscriptionRequest, opts ...grpc.CallOption) (*ConfirmPushSubscriptionResponse, error)
+	IngestChannelUploads(ctx context.Context, in *IngestChannelUploadsRequest, opts ...grpc.CallOption) (*IngestChannelUploadsResponse, error)
+	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	AddToCollection(ctx context.Context, in *AddToCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	RemoveFromCollection(ctx context.Context, in *RemoveFromCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	SetVideoTags(ctx context.Context, in *SetVideoTagsRequest, opts ...grpc.CallOption) (*VideoTags, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	ExportCollection(ctx context.Context, in *ExportCollectionRequest, opts ...grpc.CallOption) (*ExportCollectionResponse, error)
+	CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*Note, error)
+	ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (*ListNotesResponse, error)
+	DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
+	ExportVideo(ctx context.Context, in *ExportVideoRequest, opts ...grpc.CallOption) (*ExportVideoResponse, error)
+	GetFeedToken(ctx context.Context, in *GetFeedTokenRequest, opts ...grpc.CallOption) (*FeedToken, error)
+	GetSummaryFeed(ctx context.Context, in *GetSummaryFeedRequest, opts ...grpc.CallOption) (*SummaryFeed, error)
 }
 
 type videoServiceClient struct {
@@ -90,6 +166,371 @@ func (c *videoServiceClient) SummarizeVideo(ctx context.Context, in *SummarizeVi
 	return out, nil
 }
 
+func (c *videoServiceClient) SummarizeVideoStream(ctx context.Context, in *SummarizeVideoRequest, opts ...grpc.CallOption) (VideoService_SummarizeVideoStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VideoService_ServiceDesc.Streams[0], VideoService_SummarizeVideoStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &videoServiceSummarizeVideoStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VideoService_SummarizeVideoStreamClient interface {
+	Recv() (*SummarizeVideoChunk, error)
+	grpc.ClientStream
+}
+
+type videoServiceSummarizeVideoStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *videoServiceSummarizeVideoStreamClient) Recv() (*SummarizeVideoChunk, error) {
+	m := new(SummarizeVideoChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *videoServiceClient) SemanticSearch(ctx context.Context, in *SemanticSearchRequest, opts ...grpc.CallOption) (*SemanticSearchResponse, error) {
+	out := new(SemanticSearchResponse)
+	err := c.cc.Invoke(ctx, VideoService_SemanticSearch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) AskVideo(ctx context.Context, in *AskVideoRequest, opts ...grpc.CallOption) (*AskVideoResponse, error) {
+	out := new(AskVideoResponse)
+	err := c.cc.Invoke(ctx, VideoService_AskVideo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetConversation(ctx context.Context, in *GetConversationRequest, opts ...grpc.CallOption) (*GetConversationResponse, error) {
+	out := new(GetConversationResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetConversation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GenerateChapters(ctx context.Context, in *GenerateChaptersRequest, opts ...grpc.CallOption) (*GenerateChaptersResponse, error) {
+	out := new(GenerateChaptersResponse)
+	err := c.cc.Invoke(ctx, VideoService_GenerateChapters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) SubmitSummaryJob(ctx context.Context, in *SummarizeVideoRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, VideoService_SubmitSummaryJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, VideoService_GetJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, VideoService_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, VideoService_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, VideoService_ReplayWebhookDelivery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) SummarizeVideos(ctx context.Context, in *SummarizeVideosRequest, opts ...grpc.CallOption) (*SummarizeVideosResponse, error) {
+	out := new(SummarizeVideosResponse)
+	err := c.cc.Invoke(ctx, VideoService_SummarizeVideos_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) SummarizeChannel(ctx context.Context, in *SummarizeChannelRequest, opts ...grpc.CallOption) (*SummarizeChannelResponse, error) {
+	out := new(SummarizeChannelResponse)
+	err := c.cc.Invoke(ctx, VideoService_SummarizeChannel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*Subscription, error) {
+	out := new(Subscription)
+	err := c.cc.Invoke(ctx, VideoService_Subscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error) {
+	out := new(UnsubscribeResponse)
+	err := c.cc.Invoke(ctx, VideoService_Unsubscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListSubscriptions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ConfirmPushSubscription(ctx context.Context, in *ConfirmPushSubscriptionRequest, opts ...grpc.CallOption) (*ConfirmPushSubscriptionResponse, error) {
+	out := new(ConfirmPushSubscriptionResponse)
+	err := c.cc.Invoke(ctx, VideoService_ConfirmPushSubscription_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) IngestChannelUploads(ctx context.Context, in *IngestChannelUploadsRequest, opts ...grpc.CallOption) (*IngestChannelUploadsResponse, error) {
+	out := new(IngestChannelUploadsResponse)
+	err := c.cc.Invoke(ctx, VideoService_IngestChannelUploads_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error) {
+	out := new(GetFeedResponse)
+	err := c.cc.Invoke(ctx, VideoService_GetFeed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, VideoService_CreateCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListCollections_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetCollection(ctx context.Context, in *GetCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, VideoService_GetCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) UpdateCollection(ctx context.Context, in *UpdateCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, VideoService_UpdateCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, VideoService_DeleteCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) AddToCollection(ctx context.Context, in *AddToCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, VideoService_AddToCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) RemoveFromCollection(ctx context.Context, in *RemoveFromCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, VideoService_RemoveFromCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) SetVideoTags(ctx context.Context, in *SetVideoTagsRequest, opts ...grpc.CallOption) (*VideoTags, error) {
+	out := new(VideoTags)
+	err := c.cc.Invoke(ctx, VideoService_SetVideoTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ExportCollection(ctx context.Context, in *ExportCollectionRequest, opts ...grpc.CallOption) (*ExportCollectionResponse, error) {
+	out := new(ExportCollectionResponse)
+	err := c.cc.Invoke(ctx, VideoService_ExportCollection_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*Note, error) {
+	out := new(Note)
+	err := c.cc.Invoke(ctx, VideoService_CreateNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (*ListNotesResponse, error) {
+	out := new(ListNotesResponse)
+	err := c.cc.Invoke(ctx, VideoService_ListNotes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
+	out := new(DeleteNoteResponse)
+	err := c.cc.Invoke(ctx, VideoService_DeleteNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) ExportVideo(ctx context.Context, in *ExportVideoRequest, opts ...grpc.CallOption) (*ExportVideoResponse, error) {
+	out := new(ExportVideoResponse)
+	err := c.cc.Invoke(ctx, VideoService_ExportVideo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetFeedToken(ctx context.Context, in *GetFeedTokenRequest, opts ...grpc.CallOption) (*FeedToken, error) {
+	out := new(FeedToken)
+	err := c.cc.Invoke(ctx, VideoService_GetFeedToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoServiceClient) GetSummaryFeed(ctx context.Context, in *GetSummaryFeedRequest, opts ...grpc.CallOption) (*SummaryFeed, error) {
+	out := new(SummaryFeed)
+	err := c.cc.Invoke(ctx, VideoService_GetSummaryFeed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VideoServiceServer is the server API for VideoService service.
 // All implementations must embed UnimplementedVideoServiceServer
 // for forward compatibility
@@ -99,127 +540,966 @@ type VideoServiceServer interface {
 	GetVideoDetails(context.Context, *GetVideoDetailsRequest) (*GetVideoDetailsResponse, error)
 	GetVideoTranscript(context.Context, *GetVideoTranscriptRequest) (*GetVideoTranscriptResponse, error)
 	SummarizeVideo(context.Context, *SummarizeVideoRequest) (*SummarizeVideoResponse, error)
+	SummarizeVideoStream(*SummarizeVideoRequest, VideoService_SummarizeVideoStreamServer) error
+	SemanticSearch(context.Context, *SemanticSearchRequest) (*SemanticSearchResponse, error)
+	AskVideo(context.Context, *AskVideoRequest) (*AskVideoResponse, error)
+	GetConversation(context.Context, *GetConversationRequest) (*GetConversationResponse, error)
+	GenerateChapters(context.Context, *GenerateChaptersRequest) (*GenerateChaptersResponse, error)
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	SubmitSummaryJob(context.Context, *SummarizeVideoRequest) (*Job, error)
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*WebhookDelivery, error)
+	SummarizeVideos(context.Context, *SummarizeVideosRequest) (*SummarizeVideosResponse, error)
+	SummarizeChannel(context.Context, *SummarizeChannelRequest) (*SummarizeChannelResponse, error)
+	Subscribe(context.Context, *SubscribeRequest) (*Subscription, error)
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*UnsubscribeResponse, error)
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	ConfirmPushSubscription(context.Context, *ConfirmPushSubscriptionRequest) (*ConfirmPushSubscriptionResponse, error)
+	IngestChannelUploads(context.Context, *IngestChannelUploadsRequest) (*IngestChannelUploadsResponse, error)
+	GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*Collection, error)
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	GetCollection(context.Context, *GetCollectionRequest) (*Collection, error)
+	UpdateCollection(context.Context, *UpdateCollectionRequest) (*Collection, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	AddToCollection(context.Context, *AddToCollectionRequest) (*Collection, error)
+	RemoveFromCollection(context.Context, *RemoveFromCollectionRequest) (*Collection, error)
+	SetVideoTags(context.Context, *SetVideoTagsRequest) (*VideoTags, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	ExportCollection(context.Context, *ExportCollectionRequest) (*ExportCollectionResponse, error)
+	CreateNote(context.Context, *CreateNoteRequest) (*Note, error)
+	ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error)
+	DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
+	ExportVideo(context.Context, *ExportVideoRequest) (*ExportVideoResponse, error)
+	GetFeedToken(context.Context, *GetFeedTokenRequest) (*FeedToken, error)
+	GetSummaryFeed(context.Context, *GetSummaryFeedRequest) (*SummaryFeed, error)
 	mustEmbedUnimplementedVideoServiceServer()
 }
 
-// UnimplementedVideoServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedVideoServiceServer struct {
+// UnimplementedVideoServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedVideoServiceServer struct {
+}
+
+func (UnimplementedVideoServiceServer) SearchChannel(context.Context, *SearchChannelRequest) (*SearchChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchChannel not implemented")
+}
+func (UnimplementedVideoServiceServer) GetChannelVideos(context.Context, *GetChannelVideosRequest) (*GetChannelVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelVideos not implemented")
+}
+func (UnimplementedVideoServiceServer) GetVideoDetails(context.Context, *GetVideoDetailsRequest) (*GetVideoDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoDetails not implemented")
+}
+func (UnimplementedVideoServiceServer) GetVideoTranscript(context.Context, *GetVideoTranscriptRequest) (*GetVideoTranscriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoTranscript not implemented")
+}
+func (UnimplementedVideoServiceServer) SummarizeVideo(context.Context, *SummarizeVideoRequest) (*SummarizeVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeVideo not implemented")
+}
+func (UnimplementedVideoServiceServer) SummarizeVideoStream(*SummarizeVideoRequest, VideoService_SummarizeVideoStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SummarizeVideoStream not implemented")
+}
+func (UnimplementedVideoServiceServer) SemanticSearch(context.Context, *SemanticSearchRequest) (*SemanticSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SemanticSearch not implemented")
+}
+func (UnimplementedVideoServiceServer) AskVideo(context.Context, *AskVideoRequest) (*AskVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AskVideo not implemented")
+}
+func (UnimplementedVideoServiceServer) GetConversation(context.Context, *GetConversationRequest) (*GetConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConversation not implemented")
+}
+func (UnimplementedVideoServiceServer) GenerateChapters(context.Context, *GenerateChaptersRequest) (*GenerateChaptersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateChapters not implemented")
+}
+func (UnimplementedVideoServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedVideoServiceServer) SubmitSummaryJob(context.Context, *SummarizeVideoRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitSummaryJob not implemented")
+}
+func (UnimplementedVideoServiceServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedVideoServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedVideoServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedVideoServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedVideoServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedVideoServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedVideoServiceServer) ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedVideoServiceServer) SummarizeVideos(context.Context, *SummarizeVideosRequest) (*SummarizeVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeVideos not implemented")
+}
+func (UnimplementedVideoServiceServer) SummarizeChannel(context.Context, *SummarizeChannelRequest) (*SummarizeChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeChannel not implemented")
+}
+func (UnimplementedVideoServiceServer) Subscribe(context.Context, *SubscribeRequest) (*Subscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedVideoServiceServer) Unsubscribe(context.Context, *UnsubscribeRequest) (*UnsubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedVideoServiceServer) ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedVideoServiceServer) ConfirmPushSubscription(context.Context, *ConfirmPushSubscriptionRequest) (*ConfirmPushSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPushSubscription not implemented")
+}
+func (UnimplementedVideoServiceServer) IngestChannelUploads(context.Context, *IngestChannelUploadsRequest) (*IngestChannelUploadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestChannelUploads not implemented")
+}
+func (UnimplementedVideoServiceServer) GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
+}
+func (UnimplementedVideoServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedVideoServiceServer) GetCollection(context.Context, *GetCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) UpdateCollection(context.Context, *UpdateCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) AddToCollection(context.Context, *AddToCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) RemoveFromCollection(context.Context, *RemoveFromCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) SetVideoTags(context.Context, *SetVideoTagsRequest) (*VideoTags, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVideoTags not implemented")
+}
+func (UnimplementedVideoServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedVideoServiceServer) ExportCollection(context.Context, *ExportCollectionRequest) (*ExportCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportCollection not implemented")
+}
+func (UnimplementedVideoServiceServer) CreateNote(context.Context, *CreateNoteRequest) (*Note, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNote not implemented")
+}
+func (UnimplementedVideoServiceServer) ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotes not implemented")
+}
+func (UnimplementedVideoServiceServer) DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNote not implemented")
+}
+func (UnimplementedVideoServiceServer) ExportVideo(context.Context, *ExportVideoRequest) (*ExportVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportVideo not implemented")
+}
+func (UnimplementedVideoServiceServer) GetFeedToken(context.Context, *GetFeedTokenRequest) (*FeedToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeedToken not implemented")
+}
+func (UnimplementedVideoServiceServer) GetSummaryFeed(context.Context, *GetSummaryFeedRequest) (*SummaryFeed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSummaryFeed not implemented")
+}
+func (UnimplementedVideoServiceServer) mustEmbedUnimplementedVideoServiceServer() {}
+
+// UnsafeVideoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VideoServiceServer will
+// result in compilation errors.
+type UnsafeVideoServiceServer interface {
+	mustEmbedUnimplementedVideoServiceServer()
+}
+
+func RegisterVideoServiceServer(s grpc.ServiceRegistrar, srv VideoServiceServer) {
+	s.RegisterService(&VideoService_ServiceDesc, srv)
+}
+
+func _VideoService_SearchChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SearchChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SearchChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SearchChannel(ctx, req.(*SearchChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetChannelVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetChannelVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetChannelVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetChannelVideos(ctx, req.(*GetChannelVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetVideoDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetVideoDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetVideoDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetVideoDetails(ctx, req.(*GetVideoDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetVideoTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoTranscriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetVideoTranscript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetVideoTranscript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetVideoTranscript(ctx, req.(*GetVideoTranscriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_SummarizeVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SummarizeVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SummarizeVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SummarizeVideo(ctx, req.(*SummarizeVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_SummarizeVideoStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SummarizeVideoRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VideoServiceServer).SummarizeVideoStream(m, &videoServiceSummarizeVideoStreamServer{stream})
+}
+
+type VideoService_SummarizeVideoStreamServer interface {
+	Send(*SummarizeVideoChunk) error
+	grpc.ServerStream
+}
+
+type videoServiceSummarizeVideoStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *videoServiceSummarizeVideoStreamServer) Send(m *SummarizeVideoChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VideoService_SemanticSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SemanticSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SemanticSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SemanticSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SemanticSearch(ctx, req.(*SemanticSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_AskVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).AskVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_AskVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).AskVideo(ctx, req.(*AskVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetConversation(ctx, req.(*GetConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GenerateChapters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateChaptersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GenerateChapters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GenerateChapters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GenerateChapters(ctx, req.(*GenerateChaptersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_SubmitSummaryJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SubmitSummaryJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SubmitSummaryJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SubmitSummaryJob(ctx, req.(*SummarizeVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ReplayWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_SummarizeVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SummarizeVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SummarizeVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SummarizeVideos(ctx, req.(*SummarizeVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_SummarizeChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SummarizeChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SummarizeChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SummarizeChannel(ctx, req.(*SummarizeChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_Subscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).Subscribe(ctx, req.(*SubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_Unsubscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ConfirmPushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPushSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ConfirmPushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ConfirmPushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ConfirmPushSubscription(ctx, req.(*ConfirmPushSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_IngestChannelUploads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestChannelUploadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).IngestChannelUploads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_IngestChannelUploads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).IngestChannelUploads(ctx, req.(*IngestChannelUploadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetFeed(ctx, req.(*GetFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedVideoServiceServer) SearchChannel(context.Context, *SearchChannelRequest) (*SearchChannelResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SearchChannel not implemented")
+func _VideoService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedVideoServiceServer) GetChannelVideos(context.Context, *GetChannelVideosRequest) (*GetChannelVideosResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChannelVideos not implemented")
+
+func _VideoService_GetCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetCollection(ctx, req.(*GetCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedVideoServiceServer) GetVideoDetails(context.Context, *GetVideoDetailsRequest) (*GetVideoDetailsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVideoDetails not implemented")
+
+func _VideoService_UpdateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).UpdateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_UpdateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).UpdateCollection(ctx, req.(*UpdateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedVideoServiceServer) GetVideoTranscript(context.Context, *GetVideoTranscriptRequest) (*GetVideoTranscriptResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVideoTranscript not implemented")
+
+func _VideoService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedVideoServiceServer) SummarizeVideo(context.Context, *SummarizeVideoRequest) (*SummarizeVideoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SummarizeVideo not implemented")
+
+func _VideoService_AddToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).AddToCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_AddToCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).AddToCollection(ctx, req.(*AddToCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedVideoServiceServer) mustEmbedUnimplementedVideoServiceServer() {}
 
-// UnsafeVideoServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to VideoServiceServer will
-// result in compilation errors.
-type UnsafeVideoServiceServer interface {
-	mustEmbedUnimplementedVideoServiceServer()
+func _VideoService_RemoveFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).RemoveFromCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_RemoveFromCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).RemoveFromCollection(ctx, req.(*RemoveFromCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterVideoServiceServer(s grpc.ServiceRegistrar, srv VideoServiceServer) {
-	s.RegisterService(&VideoService_ServiceDesc, srv)
+func _VideoService_SetVideoTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVideoTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).SetVideoTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_SetVideoTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).SetVideoTags(ctx, req.(*SetVideoTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _VideoService_SearchChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchChannelRequest)
+func _VideoService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VideoServiceServer).SearchChannel(ctx, in)
+		return srv.(VideoServiceServer).ListTags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: VideoService_SearchChannel_FullMethodName,
+		FullMethod: VideoService_ListTags_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VideoServiceServer).SearchChannel(ctx, req.(*SearchChannelRequest))
+		return srv.(VideoServiceServer).ListTags(ctx, req.(*ListTagsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VideoService_GetChannelVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChannelVideosRequest)
+func _VideoService_ExportCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCollectionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VideoServiceServer).GetChannelVideos(ctx, in)
+		return srv.(VideoServiceServer).ExportCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: VideoService_GetChannelVideos_FullMethodName,
+		FullMethod: VideoService_ExportCollection_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VideoServiceServer).GetChannelVideos(ctx, req.(*GetChannelVideosRequest))
+		return srv.(VideoServiceServer).ExportCollection(ctx, req.(*ExportCollectionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VideoService_GetVideoDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVideoDetailsRequest)
+func _VideoService_CreateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNoteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VideoServiceServer).GetVideoDetails(ctx, in)
+		return srv.(VideoServiceServer).CreateNote(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: VideoService_GetVideoDetails_FullMethodName,
+		FullMethod: VideoService_CreateNote_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VideoServiceServer).GetVideoDetails(ctx, req.(*GetVideoDetailsRequest))
+		return srv.(VideoServiceServer).CreateNote(ctx, req.(*CreateNoteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VideoService_GetVideoTranscript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVideoTranscriptRequest)
+func _VideoService_ListNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VideoServiceServer).GetVideoTranscript(ctx, in)
+		return srv.(VideoServiceServer).ListNotes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: VideoService_GetVideoTranscript_FullMethodName,
+		FullMethod: VideoService_ListNotes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VideoServiceServer).GetVideoTranscript(ctx, req.(*GetVideoTranscriptRequest))
+		return srv.(VideoServiceServer).ListNotes(ctx, req.(*ListNotesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VideoService_SummarizeVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SummarizeVideoRequest)
+func _VideoService_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNoteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VideoServiceServer).SummarizeVideo(ctx, in)
+		return srv.(VideoServiceServer).DeleteNote(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: VideoService_SummarizeVideo_FullMethodName,
+		FullMethod: VideoService_DeleteNote_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VideoServiceServer).SummarizeVideo(ctx, req.(*SummarizeVideoRequest))
+		return srv.(VideoServiceServer).DeleteNote(ctx, req.(*DeleteNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_ExportVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).ExportVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_ExportVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).ExportVideo(ctx, req.(*ExportVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetFeedToken(ctx, req.(*GetFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoService_GetSummaryFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSummaryFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoServiceServer).GetSummaryFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoService_GetSummaryFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoServiceServer).GetSummaryFeed(ctx, req.(*GetSummaryFeedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -251,7 +1531,161 @@ var VideoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SummarizeVideo",
 			Handler:    _VideoService_SummarizeVideo_Handler,
 		},
+		{
+			MethodName: "SemanticSearch",
+			Handler:    _VideoService_SemanticSearch_Handler,
+		},
+		{
+			MethodName: "AskVideo",
+			Handler:    _VideoService_AskVideo_Handler,
+		},
+		{
+			MethodName: "GetConversation",
+			Handler:    _VideoService_GetConversation_Handler,
+		},
+		{
+			MethodName: "GenerateChapters",
+			Handler:    _VideoService_GenerateChapters_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _VideoService_GetUsage_Handler,
+		},
+		{
+			MethodName: "SubmitSummaryJob",
+			Handler:    _VideoService_SubmitSummaryJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _VideoService_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _VideoService_ListJobs_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _VideoService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _VideoService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _VideoService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _VideoService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _VideoService_ReplayWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "SummarizeVideos",
+			Handler:    _VideoService_SummarizeVideos_Handler,
+		},
+		{
+			MethodName: "SummarizeChannel",
+			Handler:    _VideoService_SummarizeChannel_Handler,
+		},
+		{
+			MethodName: "Subscribe",
+			Handler:    _VideoService_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _VideoService_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _VideoService_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "ConfirmPushSubscription",
+			Handler:    _VideoService_ConfirmPushSubscription_Handler,
+		},
+		{
+			MethodName: "IngestChannelUploads",
+			Handler:    _VideoService_IngestChannelUploads_Handler,
+		},
+		{
+			MethodName: "GetFeed",
+			Handler:    _VideoService_GetFeed_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _VideoService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _VideoService_ListCollections_Handler,
+		},
+		{
+			MethodName: "GetCollection",
+			Handler:    _VideoService_GetCollection_Handler,
+		},
+		{
+			MethodName: "UpdateCollection",
+			Handler:    _VideoService_UpdateCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _VideoService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "AddToCollection",
+			Handler:    _VideoService_AddToCollection_Handler,
+		},
+		{
+			MethodName: "RemoveFromCollection",
+			Handler:    _VideoService_RemoveFromCollection_Handler,
+		},
+		{
+			MethodName: "SetVideoTags",
+			Handler:    _VideoService_SetVideoTags_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _VideoService_ListTags_Handler,
+		},
+		{
+			MethodName: "ExportCollection",
+			Handler:    _VideoService_ExportCollection_Handler,
+		},
+		{
+			MethodName: "CreateNote",
+			Handler:    _VideoService_CreateNote_Handler,
+		},
+		{
+			MethodName: "ListNotes",
+			Handler:    _VideoService_ListNotes_Handler,
+		},
+		{
+			MethodName: "DeleteNote",
+			Handler:    _VideoService_DeleteNote_Handler,
+		},
+		{
+			MethodName: "ExportVideo",
+			Handler:    _VideoService_ExportVideo_Handler,
+		},
+		{
+			MethodName: "GetFeedToken",
+			Handler:    _VideoService_GetFeedToken_Handler,
+		},
+		{
+			MethodName: "GetSummaryFeed",
+			Handler:    _VideoService_GetSummaryFeed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SummarizeVideoStream",
+			Handler:       _VideoService_SummarizeVideoStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/video.proto",
 }
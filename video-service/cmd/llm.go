@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+	"golang.org/x/time/rate"
+
+	"videoservice/internal/client"
+	"videoservice/internal/service"
+)
+
+// newLLMRouter builds the LLMClient from LLM_ROUTES, a comma-separated list
+// of providers in priority order, each written as
+//
+//	provider[:model][?timeout=30s&rpm=60&max_input_tokens=16000]
+//
+// e.g. "openai:gpt-4o-mini?max_input_tokens=16000,gemini:gemini-2.5-pro".
+// Without LLM_ROUTES the single LLM_PROVIDER is used. The returned Embedder
+// is the first route's provider that has one, or nil.
+func newLLMRouter(ctx context.Context) (service.LLMClient, service.Embedder, func(), error) {
+	specs := os.Getenv("LLM_ROUTES")
+	if specs == "" {
+		specs = envOr("LLM_PROVIDER", "gemini")
+	}
+
+	var (
+		routes   []service.RouteProvider
+		embedder service.Embedder
+		closers  []func()
+	)
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	for _, spec := range strings.Split(specs, ",") {
+		route, err := parseRoute(strings.TrimSpace(spec))
+		if err != nil {
+			closeAll()
+			return nil, nil, nil, err
+		}
+
+		llm, emb, closer, err := newLLMClient(ctx, route.Name, route.Model)
+		if err != nil {
+			closeAll()
+			return nil, nil, nil, fmt.Errorf("LLM route %q: %w", spec, err)
+		}
+		closers = append(closers, closer)
+		if embedder == nil && emb != nil {
+			embedder = emb
+		}
+
+		route.Client = llm
+		routes = append(routes, route)
+		log.Printf("LLM route %d: %s (%s)", len(routes), route.Name, route.Model)
+	}
+
+	return service.NewRouter(routes...), embedder, closeAll, nil
+}
+
+// parseRoute parses one LLM_ROUTES entry, filling in the provider's default
+// model when none is given.
+func parseRoute(spec string) (service.RouteProvider, error) {
+	name, rawQuery, _ := strings.Cut(spec, "?")
+	provider, model, _ := strings.Cut(name, ":")
+	if model == "" {
+		model = defaultModel(provider)
+	}
+	route := service.RouteProvider{Name: provider, Model: model}
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return route, fmt.Errorf("invalid LLM route %q: %w", spec, err)
+	}
+	if v := params.Get("timeout"); v != "" {
+		if route.Timeout, err = time.ParseDuration(v); err != nil {
+			return route, fmt.Errorf("invalid timeout in LLM route %q: %w", spec, err)
+		}
+	}
+	if v := params.Get("rpm"); v != "" {
+		rpm, err := strconv.Atoi(v)
+		if err != nil || rpm <= 0 {
+			return route, fmt.Errorf("invalid rpm in LLM route %q", spec)
+		}
+		route.Limiter = rate.NewLimiter(rate.Limit(float64(rpm)/60), rpm)
+	}
+	if v := params.Get("max_input_tokens"); v != "" {
+		if route.MaxInputTokens, err = strconv.Atoi(v); err != nil {
+			return route, fmt.Errorf("invalid max_input_tokens in LLM route %q: %w", spec, err)
+		}
+	}
+	return route, nil
+}
+
+func defaultModel(provider string) string {
+	switch provider {
+	case "gemini":
+		return envOr("GEMINI_MODEL", client.DefaultGeminiModel)
+	case "openai":
+		return envOr("OPENAI_MODEL", "gpt-4o-mini")
+	case "anthropic":
+		return envOr("ANTHROPIC_MODEL", "claude-3-5-haiku-latest")
+	}
+	return provider
+}
+
+// newLLMClient creates the LLMClient for provider and model, configured from
+// that provider's environment variables. The returned Embedder is nil when
+// the provider has no embedding model configured.
+func newLLMClient(ctx context.Context, provider, model string) (service.LLMClient, service.Embedder, func(), error) {
+	noop := func() {}
+
+	switch provider {
+	case "gemini":
+		apiKey := os.Getenv("GEMINI_API_KEY")
+		if apiKey == "" {
+			return nil, nil, noop, fmt.Errorf("GEMINI_API_KEY environment variable is required for the gemini provider")
+		}
+		cfg, err := geminiConfig(model)
+		if err != nil {
+			return nil, nil, noop, err
+		}
+		c, err := client.NewGeminiClient(ctx, apiKey, cfg)
+		if err != nil {
+			return nil, nil, noop, err
+		}
+		return c, c, func() { c.Close() }, nil
+
+	case "openai":
+		c, err := client.NewOpenAIClient(
+			envOr("OPENAI_BASE_URL", "https://api.openai.com/v1"),
+			os.Getenv("OPENAI_API_KEY"),
+			model,
+			os.Getenv("OPENAI_EMBEDDING_MODEL"),
+		)
+		if err != nil {
+			return nil, nil, noop, err
+		}
+		if os.Getenv("OPENAI_EMBEDDING_MODEL") == "" {
+			return c, nil, noop, nil
+		}
+		return c, c, noop, nil
+
+	case "anthropic":
+		c, err := client.NewAnthropicClient(
+			envOr("ANTHROPIC_BASE_URL", "https://api.anthropic.com"),
+			os.Getenv("ANTHROPIC_API_KEY"),
+			model,
+		)
+		if err != nil {
+			return nil, nil, noop, err
+		}
+		return c, nil, noop, nil
+
+	case "stub":
+		log.Println("⚠️  Using the offline stub LLM, summaries are not generated by a model")
+		c := client.NewStubClient()
+		return c, c, noop, nil
+	}
+
+	return nil, nil, noop, fmt.Errorf("unknown LLM provider %q, must be gemini, openai, anthropic or stub", provider)
+}
+
+var geminiSafetyThresholds = map[string]genai.HarmBlockThreshold{
+	"none":             genai.HarmBlockNone,
+	"only_high":        genai.HarmBlockOnlyHigh,
+	"medium_and_above": genai.HarmBlockMediumAndAbove,
+	"low_and_above":    genai.HarmBlockLowAndAbove,
+}
+
+// geminiConfig reads the Gemini generation settings from the environment.
+// Unset variables keep the API's defaults.
+func geminiConfig(model string) (client.GeminiConfig, error) {
+	cfg := client.GeminiConfig{
+		Model:             model,
+		SystemInstruction: os.Getenv("GEMINI_SYSTEM_INSTRUCTION"),
+	}
+
+	var err error
+	if cfg.Temperature, err = envFloat32("GEMINI_TEMPERATURE"); err != nil {
+		return cfg, err
+	}
+	if cfg.TopP, err = envFloat32("GEMINI_TOP_P"); err != nil {
+		return cfg, err
+	}
+	if v := os.Getenv("GEMINI_MAX_OUTPUT_TOKENS"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n <= 0 {
+			return cfg, fmt.Errorf("invalid GEMINI_MAX_OUTPUT_TOKENS %q", v)
+		}
+		tokens := int32(n)
+		cfg.MaxOutputTokens = &tokens
+	}
+	if v := os.Getenv("GEMINI_SAFETY_THRESHOLD"); v != "" {
+		threshold, ok := geminiSafetyThresholds[strings.ToLower(v)]
+		if !ok {
+			return cfg, fmt.Errorf("invalid GEMINI_SAFETY_THRESHOLD %q, must be none, only_high, medium_and_above or low_and_above", v)
+		}
+		cfg.SafetyThreshold = threshold
+	}
+	return cfg, nil
+}
+
+// envFloat32 reads a float environment variable, returning nil when it is
+// unset.
+func envFloat32(key string) (*float32, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	value := float32(f)
+	return &value, nil
+}
+
+// priceTable adds the prices in spec, a comma-separated list of
+// model=input/output entries in US dollars per million tokens, e.g.
+// "gemini-2.5-flash=0.30/2.50", to the default prices.
+func priceTable(spec string) (service.PriceTable, error) {
+	prices := make(service.PriceTable, len(service.DefaultPrices))
+	for model, price := range service.DefaultPrices {
+		prices[model] = price
+	}
+	if spec == "" {
+		return prices, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		model, rates, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		input, output, ok2 := strings.Cut(rates, "/")
+		if !ok || !ok2 || model == "" {
+			return nil, fmt.Errorf("price %q must look like model=input/output", entry)
+		}
+		in, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input price in %q: %w", entry, err)
+		}
+		out, err := strconv.ParseFloat(output, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output price in %q: %w", entry, err)
+		}
+		prices[model] = service.ModelPrice{InputPerMillion: in, OutputPerMillion: out}
+	}
+	return prices, nil
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -53,11 +54,6 @@ func main() {
 		log.Fatal("YOUTUBE_API_KEY environment variable is required")
 	}
 
-	geminiAPIKey := os.Getenv("GEMINI_API_KEY")
-	if geminiAPIKey == "" {
-		log.Fatal("GEMINI_API_KEY environment variable is required")
-	}
-
 	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
 	if err != nil {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
@@ -74,13 +70,83 @@ func main() {
 	videoRepo := repository.NewVideoRepository(db)
 	youtubeClient := client.NewYouTubeClient(youtubeAPIKey)
 
-	geminiClient, err := client.NewGeminiClient(context.Background(), geminiAPIKey)
+	llmClient, embedder, closeLLM, err := newLLMRouter(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to create LLM client: %v", err)
+	}
+	defer closeLLM()
+
+	vectorRepo := repository.NewVectorRepository(db)
+	conversationRepo := repository.NewConversationRepository(db)
+	chapterRepo := repository.NewChapterRepository(db)
+	summaryRepo := repository.NewSummaryRepository(db)
+	usageRepo := repository.NewUsageRepository(db)
+	quotaRepo := repository.NewQuotaRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	pushLeaseRepo := repository.NewPushLeaseRepository(db)
+	historyRepo := repository.NewHistoryRepository(db)
+	collectionRepo := repository.NewCollectionRepository(db)
+	noteRepo := repository.NewNoteRepository(db)
+	feedTokenRepo := repository.NewFeedTokenRepository(db)
+
+	prices, err := priceTable(os.Getenv("LLM_PRICES"))
 	if err != nil {
-		log.Fatalf("Failed to create Gemini client: %v", err)
+		log.Fatalf("Invalid LLM_PRICES: %v", err)
+	}
+	quotas, err := planQuotas()
+	if err != nil {
+		log.Fatalf("Invalid plan quotas: %v", err)
 	}
-	defer geminiClient.Close()
 
-	videoService := service.NewVideoService(videoRepo, youtubeClient, geminiClient)
+	opts := []service.Option{
+		service.WithConversations(conversationRepo),
+		service.WithChapters(chapterRepo),
+		service.WithSummaryCache(summaryRepo),
+		service.WithUsageTracking(usageRepo, prices),
+		service.WithQuotas(quotaRepo, quotas),
+		service.WithMapReduce(service.MapReduceConfig{
+			ThresholdTokens: envInt("SUMMARY_MAP_REDUCE_THRESHOLD_TOKENS"),
+			ChunkTokens:     envInt("SUMMARY_CHUNK_TOKENS"),
+			Concurrency:     envInt("SUMMARY_MAP_CONCURRENCY"),
+		}),
+		service.WithJobs(jobRepo, service.JobConfig{
+			Workers:     envInt("JOB_WORKERS"),
+			MaxAttempts: envInt("JOB_MAX_ATTEMPTS"),
+			QueueSize:   envInt("JOB_QUEUE_SIZE"),
+		}),
+		service.WithWebhooks(webhookRepo, service.WebhookConfig{
+			MaxAttempts:          envInt("WEBHOOK_MAX_ATTEMPTS"),
+			Concurrency:          envInt("WEBHOOK_CONCURRENCY"),
+			AllowPrivateNetworks: os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true",
+		}),
+		service.WithSubscriptions(subscriptionRepo, service.SyncConfig{
+			Interval:    time.Duration(envInt("SUBSCRIPTION_SYNC_MINUTES")) * time.Minute,
+			Concurrency: envInt("SUBSCRIPTION_SYNC_CONCURRENCY"),
+		}),
+		service.WithHistory(historyRepo),
+		service.WithCollections(collectionRepo),
+		service.WithNotes(noteRepo),
+		service.WithSummaryFeeds(feedTokenRepo),
+	}
+	if callbackURL := os.Getenv("WEBSUB_CALLBACK_URL"); callbackURL != "" {
+		if os.Getenv("WEBSUB_SECRET") == "" {
+			log.Fatal("WEBSUB_SECRET must be set when WEBSUB_CALLBACK_URL is, or pushed uploads cannot be checked")
+		}
+		opts = append(opts, service.WithPushNotifications(pushLeaseRepo, service.PushConfig{
+			HubURL:      os.Getenv("WEBSUB_HUB_URL"),
+			CallbackURL: callbackURL,
+			Secret:      os.Getenv("WEBSUB_SECRET"),
+		}))
+	}
+	if embedder != nil {
+		opts = append(opts, service.WithSemanticSearch(embedder, vectorRepo))
+	} else {
+		log.Println("⚠️  No embedding model configured, semantic search and questions are disabled")
+	}
+
+	videoService := service.NewVideoService(videoRepo, youtubeClient, llmClient, opts...)
 
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
@@ -92,6 +158,28 @@ func main() {
 	)
 	pb.RegisterVideoServiceServer(grpcServer, videoService)
 
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		videoService.RunJobs(jobCtx)
+	}()
+	webhooksDone := make(chan struct{})
+	go func() {
+		defer close(webhooksDone)
+		videoService.RunWebhooks(jobCtx)
+	}()
+	syncDone := make(chan struct{})
+	go func() {
+		defer close(syncDone)
+		videoService.RunSubscriptionSync(jobCtx)
+	}()
+	pushDone := make(chan struct{})
+	go func() {
+		defer close(pushDone)
+		videoService.RunPushLeases(jobCtx)
+	}()
+
 	go func() {
 		log.Printf("🚀 Video service starting on port %s", port)
 		if err := grpcServer.Serve(lis); err != nil {
@@ -105,5 +193,35 @@ func main() {
 
 	log.Println("⏳ Shutting down server...")
 	grpcServer.GracefulStop()
+	// Interrupted jobs and webhook deliveries stay pending and are resumed
+	// on the next start.
+	stopJobs()
+	<-jobsDone
+	<-webhooksDone
+	<-syncDone
+	<-pushDone
 	log.Println("✅ Server stopped")
 }
+
+// envOr reads an environment variable, returning def when it is unset.
+func envOr(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// envInt reads an integer environment variable, returning 0 (use the default)
+// when it is unset or invalid.
+func envInt(key string) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", key, value, err)
+		return 0
+	}
+	return n
+}
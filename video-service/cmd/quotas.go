@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"videoservice/internal/service"
+)
+
+// planQuotas returns the default plan quotas, with the quotas of each plan
+// overridden by PLAN_QUOTAS_<PLAN>, a comma-separated list of
+// kind=daily/monthly entries such as "summaries=10/100,transcripts=50/500".
+// A limit of 0 is unlimited.
+func planQuotas() (map[string]service.PlanQuotas, error) {
+	plans := make(map[string]service.PlanQuotas, len(service.DefaultPlanQuotas))
+	for plan, quotas := range service.DefaultPlanQuotas {
+		copied := make(service.PlanQuotas, len(quotas))
+		for kind, limits := range quotas {
+			copied[kind] = limits
+		}
+		plans[plan] = copied
+	}
+
+	for plan, quotas := range plans {
+		key := "PLAN_QUOTAS_" + strings.ToUpper(plan)
+		spec := os.Getenv(key)
+		if spec == "" {
+			continue
+		}
+		for _, entry := range strings.Split(spec, ",") {
+			kind, limits, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			daily, monthly, ok2 := strings.Cut(limits, "/")
+			if !ok || !ok2 || (kind != service.QuotaSummaries && kind != service.QuotaTranscripts) {
+				return nil, fmt.Errorf("invalid %s entry %q: must look like summaries=daily/monthly or transcripts=daily/monthly", key, entry)
+			}
+			d, err := strconv.ParseInt(daily, 10, 64)
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("invalid daily limit in %s entry %q", key, entry)
+			}
+			m, err := strconv.ParseInt(monthly, 10, 64)
+			if err != nil || m < 0 {
+				return nil, fmt.Errorf("invalid monthly limit in %s entry %q", key, entry)
+			}
+			quotas[kind] = service.QuotaLimits{Daily: d, Monthly: m}
+		}
+	}
+	return plans, nil
+}
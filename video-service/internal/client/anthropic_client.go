@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/prompts"
+)
+
+const (
+	anthropicVersion = "2023-06-01"
+	// The Messages API requires an explicit output limit.
+	anthropicMaxTokens = 8192
+	// anthropicJSONTool is the tool Claude is forced to call so that its
+	// input, validated against the response schema, carries the JSON answer.
+	anthropicJSONTool = "respond"
+	// anthropicRefusal is the stop reason of a response Claude declined to
+	// give for safety reasons.
+	anthropicRefusal = "refusal"
+)
+
+// AnthropicClient implements service.LLMClient with the Anthropic Messages
+// API. Anthropic has no embeddings endpoint, so semantic search needs a
+// separate Embedder.
+type AnthropicClient struct {
+	promptClient
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+}
+
+// NewAnthropicClient creates a client for the Messages API at baseURL, e.g.
+// https://api.anthropic.com.
+func NewAnthropicClient(baseURL, apiKey, model string) (*AnthropicClient, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("Anthropic API key is required")
+	}
+	if model == "" {
+		return nil, fmt.Errorf("Anthropic model name is required")
+	}
+
+	c := &AnthropicClient{
+		httpClient: &http.Client{Timeout: httpTimeout},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+	}
+	c.promptClient = promptClient{completer: c}
+	return c, nil
+}
+
+type anthropicRequest struct {
+	Model      string                   `json:"model"`
+	MaxTokens  int                      `json:"max_tokens"`
+	System     string                   `json:"system,omitempty"`
+	Messages   []openAIMessage          `json:"messages"`
+	Stream     bool                     `json:"stream,omitempty"`
+	Tools      []map[string]interface{} `json:"tools,omitempty"`
+	ToolChoice map[string]interface{}   `json:"tool_choice,omitempty"`
+}
+
+type anthropicContent struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type anthropicResponse struct {
+	Content    []anthropicContent `json:"content"`
+	StopReason string             `json:"stop_reason"`
+	Usage      anthropicUsage     `json:"usage"`
+}
+
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+	// Message is set on message_start and Usage on message_delta events.
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Usage anthropicUsage `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *AnthropicClient) complete(ctx context.Context, prompt prompts.Prompt) (string, error) {
+	resp, err := c.messages(ctx, c.request(prompt))
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			result += block.Text
+		}
+	}
+	if result == "" {
+		return "", fmt.Errorf("no content generated by Anthropic")
+	}
+	return helpers.SanitizeMarkdown(result), nil
+}
+
+func (c *AnthropicClient) completeJSON(ctx context.Context, prompt prompts.Prompt, schema *genai.Schema) (string, error) {
+	req := c.request(prompt)
+	req.Tools = []map[string]interface{}{{
+		"name":         anthropicJSONTool,
+		"description":  "Return the requested JSON document.",
+		"input_schema": toJSONSchema(schema),
+	}}
+	req.ToolChoice = map[string]interface{}{"type": "tool", "name": anthropicJSONTool}
+
+	resp, err := c.messages(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	for _, block := range resp.Content {
+		if block.Type == "tool_use" && block.Name == anthropicJSONTool {
+			return string(block.Input), nil
+		}
+	}
+	return "", fmt.Errorf("no JSON content generated by Anthropic")
+}
+
+func (c *AnthropicClient) completeStream(ctx context.Context, prompt prompts.Prompt, onChunk func(string) error) (string, error) {
+	req := c.request(prompt)
+	req.Stream = true
+
+	resp, err := postJSON(ctx, c.httpClient, "Anthropic", c.baseURL+"/v1/messages", c.headers(), req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var usage Usage
+	defer func() { ReportUsage(ctx, usage) }()
+
+	var result strings.Builder
+	err = readSSE(resp.Body, func(data string) error {
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("failed to decode Anthropic stream event: %w", err)
+		}
+		switch event.Type {
+		case "error":
+			return fmt.Errorf("Anthropic stream error: %s: %s", event.Error.Type, event.Error.Message)
+		case "message_start":
+			usage.PromptTokens = event.Message.Usage.InputTokens
+		case "message_delta":
+			usage.CompletionTokens = event.Usage.OutputTokens
+			if event.Delta.StopReason == anthropicRefusal {
+				return ErrSafetyBlocked
+			}
+		case "content_block_delta":
+			if event.Delta.Type != "text_delta" || event.Delta.Text == "" {
+				return nil
+			}
+			result.WriteString(event.Delta.Text)
+			return onChunk(event.Delta.Text)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if result.Len() == 0 {
+		return "", fmt.Errorf("empty result from Anthropic")
+	}
+	return helpers.SanitizeMarkdown(result.String()), nil
+}
+
+func (c *AnthropicClient) messages(ctx context.Context, req anthropicRequest) (*anthropicResponse, error) {
+	resp, err := postJSON(ctx, c.httpClient, "Anthropic", c.baseURL+"/v1/messages", c.headers(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var msgResp anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&msgResp); err != nil {
+		return nil, fmt.Errorf("failed to decode Anthropic response: %w", err)
+	}
+	ReportUsage(ctx, Usage{PromptTokens: msgResp.Usage.InputTokens, CompletionTokens: msgResp.Usage.OutputTokens})
+	if msgResp.StopReason == anthropicRefusal {
+		return nil, ErrSafetyBlocked
+	}
+	return &msgResp, nil
+}
+
+func (c *AnthropicClient) request(prompt prompts.Prompt) anthropicRequest {
+	return anthropicRequest{
+		Model:     c.model,
+		MaxTokens: anthropicMaxTokens,
+		System:    prompt.System,
+		Messages:  []openAIMessage{{Role: "user", Content: prompt.User}},
+	}
+}
+
+func (c *AnthropicClient) headers() map[string]string {
+	return map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"videoservice/internal/models"
+)
+
+func TestAnthropicClient(t *testing.T) {
+	var lastRequest map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "test-key" || r.Header.Get("anthropic-version") == "" {
+			t.Errorf("Expected API key and version headers, got %v", r.Header)
+		}
+
+		lastRequest = nil
+		json.NewDecoder(r.Body).Decode(&lastRequest)
+		switch {
+		case lastRequest["stream"] == true:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: message_start\ndata: {\"type\": \"message_start\", \"message\": {\"usage\": {\"input_tokens\": 20}}}\n\n")
+			for _, delta := range []string{"Hello", " world"} {
+				fmt.Fprintf(w, "event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"delta\": {\"type\": \"text_delta\", \"text\": %q}}\n\n", delta)
+			}
+			fmt.Fprint(w, "event: message_delta\ndata: {\"type\": \"message_delta\", \"delta\": {\"stop_reason\": \"end_turn\"}, \"usage\": {\"output_tokens\": 2}}\n\n")
+			fmt.Fprint(w, "event: message_stop\ndata: {\"type\": \"message_stop\"}\n\n")
+		case lastRequest["tools"] != nil:
+			w.Write([]byte(`{"content": [{"type": "tool_use", "name": "respond", "input": {"chapters": [{"title": "Intro", "start": "0:00"}]}}]}`))
+		default:
+			w.Write([]byte(`{"content": [{"type": "text", "text": "A summary."}], "usage": {"input_tokens": 12, "output_tokens": 3}}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewAnthropicClient(ts.URL, "test-key", "test-model")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Summarize", func(t *testing.T) {
+		var usage Usage
+		ctx := WithUsageHook(context.Background(), func(u Usage) { usage = u })
+		summary, err := c.Summarize(ctx, "some transcript", models.SummaryOptions{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary != "A summary." {
+			t.Errorf("Expected summary, got %q", summary)
+		}
+		if usage != (Usage{PromptTokens: 12, CompletionTokens: 3}) {
+			t.Errorf("Expected the reported token usage, got %+v", usage)
+		}
+		if lastRequest["max_tokens"] == nil {
+			t.Error("Expected max_tokens to be set")
+		}
+		if system, _ := lastRequest["system"].(string); system == "" || strings.Contains(system, "some transcript") {
+			t.Errorf("Expected the instructions in the system field, got %q", system)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		var usage Usage
+		ctx := WithUsageHook(context.Background(), func(u Usage) { usage = u })
+		var chunks []string
+		summary, err := c.SummarizeStream(ctx, "some transcript", models.SummaryOptions{}, func(chunk string) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary != "Hello world" || len(chunks) != 2 {
+			t.Errorf("Expected 2 chunks making up the summary, got %q from %v", summary, chunks)
+		}
+		if usage != (Usage{PromptTokens: 20, CompletionTokens: 2}) {
+			t.Errorf("Expected usage from the message_start and message_delta events, got %+v", usage)
+		}
+	})
+
+	t.Run("Chapters", func(t *testing.T) {
+		chapters, err := c.GenerateChapters(context.Background(), "[0:00] hello")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(chapters) != 1 || chapters[0].Title != "Intro" {
+			t.Errorf("Expected one chapter from the forced tool call, got %+v", chapters)
+		}
+		choice := lastRequest["tool_choice"].(map[string]interface{})
+		if choice["name"] != anthropicJSONTool {
+			t.Errorf("Expected the JSON tool to be forced, got %v", choice)
+		}
+	})
+}
+
+func TestAnthropicClient_Refusal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"content": [], "stop_reason": "refusal"}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewAnthropicClient(ts.URL, "test-key", "test-model")
+	if _, err := c.Summarize(context.Background(), "some transcript", models.SummaryOptions{}); !errors.Is(err, ErrSafetyBlocked) {
+		t.Errorf("Expected ErrSafetyBlocked, got %v", err)
+	}
+}
+
+func TestAnthropicClient_New(t *testing.T) {
+	if _, err := NewAnthropicClient("https://api.anthropic.com", "", "test-model"); err == nil {
+		t.Fatal("Expected error with empty API key, got nil")
+	}
+	c, err := NewAnthropicClient("https://api.anthropic.com", "test-key", "test-model")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if c.httpClient.Timeout != httpTimeout {
+		t.Errorf("Expected a %v timeout, got %v", httpTimeout, c.httpClient.Timeout)
+	}
+}
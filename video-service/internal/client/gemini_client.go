@@ -2,57 +2,158 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 
 	"videoservice/internal/client/helpers"
+	"videoservice/internal/prompts"
 )
 
+// GeminiClient implements service.LLMClient and service.Embedder with the
+// Gemini API.
 type GeminiClient struct {
-	client *genai.Client
-	model  *genai.GenerativeModel
+	promptClient
+	client   *genai.Client
+	config   GeminiConfig
+	model    *genai.GenerativeModel
+	embedder *genai.EmbeddingModel
 }
 
-func NewGeminiClient(ctx context.Context, apiKey string) (*GeminiClient, error) {
+// GeminiConfig tunes how GeminiClient generates. Zero values leave the API's
+// defaults in place.
+type GeminiConfig struct {
+	// Model defaults to DefaultGeminiModel.
+	Model           string
+	Temperature     *float32
+	TopP            *float32
+	MaxOutputTokens *int32
+	// SystemInstruction is sent ahead of every prompt's own instructions,
+	// e.g. to set a house tone.
+	SystemInstruction string
+	// SafetyThreshold, when set, is the block threshold for every harm
+	// category.
+	SafetyThreshold genai.HarmBlockThreshold
+}
+
+var geminiHarmCategories = []genai.HarmCategory{
+	genai.HarmCategoryHarassment,
+	genai.HarmCategoryHateSpeech,
+	genai.HarmCategorySexuallyExplicit,
+	genai.HarmCategoryDangerousContent,
+}
+
+// Gemini accepts at most this many contents per BatchEmbedContents call.
+const maxEmbedBatch = 100
+
+// DefaultGeminiModel is the model NewGeminiClient generates with when the
+// config names none.
+const DefaultGeminiModel = "gemini-2.5-flash"
+
+func NewGeminiClient(ctx context.Context, apiKey string, cfg GeminiConfig) (*GeminiClient, error) {
 	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create gemini client: %w", err)
 	}
 
-	// Use gemini-2.5-flash for fast and efficient summarization
-	model := client.GenerativeModel("gemini-2.5-flash")
+	if cfg.Model == "" {
+		cfg.Model = DefaultGeminiModel
+	}
 
-	return &GeminiClient{
-		client: client,
-		model:  model,
-	}, nil
+	c := &GeminiClient{
+		client:   client,
+		config:   cfg,
+		embedder: client.EmbeddingModel("text-embedding-004"),
+	}
+	c.model = c.newModel()
+	c.promptClient = promptClient{completer: c}
+	return c, nil
+}
+
+func (c *GeminiClient) newModel() *genai.GenerativeModel {
+	model := c.client.GenerativeModel(c.config.Model)
+	model.Temperature = c.config.Temperature
+	model.TopP = c.config.TopP
+	model.MaxOutputTokens = c.config.MaxOutputTokens
+	if c.config.SafetyThreshold != genai.HarmBlockUnspecified {
+		for _, category := range geminiHarmCategories {
+			model.SafetySettings = append(model.SafetySettings, &genai.SafetySetting{
+				Category:  category,
+				Threshold: c.config.SafetyThreshold,
+			})
+		}
+	}
+	return model
 }
 
-func (c *GeminiClient) Summarize(ctx context.Context, text string) (string, error) {
-	if text == "" {
-		return "", fmt.Errorf("empty transcript provided for summarization")
+// modelFor returns a copy of the model carrying prompt's system instructions.
+func (c *GeminiClient) modelFor(prompt prompts.Prompt) *genai.GenerativeModel {
+	model := *c.model
+	system := strings.TrimSpace(c.config.SystemInstruction + "\n\n" + prompt.System)
+	if system != "" {
+		model.SystemInstruction = genai.NewUserContent(genai.Text(system))
 	}
+	return &model
+}
 
-	prompt := fmt.Sprintf(`Summarize the following transcript. 
+// Embed returns one embedding per text, batching requests to stay within the
+// API's per-call limit.
+func (c *GeminiClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += maxEmbedBatch {
+		end := start + maxEmbedBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
 
-	STRICT FORMATTING RULES:
-	- Use standard Markdown only.
-	- For bullet points, use a HYPHEN (-) followed by a SINGLE STANDARD SPACE.
-	- DO NOT use non-breaking spaces or special indentation.
-	- Double-space between paragraphs.
+		batch := c.embedder.NewBatch()
+		for _, text := range texts[start:end] {
+			batch.AddContent(genai.Text(text))
+		}
 
-	Transcript:
-	%s`, text)
+		resp, err := c.embedder.BatchEmbedContents(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed content with Gemini: %w", err)
+		}
+		if len(resp.Embeddings) != end-start {
+			return nil, fmt.Errorf("gemini returned %d embeddings for %d texts", len(resp.Embeddings), end-start)
+		}
+		for _, e := range resp.Embeddings {
+			vectors = append(vectors, e.Values)
+		}
+	}
+	return vectors, nil
+}
 
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+func (c *GeminiClient) complete(ctx context.Context, prompt prompts.Prompt) (string, error) {
+	result, err := generateText(ctx, c.modelFor(prompt), prompt.User)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate summary from Gemini: %w", err)
+		return "", err
 	}
+	return helpers.SanitizeMarkdown(result), nil
+}
+
+func (c *GeminiClient) completeJSON(ctx context.Context, prompt prompts.Prompt, schema *genai.Schema) (string, error) {
+	model := c.modelFor(prompt)
+	model.ResponseMIMEType = "application/json"
+	model.ResponseSchema = schema
+	return generateText(ctx, model, prompt.User)
+}
+
+func generateText(ctx context.Context, model *genai.GenerativeModel, prompt string) (string, error) {
+	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate content from Gemini: %w", blockedError(err))
+	}
+
+	ReportUsage(ctx, geminiUsage(resp.UsageMetadata))
 
 	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
-		return "", fmt.Errorf("no summary content generated by Gemini")
+		return "", fmt.Errorf("no content generated by Gemini")
 	}
 
 	var result string
@@ -66,7 +167,71 @@ func (c *GeminiClient) Summarize(ctx context.Context, text string) (string, erro
 		return "", fmt.Errorf("empty result parts from Gemini")
 	}
 
-	return helpers.SanitizeMarkdown(result), nil
+	return result, nil
+}
+
+func (c *GeminiClient) completeStream(ctx context.Context, prompt prompts.Prompt, onChunk func(string) error) (string, error) {
+	iter := c.modelFor(prompt).GenerateContentStream(ctx, genai.Text(prompt.User))
+
+	// Every streamed response carries the usage so far, so only the last one
+	// is reported.
+	var usage *genai.UsageMetadata
+	defer func() { ReportUsage(ctx, geminiUsage(usage)) }()
+
+	var result strings.Builder
+	for {
+		resp, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to stream content from Gemini: %w", blockedError(err))
+		}
+		if resp.UsageMetadata != nil {
+			usage = resp.UsageMetadata
+		}
+
+		if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+			continue
+		}
+		for _, part := range resp.Candidates[0].Content.Parts {
+			textPart, ok := part.(genai.Text)
+			if !ok || textPart == "" {
+				continue
+			}
+			result.WriteString(string(textPart))
+			if err := onChunk(string(textPart)); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if result.Len() == 0 {
+		return "", fmt.Errorf("empty result parts from Gemini")
+	}
+
+	return helpers.SanitizeMarkdown(result.String()), nil
+}
+
+func geminiUsage(m *genai.UsageMetadata) Usage {
+	if m == nil {
+		return Usage{}
+	}
+	return Usage{PromptTokens: int(m.PromptTokenCount), CompletionTokens: int(m.CandidatesTokenCount)}
+}
+
+// blockedError marks a *genai.BlockedError with ErrSafetyBlocked or
+// ErrRecitationBlocked, depending on why Gemini blocked the content. Other
+// errors are returned unchanged.
+func blockedError(err error) error {
+	var blocked *genai.BlockedError
+	if !errors.As(err, &blocked) {
+		return err
+	}
+	if blocked.Candidate != nil && blocked.Candidate.FinishReason == genai.FinishReasonRecitation {
+		return fmt.Errorf("%w: %v", ErrRecitationBlocked, err)
+	}
+	return fmt.Errorf("%w: %v", ErrSafetyBlocked, err)
 }
 
 func (c *GeminiClient) Close() error {
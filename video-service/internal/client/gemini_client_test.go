@@ -2,12 +2,18 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"videoservice/internal/models"
 )
 
 func TestGeminiClient_New(t *testing.T) {
 	t.Run("EmptyAPIKey", func(t *testing.T) {
-		_, err := NewGeminiClient(context.Background(), "")
+		_, err := NewGeminiClient(context.Background(), "", GeminiConfig{})
 		if err == nil {
 			t.Fatal("Expected error with empty API key, got nil")
 		}
@@ -22,8 +28,32 @@ func TestGeminiClient_Summarize_EmptyText(t *testing.T) {
 	// We can't easily initialize a real client without a real key 
 	// for a full unit test, so we'll just test the guards.
 	c := &GeminiClient{} // Partially initialized client
-	_, err := c.Summarize(context.Background(), "")
+	_, err := c.Summarize(context.Background(), "", models.SummaryOptions{})
 	if err == nil {
 		t.Fatal("Expected error for empty text, got nil")
 	}
 }
+
+func TestBlockedError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Prompt", &genai.BlockedError{PromptFeedback: &genai.PromptFeedback{BlockReason: genai.BlockReasonSafety}}, ErrSafetyBlocked},
+		{"Safety", &genai.BlockedError{Candidate: &genai.Candidate{FinishReason: genai.FinishReasonSafety}}, ErrSafetyBlocked},
+		{"Recitation", &genai.BlockedError{Candidate: &genai.Candidate{FinishReason: genai.FinishReasonRecitation}}, ErrRecitationBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := blockedError(tt.err); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	other := fmt.Errorf("quota exceeded")
+	if err := blockedError(other); err != other {
+		t.Errorf("Expected other errors unchanged, got %v", err)
+	}
+}
@@ -1,16 +1,28 @@
 package helpers
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
+var (
+	markdownSpaces = strings.NewReplacer(
+		"\r\n", "\n",
+		"\r", "\n",
+		"\u00a0", " ", // non-breaking space
+		"\u202f", " ", // narrow non-breaking space
+	)
+	trailingSpaces  = regexp.MustCompile(`[ \t]+\n`)
+	extraBlankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// SanitizeMarkdown normalizes Markdown written by an LLM: newlines become
+// "\n", non-breaking spaces, which break list and heading syntax, become
+// plain spaces, trailing spaces are removed so they do not turn into hard
+// line breaks, and runs of blank lines are collapsed to one.
 func SanitizeMarkdown(input string) string {
-    // Replace Non-Breaking Spaces (U+00A0) with standard spaces
-    // Replace tabs with standard spaces
-    // Ensure consistent newlines
-    r := strings.NewReplacer(
-        "\u00a0", " ", 
-        "\u202f", " ", 
-        "\r\n", "\n",
-        "\n\n", " \n\n",
-    )
-    return r.Replace(input)
-}
\ No newline at end of file
+	s := markdownSpaces.Replace(input)
+	s = trailingSpaces.ReplaceAllString(s, "\n")
+	s = extraBlankLines.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
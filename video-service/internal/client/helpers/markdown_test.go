@@ -0,0 +1,22 @@
+package helpers
+
+import "testing"
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"BlankLinesUntouched", "# Title\n\n- one\n- two\n\nEnd.", "# Title\n\n- one\n- two\n\nEnd."},
+		{"NonBreakingSpaces", "-\u00a0one\n-\u202ftwo", "- one\n- two"},
+		{"WindowsNewlines", "a\r\n\r\nb\rc", "a\n\nb\nc"},
+		{"TrailingSpaces", "line one  \nline two\t\n", "line one\nline two"},
+		{"ExtraBlankLines", "a\n\n\n\n\nb", "a\n\nb"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SanitizeMarkdown(tc.input); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
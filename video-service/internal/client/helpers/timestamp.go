@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// FormatTimestamp renders seconds as h:mm:ss or m:ss, the way YouTube does.
+func FormatTimestamp(seconds float64) string {
+	total := int(seconds)
+	h, m, s := total/3600, (total%3600)/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// ParseISODuration reads the ISO 8601 durations the YouTube API gives video
+// lengths in, such as PT1H2M3S, as seconds. Empty is 0.
+func ParseISODuration(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	match := isoDurationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	var seconds int64
+	for i, unit := range []int64{86400, 3600, 60, 1} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		seconds += n * unit
+	}
+	return seconds, nil
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import "testing"
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int64
+	}{
+		{"", 0},
+		{"PT45S", 45},
+		{"PT4M13S", 253},
+		{"PT1H2M3S", 3723},
+		{"PT2H", 7200},
+		{"P1DT1S", 86401},
+		{"P0D", 0},
+	}
+	for _, tc := range tests {
+		if got, err := ParseISODuration(tc.input); err != nil || got != tc.want {
+			t.Errorf("%q: expected %d, got %d (%v)", tc.input, tc.want, got, err)
+		}
+	}
+	for _, input := range []string{"PT", "1:02:03", "PT1.5S"} {
+		if _, err := ParseISODuration(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
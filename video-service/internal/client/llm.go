@@ -0,0 +1,363 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"videoservice/internal/guard"
+	"videoservice/internal/models"
+	"videoservice/internal/prompts"
+)
+
+var (
+	// ErrSafetyBlocked is returned when a provider refuses the prompt or
+	// stops generating because of its safety filters.
+	ErrSafetyBlocked = errors.New("content blocked by the model's safety filters")
+	// ErrRecitationBlocked is returned when a provider stops generating
+	// because the output was reciting existing, possibly copyrighted,
+	// material.
+	ErrRecitationBlocked = errors.New("content blocked for reciting existing material")
+)
+
+// completer is the provider-specific half of an LLM client: it sends a
+// rendered prompt to a model and returns the generated text. The prompt's
+// System part goes in the provider's system instructions and its User part
+// in the user message.
+type completer interface {
+	// complete returns the generated text, sanitized for Markdown rendering.
+	complete(ctx context.Context, prompt prompts.Prompt) (string, error)
+	// completeStream passes each piece of generated text to onChunk as it
+	// arrives and returns the complete, sanitized text at the end.
+	completeStream(ctx context.Context, prompt prompts.Prompt, onChunk func(string) error) (string, error)
+	// completeJSON constrains the response to a JSON document matching schema
+	// and returns it unmodified.
+	completeJSON(ctx context.Context, prompt prompts.Prompt, schema *genai.Schema) (string, error)
+}
+
+// promptClient implements service.LLMClient on top of a completer, so every
+// provider renders the same versioned prompts and validates output the same
+// way. Markdown output is checked against the transcript it came from with
+// guard.Markdown; when streaming, that applies to the returned text, not to
+// the chunks.
+type promptClient struct {
+	completer completer
+}
+
+func (c promptClient) Summarize(ctx context.Context, text string, opts models.SummaryOptions) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("empty transcript provided for summarization")
+	}
+
+	prompt, err := prompts.Summary(text, opts)
+	if err != nil {
+		return "", err
+	}
+	logInjections("summary", prompt)
+
+	summary, err := c.completer.complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	return checkMarkdown(summary, outputSource(prompt, text)), nil
+}
+
+// SummarizeStream streams the summary through onChunk as the model generates
+// it and returns the complete, sanitized summary at the end.
+func (c promptClient) SummarizeStream(ctx context.Context, text string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("empty transcript provided for summarization")
+	}
+
+	prompt, err := prompts.Summary(text, opts)
+	if err != nil {
+		return "", err
+	}
+	logInjections("summary", prompt)
+
+	summary, err := c.completer.completeStream(ctx, prompt, onChunk)
+	if err != nil {
+		return "", err
+	}
+	return checkMarkdown(summary, outputSource(prompt, text)), nil
+}
+
+// CombineSummaries merges summaries of consecutive transcript parts into one.
+// A nil onChunk generates the result in one call instead of streaming it.
+func (c promptClient) CombineSummaries(ctx context.Context, partials []string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	if len(partials) == 0 {
+		return "", fmt.Errorf("no partial summaries provided to combine")
+	}
+
+	prompt, err := prompts.CombineSummaries(partials, opts)
+	if err != nil {
+		return "", err
+	}
+	logInjections("combine", prompt)
+
+	var summary string
+	if onChunk == nil {
+		summary, err = c.completer.complete(ctx, prompt)
+	} else {
+		summary, err = c.completer.completeStream(ctx, prompt, onChunk)
+	}
+	if err != nil {
+		return "", err
+	}
+	return checkMarkdown(summary, outputSource(prompt, strings.Join(partials, "\n\n"))), nil
+}
+
+// SummarizeStructured asks for JSON matching structuredSummarySchema and
+// validates the result.
+func (c promptClient) SummarizeStructured(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+	if transcript == "" {
+		return nil, fmt.Errorf("empty transcript provided for summarization")
+	}
+
+	prompt, err := prompts.StructuredSummary(transcript, opts)
+	if err != nil {
+		return nil, err
+	}
+	logInjections("structured", prompt)
+
+	raw, err := c.completer.completeJSON(ctx, prompt, structuredSummarySchema)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := models.ParseStructuredSummary([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	source := outputSource(prompt, transcript)
+	for i, r := range summary.Resources {
+		if r.URL != "" && !guard.URLInSource(r.URL, source) {
+			log.Printf("Removed from model output: resource URL not in transcript: %s", r.URL)
+			summary.Resources[i].URL = ""
+		}
+	}
+	return summary, nil
+}
+
+// GenerateChapters splits a timestamped transcript into titled chapters.
+func (c promptClient) GenerateChapters(ctx context.Context, transcript string) ([]models.Chapter, error) {
+	if transcript == "" {
+		return nil, fmt.Errorf("empty transcript provided for chapter generation")
+	}
+
+	prompt, err := prompts.Chapters(transcript)
+	if err != nil {
+		return nil, err
+	}
+	logInjections("chapters", prompt)
+
+	raw, err := c.completer.completeJSON(ctx, prompt, chaptersSchema)
+	if err != nil {
+		return nil, err
+	}
+	return models.ParseChapters([]byte(raw))
+}
+
+func (c promptClient) Answer(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error) {
+	if question == "" {
+		return "", fmt.Errorf("empty question provided")
+	}
+	if len(passages) == 0 {
+		return "", fmt.Errorf("no transcript passages provided to answer from")
+	}
+
+	prompt, err := prompts.Answer(question, passages, notes, history)
+	if err != nil {
+		return "", err
+	}
+	logInjections("answer", prompt)
+
+	answer, err := c.completer.complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	// The user may ask about a URL, have noted one or have been given one
+	// earlier in the conversation, so those count as source too.
+	source := []string{question}
+	for _, p := range passages {
+		source = append(source, p.Text)
+	}
+	for _, n := range notes {
+		source = append(source, n.Quote, n.Text)
+	}
+	for _, m := range history {
+		source = append(source, m.Content)
+	}
+	return checkMarkdown(answer, outputSource(prompt, strings.Join(source, "\n\n"))), nil
+}
+
+// CompareVideos writes a digest comparing summaries of several videos,
+// citing each by its number.
+func (c promptClient) CompareVideos(ctx context.Context, videos []models.VideoSummary, focus string, opts models.SummaryOptions) (string, error) {
+	if len(videos) == 0 {
+		return "", fmt.Errorf("no video summaries provided to compare")
+	}
+
+	prompt, err := prompts.Compare(videos, focus, opts)
+	if err != nil {
+		return "", err
+	}
+	logInjections("compare", prompt)
+
+	digest, err := c.completer.complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	source := []string{focus}
+	for _, v := range videos {
+		source = append(source, v.Summary)
+	}
+	return checkMarkdown(digest, outputSource(prompt, strings.Join(source, "\n\n"))), nil
+}
+
+// ChannelDigest writes a newsletter-style digest of a channel's recent
+// uploads from their summaries, citing each by its number.
+func (c promptClient) ChannelDigest(ctx context.Context, channel string, videos []models.VideoSummary, opts models.SummaryOptions) (string, error) {
+	if len(videos) == 0 {
+		return "", fmt.Errorf("no video summaries provided for a channel digest")
+	}
+
+	prompt, err := prompts.ChannelDigest(channel, videos, opts)
+	if err != nil {
+		return "", err
+	}
+	logInjections("channel digest", prompt)
+
+	digest, err := c.completer.complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	var source []string
+	for _, v := range videos {
+		source = append(source, v.Summary)
+	}
+	return checkMarkdown(digest, outputSource(prompt, strings.Join(source, "\n\n"))), nil
+}
+
+// logInjections logs what guard.Detect found in a prompt's untrusted text.
+// The prompt already warns the model; the log shows which videos try it.
+func logInjections(name string, prompt prompts.Prompt) {
+	for _, f := range prompt.Injections {
+		log.Printf("Possible prompt injection in %s prompt (%s): %q", name, f.Rule, f.Excerpt)
+	}
+}
+
+// outputSource returns the text a prompt's output is checked against: the
+// untrusted text it was rendered from, less any injections found in it.
+func outputSource(prompt prompts.Prompt, text string) string {
+	if len(prompt.Injections) == 0 {
+		return text
+	}
+	return guard.WithoutInjections(text)
+}
+
+// checkMarkdown applies guard.Markdown to model output, logging what it
+// removes.
+func checkMarkdown(output, source string) string {
+	checked, removed := guard.Markdown(output, source)
+	for _, r := range removed {
+		log.Printf("Removed from model output: %s", r)
+	}
+	return checked
+}
+
+// Usage is the number of tokens one LLM call consumed.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+type usageHookKey struct{}
+
+// WithUsageHook returns a context in which clients pass the token usage of
+// every call they make to hook, whenever the provider reports it.
+func WithUsageHook(ctx context.Context, hook func(Usage)) context.Context {
+	return context.WithValue(ctx, usageHookKey{}, hook)
+}
+
+// ReportUsage passes u to the usage hook in ctx, if there is one. Every
+// LLMClient implementation calls it for each call whose usage it knows.
+func ReportUsage(ctx context.Context, u Usage) {
+	hook, ok := ctx.Value(usageHookKey{}).(func(Usage))
+	if !ok || (u.PromptTokens == 0 && u.CompletionTokens == 0) {
+		return
+	}
+	hook(u)
+}
+
+// APIError is returned when an LLM provider's HTTP API answers with a non-2xx
+// status.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API error: %d %s - %s", e.Provider, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// httpTimeout bounds each call to an LLM provider's HTTP API, reading a
+// streamed response included, so a stalled provider cannot hold a request
+// forever. Routes can set a shorter timeout.
+const httpTimeout = 5 * time.Minute
+
+// postJSON sends body as JSON and returns the response for the caller to
+// read. Non-2xx responses are turned into an *APIError.
+func postJSON(ctx context.Context, httpClient *http.Client, provider, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s request: %w", provider, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", provider, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s API: %w", provider, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &APIError{Provider: provider, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	return resp, nil
+}
+
+// readSSE calls onData with the payload of every "data:" line of a
+// Server-Sent Events stream until the stream ends or onData returns an error.
+func readSSE(body io.Reader, onData func(data string) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		if err := onData(strings.TrimSpace(strings.TrimPrefix(line, "data:"))); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/prompts"
+)
+
+// OpenAIClient implements service.LLMClient with an OpenAI-compatible chat
+// completions API. Besides OpenAI itself this covers local servers such as
+// Ollama and llama.cpp, which usually need no API key.
+type OpenAIClient struct {
+	promptClient
+	httpClient     *http.Client
+	baseURL        string
+	apiKey         string
+	model          string
+	embeddingModel string
+}
+
+// NewOpenAIClient creates a client for the API at baseURL, e.g.
+// https://api.openai.com/v1 or http://localhost:11434/v1 for Ollama.
+// embeddingModel may be empty when the server has no embeddings endpoint.
+func NewOpenAIClient(baseURL, apiKey, model, embeddingModel string) (*OpenAIClient, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("OpenAI-compatible base URL is required")
+	}
+	if model == "" {
+		return nil, fmt.Errorf("OpenAI-compatible model name is required")
+	}
+
+	c := &OpenAIClient{
+		httpClient:     &http.Client{Timeout: httpTimeout},
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		apiKey:         apiKey,
+		model:          model,
+		embeddingModel: embeddingModel,
+	}
+	c.promptClient = promptClient{completer: c}
+	return c, nil
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []openAIMessage        `json:"messages"`
+	Stream         bool                   `json:"stream,omitempty"`
+	ResponseFormat map[string]interface{} `json:"response_format,omitempty"`
+	StreamOptions  map[string]interface{} `json:"stream_options,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		Delta        openAIMessage `json:"delta"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func (r *openAIChatResponse) usage() Usage {
+	if r.Usage == nil {
+		return Usage{}
+	}
+	return Usage{PromptTokens: r.Usage.PromptTokens, CompletionTokens: r.Usage.CompletionTokens}
+}
+
+func (c *OpenAIClient) complete(ctx context.Context, prompt prompts.Prompt) (string, error) {
+	result, err := c.chat(ctx, openAIChatRequest{Model: c.model, Messages: chatMessages(prompt)})
+	if err != nil {
+		return "", err
+	}
+	return helpers.SanitizeMarkdown(result), nil
+}
+
+func (c *OpenAIClient) completeJSON(ctx context.Context, prompt prompts.Prompt, schema *genai.Schema) (string, error) {
+	return c.chat(ctx, openAIChatRequest{
+		Model:    c.model,
+		Messages: chatMessages(prompt),
+		ResponseFormat: map[string]interface{}{
+			"type": "json_schema",
+			"json_schema": map[string]interface{}{
+				"name":   "response",
+				"schema": toJSONSchema(schema),
+			},
+		},
+	})
+}
+
+func (c *OpenAIClient) completeStream(ctx context.Context, prompt prompts.Prompt, onChunk func(string) error) (string, error) {
+	resp, err := postJSON(ctx, c.httpClient, "OpenAI", c.baseURL+"/chat/completions", c.headers(), openAIChatRequest{
+		Model:    c.model,
+		Messages: chatMessages(prompt),
+		Stream:   true,
+		// Asks for a final event carrying the token usage.
+		StreamOptions: map[string]interface{}{"include_usage": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result strings.Builder
+	err = readSSE(resp.Body, func(data string) error {
+		if data == "[DONE]" {
+			return nil
+		}
+		var chunk openAIChatResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to decode OpenAI stream event: %w", err)
+		}
+		ReportUsage(ctx, chunk.usage())
+		if len(chunk.Choices) == 0 {
+			return nil
+		}
+		if chunk.Choices[0].FinishReason == "content_filter" {
+			return ErrSafetyBlocked
+		}
+		if chunk.Choices[0].Delta.Content == "" {
+			return nil
+		}
+		text := chunk.Choices[0].Delta.Content
+		result.WriteString(text)
+		return onChunk(text)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if result.Len() == 0 {
+		return "", fmt.Errorf("empty result from OpenAI")
+	}
+	return helpers.SanitizeMarkdown(result.String()), nil
+}
+
+func (c *OpenAIClient) chat(ctx context.Context, body openAIChatRequest) (string, error) {
+	resp, err := postJSON(ctx, c.httpClient, "OpenAI", c.baseURL+"/chat/completions", c.headers(), body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var chatResp openAIChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return "", fmt.Errorf("failed to decode OpenAI response: %w", err)
+	}
+	ReportUsage(ctx, chatResp.usage())
+	if len(chatResp.Choices) > 0 && chatResp.Choices[0].FinishReason == "content_filter" {
+		return "", ErrSafetyBlocked
+	}
+	if len(chatResp.Choices) == 0 || chatResp.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("no content generated by OpenAI")
+	}
+	return chatResp.Choices[0].Message.Content, nil
+}
+
+// Embed returns one embedding per text using the configured embedding model.
+func (c *OpenAIClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if c.embeddingModel == "" {
+		return nil, fmt.Errorf("no OpenAI embedding model configured")
+	}
+
+	resp, err := postJSON(ctx, c.httpClient, "OpenAI", c.baseURL+"/embeddings", c.headers(), map[string]interface{}{
+		"model": c.embeddingModel,
+		"input": texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var embedResp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAI embeddings: %w", err)
+	}
+	if len(embedResp.Data) != len(texts) {
+		return nil, fmt.Errorf("OpenAI returned %d embeddings for %d texts", len(embedResp.Data), len(texts))
+	}
+
+	sort.Slice(embedResp.Data, func(i, j int) bool { return embedResp.Data[i].Index < embedResp.Data[j].Index })
+	vectors := make([][]float32, len(texts))
+	for i, d := range embedResp.Data {
+		vectors[i] = d.Embedding
+	}
+	return vectors, nil
+}
+
+func (c *OpenAIClient) headers() map[string]string {
+	if c.apiKey == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + c.apiKey}
+}
+
+// chatMessages sends the prompt's instructions as a system message ahead of
+// the user message.
+func chatMessages(prompt prompts.Prompt) []openAIMessage {
+	messages := []openAIMessage{{Role: "user", Content: prompt.User}}
+	if prompt.System != "" {
+		messages = append([]openAIMessage{{Role: "system", Content: prompt.System}}, messages...)
+	}
+	return messages
+}
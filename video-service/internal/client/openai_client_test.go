@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"videoservice/internal/models"
+)
+
+func TestOpenAIClient(t *testing.T) {
+	var lastRequest map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Expected bearer token, got %q", got)
+		}
+		if r.URL.Path == "/v1/embeddings" {
+			w.Write([]byte(`{"data": [{"index": 1, "embedding": [0, 1]}, {"index": 0, "embedding": [1, 0]}]}`))
+			return
+		}
+
+		lastRequest = nil
+		json.NewDecoder(r.Body).Decode(&lastRequest)
+		switch {
+		case lastRequest["stream"] == true:
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, delta := range []string{"Hello", " world"} {
+				fmt.Fprintf(w, "data: {\"choices\": [{\"delta\": {\"content\": %q}}]}\n\n", delta)
+			}
+			fmt.Fprint(w, "data: {\"choices\": [], \"usage\": {\"prompt_tokens\": 20, \"completion_tokens\": 2}}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		case lastRequest["response_format"] != nil:
+			w.Write([]byte(`{"choices": [{"message": {"content": "{\"chapters\": [{\"title\": \"Intro\", \"start\": \"0:00\"}]}"}}]}`))
+		default:
+			w.Write([]byte(`{"choices": [{"message": {"content": "A summary."}}], "usage": {"prompt_tokens": 12, "completion_tokens": 3}}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewOpenAIClient(ts.URL+"/v1/", "test-key", "test-model", "test-embedder")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Summarize", func(t *testing.T) {
+		var usage Usage
+		ctx := WithUsageHook(context.Background(), func(u Usage) { usage = u })
+		summary, err := c.Summarize(ctx, "some transcript", models.SummaryOptions{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary != "A summary." {
+			t.Errorf("Expected summary, got %q", summary)
+		}
+		if usage != (Usage{PromptTokens: 12, CompletionTokens: 3}) {
+			t.Errorf("Expected the reported token usage, got %+v", usage)
+		}
+		if lastRequest["model"] != "test-model" {
+			t.Errorf("Expected configured model, got %v", lastRequest["model"])
+		}
+		messages := lastRequest["messages"].([]interface{})
+		system := messages[0].(map[string]interface{})
+		user := messages[1].(map[string]interface{})
+		if system["role"] != "system" || strings.Contains(system["content"].(string), "some transcript") {
+			t.Errorf("Expected the instructions in a system message, got %v", system)
+		}
+		if user["role"] != "user" || !strings.Contains(user["content"].(string), "some transcript") {
+			t.Errorf("Expected the transcript in the user message, got %v", user)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		var usage Usage
+		ctx := WithUsageHook(context.Background(), func(u Usage) { usage = u })
+		var chunks []string
+		summary, err := c.SummarizeStream(ctx, "some transcript", models.SummaryOptions{}, func(chunk string) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary != "Hello world" || len(chunks) != 2 {
+			t.Errorf("Expected 2 chunks making up the summary, got %q from %v", summary, chunks)
+		}
+		if usage.PromptTokens != 20 || lastRequest["stream_options"] == nil {
+			t.Errorf("Expected usage from the final stream event, got %+v", usage)
+		}
+	})
+
+	t.Run("Chapters", func(t *testing.T) {
+		chapters, err := c.GenerateChapters(context.Background(), "[0:00] hello")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(chapters) != 1 || chapters[0].Title != "Intro" {
+			t.Errorf("Expected one chapter, got %+v", chapters)
+		}
+		format := lastRequest["response_format"].(map[string]interface{})
+		schema := format["json_schema"].(map[string]interface{})["schema"].(map[string]interface{})
+		if schema["type"] != "object" || schema["properties"] == nil {
+			t.Errorf("Expected the chapters JSON schema, got %v", schema)
+		}
+	})
+
+	t.Run("Embed", func(t *testing.T) {
+		vectors, err := c.Embed(context.Background(), []string{"a", "b"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if vectors[0][0] != 1 || vectors[1][1] != 1 {
+			t.Errorf("Expected embeddings in input order, got %v", vectors)
+		}
+	})
+}
+
+func TestOpenAIClient_APIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "slow down"}}`, http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c, _ := NewOpenAIClient(ts.URL, "", "test-model", "")
+	_, err := c.Summarize(context.Background(), "some transcript", models.SummaryOptions{})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected a 429 APIError, got %v", err)
+	}
+	if !strings.Contains(apiErr.Message, "slow down") {
+		t.Errorf("Expected the error body in the message, got %q", apiErr.Message)
+	}
+}
+
+func TestOpenAIClient_ContentFilter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices": [{"message": {"content": ""}, "finish_reason": "content_filter"}]}`))
+	}))
+	defer ts.Close()
+
+	c, _ := NewOpenAIClient(ts.URL, "", "test-model", "")
+	if _, err := c.Summarize(context.Background(), "some transcript", models.SummaryOptions{}); !errors.Is(err, ErrSafetyBlocked) {
+		t.Errorf("Expected ErrSafetyBlocked, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"github.com/google/generative-ai-go/genai"
+
+	"videoservice/internal/models"
+)
+
+// Response schemas are written once as genai.Schema, which Gemini takes
+// directly; toJSONSchema converts them for providers that take JSON Schema.
+
+// structuredSummarySchema mirrors models.StructuredSummary so that the
+// model's JSON output decodes into it directly.
+var structuredSummarySchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"title": {Type: genai.TypeString},
+		"tldr":  {Type: genai.TypeString},
+		"chapters": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"title":   {Type: genai.TypeString},
+					"start":   {Type: genai.TypeString, Description: "Start timestamp copied from the transcript, e.g. 12:34"},
+					"summary": {Type: genai.TypeString},
+				},
+				Required: []string{"title", "start", "summary"},
+			},
+		},
+		"key_takeaways": {Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}},
+		"entities": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"name": {Type: genai.TypeString},
+					"type": {Type: genai.TypeString, Format: "enum", Enum: models.EntityTypes},
+				},
+				Required: []string{"name", "type"},
+			},
+		},
+		"resources": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"title": {Type: genai.TypeString},
+					"url":   {Type: genai.TypeString},
+				},
+				Required: []string{"title"},
+			},
+		},
+		"action_items": {Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}},
+	},
+	Required: []string{"title", "tldr", "chapters", "key_takeaways", "entities", "resources", "action_items"},
+}
+
+// chaptersSchema is the response schema for chapter generation, decoded by
+// models.ParseChapters.
+var chaptersSchema = &genai.Schema{
+	Type: genai.TypeObject,
+	Properties: map[string]*genai.Schema{
+		"chapters": {
+			Type: genai.TypeArray,
+			Items: &genai.Schema{
+				Type: genai.TypeObject,
+				Properties: map[string]*genai.Schema{
+					"title": {Type: genai.TypeString},
+					"start": {Type: genai.TypeString, Description: "Start timestamp copied from the transcript, e.g. 12:34"},
+				},
+				Required: []string{"title", "start"},
+			},
+		},
+	},
+	Required: []string{"chapters"},
+}
+
+// toJSONSchema converts a genai.Schema into the equivalent JSON Schema
+// document.
+func toJSONSchema(s *genai.Schema) map[string]interface{} {
+	doc := map[string]interface{}{}
+	switch s.Type {
+	case genai.TypeObject:
+		doc["type"] = "object"
+	case genai.TypeArray:
+		doc["type"] = "array"
+	case genai.TypeString:
+		doc["type"] = "string"
+	case genai.TypeNumber:
+		doc["type"] = "number"
+	case genai.TypeInteger:
+		doc["type"] = "integer"
+	case genai.TypeBoolean:
+		doc["type"] = "boolean"
+	}
+	if s.Description != "" {
+		doc["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		doc["enum"] = s.Enum
+	}
+	if s.Items != nil {
+		doc["items"] = toJSONSchema(s.Items)
+	}
+	if len(s.Properties) > 0 {
+		props := make(map[string]interface{}, len(s.Properties))
+		for name, prop := range s.Properties {
+			props[name] = toJSONSchema(prop)
+		}
+		doc["properties"] = props
+	}
+	if len(s.Required) > 0 {
+		doc["required"] = s.Required
+	}
+	return doc
+}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/models"
+)
+
+// stubEmbeddingDims is the size of the StubClient's hashed bag-of-words
+// vectors.
+const stubEmbeddingDims = 256
+
+// stubMaxChapters caps the chapters StubClient derives from a transcript.
+const stubMaxChapters = 6
+
+var (
+	stubMarker = regexp.MustCompile(`^\[(\d+(?::\d{2}){1,2})\]\s*(.*)$`)
+	stubWord   = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// StubClient is a deterministic, offline stand-in for an LLM. It builds
+// summaries, chapters and answers from the transcript itself without calling
+// any model, so the whole stack can run in development and tests without API
+// keys. Its embeddings are hashed word counts, good enough for lexical
+// semantic search.
+type StubClient struct{}
+
+func NewStubClient() *StubClient {
+	return &StubClient{}
+}
+
+func (c *StubClient) Summarize(ctx context.Context, text string, opts models.SummaryOptions) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("empty transcript provided for summarization")
+	}
+	return stubSummary(text, opts), nil
+}
+
+// SummarizeStream emits the stub summary one word at a time.
+func (c *StubClient) SummarizeStream(ctx context.Context, text string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	summary, err := c.Summarize(ctx, text, opts)
+	if err != nil {
+		return "", err
+	}
+	if err := streamWords(summary, onChunk); err != nil {
+		return "", err
+	}
+	return summary, nil
+}
+
+func (c *StubClient) CombineSummaries(ctx context.Context, partials []string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	if len(partials) == 0 {
+		return "", fmt.Errorf("no partial summaries provided to combine")
+	}
+
+	summary := stubSummary(strings.Join(partials, "\n"), opts)
+	if onChunk != nil {
+		if err := streamWords(summary, onChunk); err != nil {
+			return "", err
+		}
+	}
+	return summary, nil
+}
+
+// SummarizeStructured uses the opening words as title and TL;DR, and the
+// transcript's [m:ss] markers for chapters and key takeaways.
+func (c *StubClient) SummarizeStructured(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+	if transcript == "" {
+		return nil, fmt.Errorf("empty transcript provided for summarization")
+	}
+
+	type chapter struct {
+		Title   string `json:"title"`
+		Start   string `json:"start"`
+		Summary string `json:"summary"`
+	}
+	chapters := []chapter{}
+	takeaways := []string{}
+	for _, line := range stubChapterLines(transcript) {
+		chapters = append(chapters, chapter{
+			Title:   firstWords(line.text, 5),
+			Start:   line.start,
+			Summary: firstWords(line.text, 20),
+		})
+		takeaways = append(takeaways, firstWords(line.text, 15))
+	}
+
+	text := stripMarkers(transcript)
+	data, err := json.Marshal(map[string]interface{}{
+		"title":         firstWords(text, 8),
+		"tldr":          firstWords(text, 30),
+		"chapters":      chapters,
+		"key_takeaways": takeaways,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return models.ParseStructuredSummary(data)
+}
+
+// GenerateChapters starts a chapter at evenly spaced [m:ss] markers, titled
+// with the first words spoken there.
+func (c *StubClient) GenerateChapters(ctx context.Context, transcript string) ([]models.Chapter, error) {
+	if transcript == "" {
+		return nil, fmt.Errorf("empty transcript provided for chapter generation")
+	}
+
+	type chapter struct {
+		Title string `json:"title"`
+		Start string `json:"start"`
+	}
+	var chapters []chapter
+	for _, line := range stubChapterLines(transcript) {
+		chapters = append(chapters, chapter{Title: firstWords(line.text, 5), Start: line.start})
+	}
+	if len(chapters) == 0 {
+		chapters = append(chapters, chapter{Title: firstWords(transcript, 5), Start: "0:00"})
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"chapters": chapters})
+	if err != nil {
+		return nil, err
+	}
+	return models.ParseChapters(data)
+}
+
+// Answer quotes the first passage, cited by its start timestamp.
+func (c *StubClient) Answer(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error) {
+	if question == "" {
+		return "", fmt.Errorf("empty question provided")
+	}
+	if len(passages) == 0 {
+		return "", fmt.Errorf("no transcript passages provided to answer from")
+	}
+
+	p := passages[0]
+	return fmt.Sprintf("The most relevant part of the video says: %q [%s]", firstWords(p.Text, 40), helpers.FormatTimestamp(p.StartSeconds)), nil
+}
+
+// CompareVideos lists the opening words of each summary, cited by video
+// number.
+func (c *StubClient) CompareVideos(ctx context.Context, videos []models.VideoSummary, focus string, opts models.SummaryOptions) (string, error) {
+	if len(videos) == 0 {
+		return "", fmt.Errorf("no video summaries provided to compare")
+	}
+
+	var b strings.Builder
+	if focus != "" {
+		fmt.Fprintf(&b, "What %d videos say about: %s\n\n", len(videos), focus)
+	} else {
+		fmt.Fprintf(&b, "Comparison of %d videos\n\n", len(videos))
+	}
+	for _, v := range videos {
+		fmt.Fprintf(&b, "- %s [%d]\n", firstWords(v.Summary, 20), v.Number)
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// ChannelDigest lists each upload's title with the opening words of its
+// summary, cited by video number.
+func (c *StubClient) ChannelDigest(ctx context.Context, channel string, videos []models.VideoSummary, opts models.SummaryOptions) (string, error) {
+	if len(videos) == 0 {
+		return "", fmt.Errorf("no video summaries provided for a channel digest")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %d new videos from %s\n\n", len(videos), channel)
+	for _, v := range videos {
+		fmt.Fprintf(&b, "## %s\n\n%s [%d]\n\n", v.Title, firstWords(v.Summary, 30), v.Number)
+	}
+	return strings.TrimSuffix(b.String(), "\n\n"), nil
+}
+
+// Embed hashes each word into one of stubEmbeddingDims buckets and returns
+// the normalized counts.
+func (c *StubClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		v := make([]float32, stubEmbeddingDims)
+		for _, word := range stubWord.FindAllString(strings.ToLower(text), -1) {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			v[h.Sum32()%stubEmbeddingDims]++
+		}
+
+		var norm float64
+		for _, x := range v {
+			norm += float64(x * x)
+		}
+		if norm > 0 {
+			scale := float32(1 / math.Sqrt(norm))
+			for j := range v {
+				v[j] *= scale
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+// stubSummary keeps the opening words of text, more of them for longer
+// summaries.
+func stubSummary(text string, opts models.SummaryOptions) string {
+	words := map[models.SummaryLength]int{
+		models.SummaryLengthShort: 40,
+		models.SummaryLengthLong:  160,
+	}[opts.Length]
+	if words == 0 {
+		words = 80
+	}
+	if opts.Style == models.SummaryStyleTLDR {
+		words /= 2
+	}
+
+	style := opts.Style
+	if style == "" {
+		style = models.DefaultSummaryStyle
+	}
+	return fmt.Sprintf("**Summary** (%s, offline stub)\n\n%s", style, firstWords(stripMarkers(text), words))
+}
+
+type stubLine struct {
+	start string
+	text  string
+}
+
+// stubChapterLines returns up to stubMaxChapters evenly spaced lines that
+// start with an [m:ss] marker.
+func stubChapterLines(transcript string) []stubLine {
+	var lines []stubLine
+	for _, line := range strings.Split(transcript, "\n") {
+		m := stubMarker.FindStringSubmatch(strings.TrimSpace(line))
+		if m != nil && strings.TrimSpace(m[2]) != "" {
+			lines = append(lines, stubLine{start: m[1], text: m[2]})
+		}
+	}
+	if len(lines) <= stubMaxChapters {
+		return lines
+	}
+
+	step := (len(lines) + stubMaxChapters - 1) / stubMaxChapters
+	var picked []stubLine
+	for i := 0; i < len(lines); i += step {
+		picked = append(picked, lines[i])
+	}
+	return picked
+}
+
+func stripMarkers(transcript string) string {
+	lines := strings.Split(transcript, "\n")
+	for i, line := range lines {
+		if m := stubMarker.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			lines[i] = m[2]
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+func firstWords(text string, n int) string {
+	words := strings.Fields(text)
+	if len(words) <= n {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:n], " ") + "…"
+}
+
+func streamWords(text string, onChunk func(string) error) error {
+	for _, word := range strings.SplitAfter(text, " ") {
+		if word == "" {
+			continue
+		}
+		if err := onChunk(word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
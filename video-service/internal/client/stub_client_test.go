@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"videoservice/internal/models"
+)
+
+func TestStubClient(t *testing.T) {
+	c := NewStubClient()
+	ctx := context.Background()
+	transcript := "[0:00] welcome to the show\n[1:30] today we talk about go\n[4:05] and then about rust"
+
+	t.Run("Summarize", func(t *testing.T) {
+		first, err := c.Summarize(ctx, transcript, models.SummaryOptions{Style: models.SummaryStyleBullets})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		second, _ := c.Summarize(ctx, transcript, models.SummaryOptions{Style: models.SummaryStyleBullets})
+		if first != second {
+			t.Errorf("Expected deterministic output, got %q and %q", first, second)
+		}
+		if strings.Contains(first, "[1:30]") || !strings.Contains(first, "welcome to the show") {
+			t.Errorf("Expected transcript text without markers, got %q", first)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		var streamed strings.Builder
+		summary, err := c.SummarizeStream(ctx, transcript, models.SummaryOptions{}, func(chunk string) error {
+			streamed.WriteString(chunk)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if streamed.String() != summary {
+			t.Errorf("Expected chunks to add up to the summary, got %q vs %q", streamed.String(), summary)
+		}
+	})
+
+	t.Run("Structured", func(t *testing.T) {
+		summary, err := c.SummarizeStructured(ctx, transcript, models.SummaryOptions{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(summary.Chapters) != 3 || summary.Chapters[2].StartSeconds != 245 {
+			t.Errorf("Expected chapters at the transcript markers, got %+v", summary.Chapters)
+		}
+	})
+
+	t.Run("Chapters", func(t *testing.T) {
+		chapters, err := c.GenerateChapters(ctx, transcript)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(chapters) != 3 || chapters[1].Title != "today we talk about go" {
+			t.Errorf("Expected chapters at the transcript markers, got %+v", chapters)
+		}
+	})
+
+	t.Run("Answer", func(t *testing.T) {
+		answer, err := c.Answer(ctx, "What about go?", []models.TranscriptChunk{{Text: "today we talk about go", StartSeconds: 90}}, nil, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(answer, "[1:30]") {
+			t.Errorf("Expected a timestamp citation, got %q", answer)
+		}
+	})
+
+	t.Run("Embed", func(t *testing.T) {
+		vectors, err := c.Embed(ctx, []string{"go programming", "Go programming!", "baking bread"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if dot(vectors[0], vectors[1]) < 0.99 {
+			t.Error("Expected texts with the same words to embed identically")
+		}
+		if dot(vectors[0], vectors[2]) > 0.5 {
+			t.Error("Expected unrelated texts to embed far apart")
+		}
+	})
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"videoservice/internal/client/helpers"
 	"videoservice/internal/models"
 )
 
@@ -80,6 +82,9 @@ type VideoDetailsResponse struct {
 			ViewCount string `json:"viewCount"`
 			LikeCount string `json:"likeCount"`
 		} `json:"statistics"`
+		ContentDetails struct {
+			Duration string `json:"duration"`
+		} `json:"contentDetails"`
 	} `json:"items"`
 }
 
@@ -227,7 +232,7 @@ func (c *YouTubeClient) GetChannelVideos(channelID string, maxResults int, pageT
 
 func (c *YouTubeClient) GetVideoDetails(videoID string) (*models.Video, error) {
 	detailsURL := fmt.Sprintf(
-		"https://www.googleapis.com/youtube/v3/videos?key=%s&id=%s&part=snippet,statistics",
+		"https://www.googleapis.com/youtube/v3/videos?key=%s&id=%s&part=snippet,statistics,contentDetails",
 		c.apiKey,
 		videoID,
 	)
@@ -257,16 +262,21 @@ func (c *YouTubeClient) GetVideoDetails(videoID string) (*models.Video, error) {
 	var viewCount, likeCount int64
 	fmt.Sscanf(item.Statistics.ViewCount, "%d", &viewCount)
 	fmt.Sscanf(item.Statistics.LikeCount, "%d", &likeCount)
+	duration, err := helpers.ParseISODuration(item.ContentDetails.Duration)
+	if err != nil {
+		log.Printf("Ignoring duration of video %s: %v", videoID, err)
+	}
 
 	return &models.Video{
-		VideoID:      item.ID,
-		Title:        item.Snippet.Title,
-		Description:  item.Snippet.Description,
-		Thumbnail:    item.Snippet.Thumbnails.Default.URL,
-		PublishedAt:  item.Snippet.PublishedAt,
-		ChannelID:    item.Snippet.ChannelID,
-		ChannelTitle: item.Snippet.ChannelTitle,
-		ViewCount:    viewCount,
-		LikeCount:    likeCount,
+		VideoID:         item.ID,
+		Title:           item.Snippet.Title,
+		Description:     item.Snippet.Description,
+		Thumbnail:       item.Snippet.Thumbnails.Default.URL,
+		PublishedAt:     item.Snippet.PublishedAt,
+		ChannelID:       item.Snippet.ChannelID,
+		ChannelTitle:    item.Snippet.ChannelTitle,
+		ViewCount:       viewCount,
+		LikeCount:       likeCount,
+		DurationSeconds: duration,
 	}, nil
 }
@@ -0,0 +1,115 @@
+// Package guard defends the LLM prompts against prompt injection. Transcripts
+// are written by whoever uploaded the video, so before they go into a prompt
+// they are cleaned of invisible characters and chat-template tokens, fenced
+// so they cannot close the tags that delimit them, and scanned for text
+// addressed to the model. What the model writes back is checked against the
+// same source before anyone sees it.
+package guard
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Finding is a piece of untrusted text that looks like an instruction to the
+// model rather than content.
+type Finding struct {
+	// Rule names the pattern that matched.
+	Rule string
+	// Excerpt is the matching text, shortened for logging.
+	Excerpt string
+}
+
+var injectionRules = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"override", regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override|bypass)\b[^.\n]{0,40}?\b(?:previous|prior|above|earlier|preceding|all|any|your|the|these|those)\b[^.\n]{0,20}?\b(?:instructions?|prompts?|rules|directions|guidelines|directives)\b`)},
+	{"new_instructions", regexp.MustCompile(`(?i)\b(?:new|updated|real|actual|additional)\s+(?:instructions?|rules|directives)\s*:`)},
+	{"role_change", regexp.MustCompile(`(?i)\b(?:you\s+are\s+(?:now|no\s+longer)|from\s+now\s+on,?\s+(?:you|the\s+assistant)|pretend\s+(?:to\s+be|you\s+are))\b`)},
+	{"address_model", regexp.MustCompile(`(?i)\b(?:dear|hey|attention|note\s+to(?:\s+the)?|if\s+you\s+are\s+an?)\s+(?:AI|assistant|language\s+model|LLM|chatbot|summari[sz]er|GPT|Gemini|Claude)\b`)},
+	{"prompt_leak", regexp.MustCompile(`(?i)\b(?:reveal|print|repeat|show|output)\b[^.\n]{0,30}?\b(?:system|developer|hidden|initial)\s+(?:prompt|message|instructions?)\b`)},
+	{"role_marker", regexp.MustCompile(`(?im)^[\t ]*(?:#+[\t ]*)?(?:system|assistant|developer)[\t ]*:`)},
+	{"special_token", specialTokens},
+}
+
+// specialTokens matches the turn and role markers of common chat templates,
+// which never belong in a transcript.
+var specialTokens = regexp.MustCompile(`(?i)<\|[a-z_]+\|>|\[/?INST\]|<</?SYS>>|</?(?:start|end)_of_turn>`)
+
+// Detect reports the parts of untrusted text that look like instructions to
+// the model. Videos about prompt injection will trip it, so findings are a
+// reason to warn the model, not to refuse the text.
+func Detect(text string) []Finding {
+	var findings []Finding
+	for _, rule := range injectionRules {
+		for _, match := range rule.re.FindAllString(text, 3) {
+			findings = append(findings, Finding{Rule: rule.name, Excerpt: excerpt(match)})
+		}
+	}
+	return findings
+}
+
+// sentenceEnds matches the end of a sentence or line. A period must be
+// followed by a space so that URLs stay in one piece.
+var sentenceEnds = regexp.MustCompile(`[.!?]+\s+|\n`)
+
+// WithoutInjections returns text without the sentences Detect finds
+// something in, nor the sentence after each, since an injected instruction
+// often carries on there. Output is checked against what is left, so that a
+// URL an injection asks the model to promote does not count as coming from
+// the video.
+func WithoutInjections(text string) string {
+	var b strings.Builder
+	start, skip := 0, 0
+	ends := append(sentenceEnds.FindAllStringIndex(text, -1), []int{len(text), len(text)})
+	for _, end := range ends {
+		sentence := text[start:end[1]]
+		start = end[1]
+		if len(Detect(sentence)) > 0 {
+			skip = 2
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		b.WriteString(sentence)
+	}
+	return b.String()
+}
+
+func excerpt(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 80 {
+		return string(r[:80]) + "..."
+	}
+	return s
+}
+
+// Clean removes what untrusted text can use to hide instructions: control
+// and invisible formatting characters, such as zero-width spaces,
+// bidirectional overrides and Unicode tag characters, and chat-template
+// tokens.
+func Clean(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, text)
+	return specialTokens.ReplaceAllString(text, "")
+}
+
+// fenceTags are the tags the prompt templates put around untrusted text.
+var fenceTags = regexp.MustCompile(`(?i)<(\s*/?\s*(?:transcript|excerpts?|summary|conversation|message|question|video|focus|notes?)\b)`)
+
+// Escape cleans untrusted text and escapes anything in it that looks like one
+// of the tags the templates delimit it with, so it cannot end its fence early
+// and continue as instructions.
+func Escape(text string) string {
+	return fenceTags.ReplaceAllString(Clean(text), "&lt;$1")
+}
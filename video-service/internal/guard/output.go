@@ -0,0 +1,100 @@
+package guard
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// codeSpans matches fenced code blocks and inline code, which Markdown
+	// renders literally and so are left alone.
+	codeSpans = regexp.MustCompile("(?s)```.*?(?:```|$)|`[^`\n]+`")
+
+	// markdownLinks matches inline links and images: [text](url "title").
+	// A removed image keeps its alt text.
+	markdownLinks = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(\s*<?([^)\s>]*)>?(?:\s+"[^"\n]*")?\s*\)`)
+	// bareURLs matches URLs in running text, with or without autolink
+	// brackets.
+	bareURLs = regexp.MustCompile(`(?i)<?\b(?:https?://|www\.)[^\s<>()\[\]"'` + "`" + `]+>?`)
+
+	// rawHTML matches HTML tags. Only known element names are matched so
+	// that text such as "a<b" or "List<String>" survives.
+	rawHTML   = regexp.MustCompile(`(?i)</?(?:a|abbr|audio|b|base|blockquote|body|br|button|canvas|center|code|details|div|em|embed|font|form|frame|frameset|h[1-6]|head|hr|html|i|iframe|img|input|kbd|label|li|link|marquee|meta|noscript|object|ol|p|param|picture|pre|s|select|source|span|strong|sub|summary|sup|svg|table|tbody|td|template|textarea|th|thead|title|tr|u|ul|video)\b[^>]*>`)
+	rawScript = regexp.MustCompile(`(?is)<(script|style)\b.*?(?:</(?:script|style)\s*>|$)`)
+)
+
+// LinkRemoved replaces a URL that was removed from model output.
+const LinkRemoved = "[link removed]"
+
+// Markdown checks model output that will be rendered as Markdown against the
+// untrusted source it was generated from. Raw HTML is stripped, and links to
+// URLs that do not appear in the source are removed, keeping the link text:
+// a model that followed an injected instruction to advertise a URL should
+// not be able to put it in front of the reader. It returns the checked output
+// and a description of each removal.
+func Markdown(output, source string) (string, []string) {
+	var removed []string
+	checked := outsideCode(output, func(text string) string {
+		text = rawScript.ReplaceAllStringFunc(text, func(m string) string {
+			removed = append(removed, "html: "+excerpt(m))
+			return ""
+		})
+		text = rawHTML.ReplaceAllStringFunc(text, func(m string) string {
+			removed = append(removed, "html: "+excerpt(m))
+			return ""
+		})
+		text = markdownLinks.ReplaceAllStringFunc(text, func(m string) string {
+			parts := markdownLinks.FindStringSubmatch(m)
+			if URLInSource(parts[3], source) {
+				return m
+			}
+			removed = append(removed, "link: "+excerpt(parts[3]))
+			return parts[2]
+		})
+		return bareURLs.ReplaceAllStringFunc(text, func(m string) string {
+			u := strings.TrimSuffix(strings.TrimPrefix(m, "<"), ">")
+			trimmed := strings.TrimRight(u, ".,;:!?*_~")
+			if URLInSource(trimmed, source) {
+				return m
+			}
+			removed = append(removed, "link: "+excerpt(trimmed))
+			return LinkRemoved + u[len(trimmed):]
+		})
+	})
+	return checked, removed
+}
+
+// URLInSource reports whether an http(s) URL appears in source. The scheme,
+// a leading "www.", case and a trailing slash are ignored, since transcripts
+// rarely spell URLs out in full.
+func URLInSource(rawURL, source string) bool {
+	u := strings.ToLower(strings.TrimSpace(rawURL))
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		u = strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		u = strings.TrimPrefix(u, "http://")
+	case strings.HasPrefix(u, "www."):
+	default:
+		return false
+	}
+	u = strings.TrimSuffix(strings.TrimPrefix(u, "www."), "/")
+	if u == "" {
+		return false
+	}
+	return strings.Contains(strings.ToLower(source), u)
+}
+
+// outsideCode applies fn to the parts of a Markdown document that are not
+// code.
+func outsideCode(text string, fn func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeSpans.FindAllStringIndex(text, -1) {
+		b.WriteString(fn(text[last:loc[0]]))
+		b.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(fn(text[last:]))
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package guard
+
+import "testing"
+
+func TestMarkdown(t *testing.T) {
+	source := "check the docs at go.dev/doc and the repo https://github.com/example/project"
+
+	tests := []struct {
+		name, output, want string
+		removed            int
+	}{
+		{
+			name:   "LinksFromSourceKept",
+			output: "- Read [the docs](https://go.dev/doc/) and https://github.com/example/project.",
+			want:   "- Read [the docs](https://go.dev/doc/) and https://github.com/example/project.",
+		},
+		{
+			name:    "InjectedLinkKeepsText",
+			output:  "- Buy [the course](https://evil.example/buy) today",
+			want:    "- Buy the course today",
+			removed: 1,
+		},
+		{
+			name:    "InjectedBareURL",
+			output:  "Visit https://evil.example/?ref=video, or <www.evil.example>.",
+			want:    "Visit " + LinkRemoved + ", or " + LinkRemoved + ".",
+			removed: 2,
+		},
+		{
+			name:    "InjectedImage",
+			output:  "![tracking pixel](https://evil.example/p.gif)",
+			want:    "tracking pixel",
+			removed: 1,
+		},
+		{
+			name:    "JavaScriptLink",
+			output:  "[click](javascript:alert%281%29)",
+			want:    "click",
+			removed: 1,
+		},
+		{
+			name:    "RawHTML",
+			output:  "**Key point** <img src=x onerror=alert(1)><script>steal()</script><div>text</div>",
+			want:    "**Key point** text",
+			removed: 4,
+		},
+		{
+			name:   "CodeLeftAlone",
+			output: "Use `<div>` and\n```\ncurl https://evil.example\n```\n",
+			want:   "Use `<div>` and\n```\ncurl https://evil.example\n```\n",
+		},
+		{
+			name:   "AngleBracketsInText",
+			output: "When a < b, use List<String>.",
+			want:   "When a < b, use List<String>.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, removed := Markdown(tc.output, source)
+			if got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+			if len(removed) != tc.removed {
+				t.Errorf("Expected %d removals, got %v", tc.removed, removed)
+			}
+		})
+	}
+}
+
+func TestURLInSource(t *testing.T) {
+	source := "Links: WWW.Example.com/Path and http://docs.example.org/"
+	for _, u := range []string{"https://example.com/path", "http://www.example.com/path/", "https://docs.example.org"} {
+		if !URLInSource(u, source) {
+			t.Errorf("Expected %s to be found in the source", u)
+		}
+	}
+	for _, u := range []string{"https://evil.example", "ftp://example.com/path", "javascript:alert(1)", "https://"} {
+		if URLInSource(u, source) {
+			t.Errorf("Expected %s not to be found in the source", u)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Chapter struct {
+	Title        string  `bson:"title"`
+	StartSeconds float64 `bson:"start_seconds"`
+}
+
+// VideoChapters caches the generated chapters of one video.
+type VideoChapters struct {
+	VideoID     string    `bson:"_id"`
+	Chapters    []Chapter `bson:"chapters"`
+	GeneratedAt time.Time `bson:"generated_at"`
+}
+
+// ParseChapters decodes and validates the {"chapters": [{"title", "start"}]}
+// document an LLM returns for chapter generation. Chapters are sorted by
+// start time, and untitled or duplicate-start ones are dropped.
+func ParseChapters(data []byte) ([]Chapter, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var doc struct {
+		Chapters []struct {
+			Title string `json:"title"`
+			Start string `json:"start"`
+		} `json:"chapters"`
+	}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid chapters: %w", err)
+	}
+
+	chapters := make([]Chapter, 0, len(doc.Chapters))
+	for _, c := range doc.Chapters {
+		title := strings.TrimSpace(c.Title)
+		if title == "" {
+			continue
+		}
+		seconds, err := parseTimestamp(c.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chapters: %q: %w", title, err)
+		}
+		chapters = append(chapters, Chapter{Title: title, StartSeconds: seconds})
+	}
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("invalid chapters: none returned")
+	}
+
+	sort.SliceStable(chapters, func(i, j int) bool { return chapters[i].StartSeconds < chapters[j].StartSeconds })
+	result := chapters[:1]
+	for _, c := range chapters[1:] {
+		if c.StartSeconds != result[len(result)-1].StartSeconds {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
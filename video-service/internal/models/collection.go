@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Collection is a named list of videos a user saved.
+type Collection struct {
+	ID          string `bson:"_id"`
+	UserID      string `bson:"user_id"`
+	Name        string `bson:"name"`
+	Description string `bson:"description"`
+	// Items are kept in the order they were added.
+	Items     []CollectionItem `bson:"items"`
+	CreatedAt time.Time        `bson:"created_at"`
+	UpdatedAt time.Time        `bson:"updated_at"`
+}
+
+// CollectionItem is a video saved to a collection, with its details as they
+// were when it was added.
+type CollectionItem struct {
+	VideoID      string    `bson:"video_id"`
+	Title        string    `bson:"title"`
+	ChannelID    string    `bson:"channel_id"`
+	ChannelTitle string    `bson:"channel_title"`
+	Thumbnail    string    `bson:"thumbnail"`
+	PublishedAt  string    `bson:"published_at"`
+	AddedAt      time.Time `bson:"added_at"`
+}
+
+// VideoTags are the tags a user gave a video.
+type VideoTags struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	VideoID   string    `bson:"video_id"`
+	Tags      []string  `bson:"tags"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
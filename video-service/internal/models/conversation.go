@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// MaxConversationMessages is how many of its latest messages a conversation
+// keeps; older ones are dropped as new ones are added.
+const MaxConversationMessages = 100
+
+// Citation points an answer back to the part of the transcript it is based on.
+type Citation struct {
+	StartSeconds float64 `bson:"start_seconds"`
+	EndSeconds   float64 `bson:"end_seconds"`
+	Text         string  `bson:"text"`
+}
+
+type ChatMessage struct {
+	Role      string     `bson:"role"`
+	Content   string     `bson:"content"`
+	Citations []Citation `bson:"citations,omitempty"`
+	CreatedAt time.Time  `bson:"created_at"`
+}
+
+// Conversation is the question-and-answer history of one user about one video.
+type Conversation struct {
+	ID        string        `bson:"_id,omitempty"`
+	UserID    string        `bson:"user_id"`
+	VideoID   string        `bson:"video_id"`
+	Messages  []ChatMessage `bson:"messages"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
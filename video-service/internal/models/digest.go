@@ -0,0 +1,13 @@
+package models
+
+// VideoSummary is the summary of one video, as input to a digest that covers
+// several.
+type VideoSummary struct {
+	// Number identifies the video in the digest's citations, e.g. [2].
+	Number       int
+	VideoID      string
+	Title        string
+	ChannelTitle string
+	PublishedAt  string
+	Summary      string
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// What a user did with a video, as recorded in their history.
+const (
+	HistoryViewed         = "viewed"
+	HistoryTranscriptRead = "transcript_read"
+	HistorySummarized     = "summarized"
+)
+
+// HistoryEntry is a video in a user's history. Each action's time is zero
+// if the user never did it.
+type HistoryEntry struct {
+	ID               string    `bson:"_id"`
+	UserID           string    `bson:"user_id"`
+	VideoID          string    `bson:"video_id"`
+	Title            string    `bson:"title"`
+	ChannelID        string    `bson:"channel_id"`
+	ChannelTitle     string    `bson:"channel_title"`
+	Thumbnail        string    `bson:"thumbnail"`
+	ViewedAt         time.Time `bson:"viewed_at"`
+	TranscriptReadAt time.Time `bson:"transcript_read_at"`
+	SummarizedAt     time.Time `bson:"summarized_at"`
+	LastActivityAt   time.Time `bson:"last_activity_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Job types.
+const (
+	JobTypeSummary = "summary"
+)
+
+// Job statuses. A job that failed but will be retried goes back to queued.
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+// Job is a unit of work run in the background for a user.
+type Job struct {
+	ID       string `bson:"_id"`
+	UserID   string `bson:"user_id"`
+	Type     string `bson:"type"`
+	VideoID  string `bson:"video_id"`
+	Status   string `bson:"status"`
+	Progress int    `bson:"progress"`
+	Attempts int    `bson:"attempts"`
+	Error    string `bson:"error,omitempty"`
+
+	// Set for summary jobs.
+	Summary *SummaryJob `bson:"summary,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// Finished reports whether the job has succeeded or failed for good.
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
+
+// SummaryJob is what a summary job was asked for and, once it succeeds,
+// what it produced.
+type SummaryJob struct {
+	Options    SummaryOptions `bson:"options"`
+	Structured bool           `bson:"structured"`
+
+	Result *SummaryResult `bson:"result,omitempty"`
+}
+
+// SummaryResult is a generated summary.
+type SummaryResult struct {
+	Summary     string             `bson:"summary"`
+	Structured  *StructuredSummary `bson:"structured,omitempty"`
+	GeneratedBy []ModelInfo        `bson:"generated_by"`
+}
+
+// ModelInfo names a provider and model that served LLM calls.
+type ModelInfo struct {
+	Provider string `bson:"provider"`
+	Model    string `bson:"model"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Note is a user's highlight of a stretch of a video's transcript, with
+// their own text about it. Either Quote or Text may be empty, but not both.
+type Note struct {
+	ID           string  `bson:"_id"`
+	UserID       string  `bson:"user_id"`
+	VideoID      string  `bson:"video_id"`
+	StartSeconds float64 `bson:"start_seconds"`
+	EndSeconds   float64 `bson:"end_seconds"`
+	// Quote is the highlighted transcript text.
+	Quote     string    `bson:"quote"`
+	Text      string    `bson:"text"`
+	CreatedAt time.Time `bson:"created_at"`
+}
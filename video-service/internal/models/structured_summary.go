@@ -0,0 +1,156 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EntityTypes lists the kinds of named entity a structured summary may
+// contain. Anything else is reported as "other".
+var EntityTypes = []string{"person", "organization", "product", "place", "work", "other"}
+
+// StructuredSummary is the JSON document the LLM returns for structured
+// summaries. Field names match the response schema sent to the model.
+type StructuredSummary struct {
+	Title        string            `json:"title" bson:"title"`
+	TLDR         string            `json:"tldr" bson:"tldr"`
+	Chapters     []SummaryChapter  `json:"chapters" bson:"chapters"`
+	KeyTakeaways []string          `json:"key_takeaways" bson:"key_takeaways"`
+	Entities     []SummaryEntity   `json:"entities" bson:"entities"`
+	Resources    []SummaryResource `json:"resources" bson:"resources"`
+	ActionItems  []string          `json:"action_items" bson:"action_items"`
+}
+
+type SummaryChapter struct {
+	Title   string `json:"title" bson:"title"`
+	Start   string `json:"start" bson:"start"` // m:ss or h:mm:ss, as marked in the transcript
+	Summary string `json:"summary" bson:"summary"`
+
+	// StartSeconds is Start parsed by Validate.
+	StartSeconds float64 `json:"-" bson:"start_seconds"`
+}
+
+type SummaryEntity struct {
+	Name string `json:"name" bson:"name"`
+	Type string `json:"type" bson:"type"`
+}
+
+type SummaryResource struct {
+	Title string `json:"title" bson:"title"`
+	URL   string `json:"url" bson:"url"`
+}
+
+// ParseStructuredSummary decodes and validates a structured summary produced
+// by an LLM. Fields the schema does not define are rejected.
+func ParseStructuredSummary(data []byte) (*StructuredSummary, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var summary StructuredSummary
+	if err := dec.Decode(&summary); err != nil {
+		return nil, fmt.Errorf("invalid structured summary: %w", err)
+	}
+	if err := summary.Validate(); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Validate checks the required fields and normalizes the rest: blank list
+// items are dropped, chapter start times are parsed and sorted, unknown
+// entity types become "other" and non-HTTP resource URLs are cleared.
+func (s *StructuredSummary) Validate() error {
+	s.Title = strings.TrimSpace(s.Title)
+	s.TLDR = strings.TrimSpace(s.TLDR)
+	if s.Title == "" {
+		return fmt.Errorf("invalid structured summary: title is required")
+	}
+	if s.TLDR == "" {
+		return fmt.Errorf("invalid structured summary: tldr is required")
+	}
+
+	chapters := s.Chapters[:0]
+	for _, c := range s.Chapters {
+		c.Title = strings.TrimSpace(c.Title)
+		c.Summary = strings.TrimSpace(c.Summary)
+		if c.Title == "" {
+			continue
+		}
+		seconds, err := parseTimestamp(c.Start)
+		if err != nil {
+			return fmt.Errorf("invalid structured summary: chapter %q: %w", c.Title, err)
+		}
+		c.StartSeconds = seconds
+		chapters = append(chapters, c)
+	}
+	sort.SliceStable(chapters, func(i, j int) bool { return chapters[i].StartSeconds < chapters[j].StartSeconds })
+	s.Chapters = chapters
+
+	s.KeyTakeaways = compactStrings(s.KeyTakeaways)
+	s.ActionItems = compactStrings(s.ActionItems)
+
+	entities := s.Entities[:0]
+	for _, e := range s.Entities {
+		e.Name = strings.TrimSpace(e.Name)
+		e.Type = strings.ToLower(strings.TrimSpace(e.Type))
+		if e.Name == "" {
+			continue
+		}
+		if !slices.Contains(EntityTypes, e.Type) {
+			e.Type = "other"
+		}
+		entities = append(entities, e)
+	}
+	s.Entities = entities
+
+	resources := s.Resources[:0]
+	for _, r := range s.Resources {
+		r.Title = strings.TrimSpace(r.Title)
+		r.URL = strings.TrimSpace(r.URL)
+		if r.Title == "" {
+			continue
+		}
+		if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			r.URL = ""
+		}
+		resources = append(resources, r)
+	}
+	s.Resources = resources
+
+	return nil
+}
+
+func compactStrings(items []string) []string {
+	result := items[:0]
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// parseTimestamp reads s, m:ss or h:mm:ss, optionally wrapped in brackets.
+func parseTimestamp(ts string) (float64, error) {
+	ts = strings.Trim(strings.TrimSpace(ts), "[]")
+	parts := strings.Split(ts, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", ts)
+	}
+
+	total := 0
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", ts)
+		}
+		total = total*60 + n
+	}
+	return float64(total), nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import "testing"
+
+func TestParseStructuredSummary(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		summary, err := ParseStructuredSummary([]byte(`{
+			"title": " A Talk ",
+			"tldr": "Short version.",
+			"chapters": [
+				{"title": "Later", "start": "1:02:03", "summary": "b"},
+				{"title": "Intro", "start": "[0:00]", "summary": "a"},
+				{"title": "", "start": "bogus", "summary": "dropped"}
+			],
+			"key_takeaways": ["one", "  "],
+			"entities": [{"name": "Ada Lovelace", "type": "Person"}, {"name": "Thing", "type": "gadget"}],
+			"resources": [{"title": "Docs", "url": "https://example.com/docs"}, {"title": "Book", "url": "javascript:alert(1)"}],
+			"action_items": []
+		}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if summary.Title != "A Talk" {
+			t.Errorf("Expected trimmed title, got %q", summary.Title)
+		}
+		if len(summary.Chapters) != 2 || summary.Chapters[0].Title != "Intro" || summary.Chapters[1].StartSeconds != 3723 {
+			t.Errorf("Expected 2 sorted chapters with parsed start times, got %+v", summary.Chapters)
+		}
+		if len(summary.KeyTakeaways) != 1 {
+			t.Errorf("Expected blank takeaways to be dropped, got %v", summary.KeyTakeaways)
+		}
+		if summary.Entities[0].Type != "person" || summary.Entities[1].Type != "other" {
+			t.Errorf("Expected normalized entity types, got %+v", summary.Entities)
+		}
+		if summary.Resources[0].URL == "" || summary.Resources[1].URL != "" {
+			t.Errorf("Expected only http(s) URLs to be kept, got %+v", summary.Resources)
+		}
+	})
+
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"NotJSON", `Here is your summary:`},
+		{"MissingTitle", `{"tldr": "x"}`},
+		{"MissingTLDR", `{"title": "x"}`},
+		{"UnknownField", `{"title": "x", "tldr": "y", "sentiment": "positive"}`},
+		{"BadTimestamp", `{"title": "x", "tldr": "y", "chapters": [{"title": "c", "start": "soon"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseStructuredSummary([]byte(tt.json)); err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseChapters(t *testing.T) {
+	chapters, err := ParseChapters([]byte(`{"chapters": [
+		{"title": "Second", "start": "2:00"},
+		{"title": "First", "start": "0:05"},
+		{"title": "Duplicate", "start": "2:00"},
+		{"title": " ", "start": "3:00"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(chapters) != 2 || chapters[0].Title != "First" || chapters[1].StartSeconds != 120 {
+		t.Errorf("Expected 2 sorted chapters, got %+v", chapters)
+	}
+
+	for _, bad := range []string{`{"chapters": []}`, `{"chapters": [{"title": "x", "start": "later"}]}`, `[]`} {
+		if _, err := ParseChapters([]byte(bad)); err == nil {
+			t.Errorf("Expected error for %s, got nil", bad)
+		}
+	}
+}
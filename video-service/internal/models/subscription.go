@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// What a subscription fetches ahead of time for new uploads. A summary
+// prefetch fetches the transcript too.
+const (
+	PrefetchNone       = ""
+	PrefetchTranscript = "transcript"
+	PrefetchSummary    = "summary"
+)
+
+// PrefetchLevels lists every prefetch setting, from least to most work.
+var PrefetchLevels = []string{PrefetchNone, PrefetchTranscript, PrefetchSummary}
+
+// Subscription is a user following a channel.
+type Subscription struct {
+	ID           string `bson:"_id"`
+	UserID       string `bson:"user_id"`
+	ChannelID    string `bson:"channel_id"`
+	ChannelTitle string `bson:"channel_title"`
+	Prefetch     string `bson:"prefetch"`
+	// Plan is the user's plan when they subscribed; prefetched summaries
+	// count against its quota.
+	Plan      string    `bson:"plan"`
+	CreatedAt time.Time `bson:"created_at"`
+	// Feed items added after this are new to the user.
+	LastVisitedAt time.Time `bson:"last_visited_at"`
+}
+
+// FeedItem is an upload from a followed channel in a user's feed.
+type FeedItem struct {
+	ID           string    `bson:"_id"`
+	UserID       string    `bson:"user_id"`
+	VideoID      string    `bson:"video_id"`
+	ChannelID    string    `bson:"channel_id"`
+	Title        string    `bson:"title"`
+	ChannelTitle string    `bson:"channel_title"`
+	Thumbnail    string    `bson:"thumbnail"`
+	PublishedAt  string    `bson:"published_at"`
+	AddedAt      time.Time `bson:"added_at"`
+}
+
+// PushLease is the WebSub subscription to a followed channel's upload
+// notifications, shared by all of its subscribers.
+type PushLease struct {
+	ChannelID string `bson:"_id"`
+	// RequestedAt is when the hub was last asked to (re)subscribe.
+	RequestedAt time.Time `bson:"requested_at"`
+	// ExpiresAt is when the hub stops pushing uploads unless the lease is
+	// renewed. Zero until the hub has verified the subscription.
+	ExpiresAt time.Time `bson:"expires_at"`
+	// VerifyToken is sent to the hub with the last request and must come
+	// back with its verification. Empty once the request is verified.
+	VerifyToken string `bson:"verify_token,omitempty"`
+}
+
+// FeedToken is the secret that gives a feed reader a user's summary feed
+// without their credentials.
+type FeedToken struct {
+	UserID    string    `bson:"_id"`
+	Token     string    `bson:"token"`
+	CreatedAt time.Time `bson:"created_at"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type SummaryStyle string
+
+const (
+	SummaryStyleTLDR        SummaryStyle = "tldr"
+	SummaryStyleBullets     SummaryStyle = "bullets"
+	SummaryStyleDetailed    SummaryStyle = "detailed"
+	SummaryStyleExecutive   SummaryStyle = "executive"
+	SummaryStyleStudyGuide  SummaryStyle = "study_guide"
+	SummaryStyleTweetThread SummaryStyle = "tweet_thread"
+	DefaultSummaryStyle                  = SummaryStyleDetailed
+)
+
+// SummaryStyles lists every supported style, in the order clients should
+// offer them.
+var SummaryStyles = []SummaryStyle{
+	SummaryStyleTLDR,
+	SummaryStyleBullets,
+	SummaryStyleDetailed,
+	SummaryStyleExecutive,
+	SummaryStyleStudyGuide,
+	SummaryStyleTweetThread,
+}
+
+type SummaryLength string
+
+const (
+	SummaryLengthShort   SummaryLength = "short"
+	SummaryLengthMedium  SummaryLength = "medium"
+	SummaryLengthLong    SummaryLength = "long"
+	DefaultSummaryLength               = SummaryLengthMedium
+)
+
+var SummaryLengths = []SummaryLength{
+	SummaryLengthShort,
+	SummaryLengthMedium,
+	SummaryLengthLong,
+}
+
+const maxSummaryLanguageLength = 40
+
+// SummaryOptions controls the shape of a generated summary. An empty Language
+// means the summary is written in the language of the transcript.
+type SummaryOptions struct {
+	Style    SummaryStyle  `bson:"style"`
+	Length   SummaryLength `bson:"length"`
+	Language string        `bson:"language"`
+}
+
+// ParseSummaryOptions validates user-supplied options, filling in defaults for
+// empty values.
+func ParseSummaryOptions(style, length, language string) (SummaryOptions, error) {
+	opts := SummaryOptions{
+		Style:    DefaultSummaryStyle,
+		Length:   DefaultSummaryLength,
+		Language: strings.TrimSpace(language),
+	}
+
+	if style = strings.ToLower(strings.TrimSpace(style)); style != "" {
+		opts.Style = SummaryStyle(style)
+		if !slices.Contains(SummaryStyles, opts.Style) {
+			return SummaryOptions{}, fmt.Errorf("unknown summary style %q", style)
+		}
+	}
+
+	if length = strings.ToLower(strings.TrimSpace(length)); length != "" {
+		opts.Length = SummaryLength(length)
+		if !slices.Contains(SummaryLengths, opts.Length) {
+			return SummaryOptions{}, fmt.Errorf("unknown summary length %q", length)
+		}
+	}
+
+	if len(opts.Language) > maxSummaryLanguageLength || strings.ContainsAny(opts.Language, "\r\n") {
+		return SummaryOptions{}, fmt.Errorf("invalid summary language %q", opts.Language)
+	}
+
+	return opts, nil
+}
+
+// CachedSummary is a Markdown summary of a video kept for reuse, such as in
+// digests. A video can have one per set of options.
+type CachedSummary struct {
+	ID          string         `bson:"_id"`
+	VideoID     string         `bson:"video_id"`
+	Options     SummaryOptions `bson:"options"`
+	Summary     string         `bson:"summary"`
+	GeneratedAt time.Time      `bson:"generated_at"`
+}
+
+// SummaryCacheKey identifies the summary of a video written with opts.
+func SummaryCacheKey(videoID string, opts SummaryOptions) string {
+	return strings.Join([]string{videoID, string(opts.Style), string(opts.Length), strings.ToLower(opts.Language)}, ":")
+}
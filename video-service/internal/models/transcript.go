@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// TranscriptSegment is a single timed caption line as returned by the
+// transcript service.
+type TranscriptSegment struct {
+	Text     string  `json:"text"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+}
+
+// TranscriptChunk is an overlapping window of a transcript, stored together
+// with its embedding for semantic search.
+type TranscriptChunk struct {
+	ID           string    `bson:"_id,omitempty"`
+	VideoID      string    `bson:"video_id"`
+	Index        int       `bson:"chunk_index"`
+	Text         string    `bson:"text"`
+	StartSeconds float64   `bson:"start_seconds"`
+	EndSeconds   float64   `bson:"end_seconds"`
+	Embedding    []float32 `bson:"embedding"`
+	IndexedAt    time.Time `bson:"indexed_at"`
+}
+
+// ScoredChunk is a transcript chunk ranked against a search query.
+type ScoredChunk struct {
+	TranscriptChunk
+	Score float64
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Operations that LLM usage is recorded under.
+const (
+	OperationSummarize = "summarize"
+	OperationChapters  = "chapters"
+	OperationAsk       = "ask"
+	OperationCompare   = "compare"
+	OperationDigest    = "channel_digest"
+)
+
+// UsageRecord is the tokens one provider and model consumed, and their
+// estimated cost, while serving one request for a user.
+type UsageRecord struct {
+	ID               string    `bson:"_id,omitempty"`
+	UserID           string    `bson:"user_id"`
+	VideoID          string    `bson:"video_id"`
+	Operation        string    `bson:"operation"`
+	Provider         string    `bson:"provider"`
+	Model            string    `bson:"model"`
+	Calls            int       `bson:"calls"`
+	PromptTokens     int       `bson:"prompt_tokens"`
+	CompletionTokens int       `bson:"completion_tokens"`
+	CostUSD          float64   `bson:"cost_usd"`
+	CreatedAt        time.Time `bson:"created_at"`
+}
+
+// ModelUsage totals a user's usage of one provider and model.
+type ModelUsage struct {
+	Provider         string  `bson:"provider"`
+	Model            string  `bson:"model"`
+	Calls            int64   `bson:"calls"`
+	PromptTokens     int64   `bson:"prompt_tokens"`
+	CompletionTokens int64   `bson:"completion_tokens"`
+	CostUSD          float64 `bson:"cost_usd"`
+}
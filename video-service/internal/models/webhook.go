@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Webhook events.
+const (
+	EventSummaryCompleted = "summary.completed"
+	EventJobFailed        = "job.failed"
+	EventChannelVideo     = "channel.video_published"
+)
+
+// WebhookEvents lists every event a webhook can subscribe to.
+var WebhookEvents = []string{EventSummaryCompleted, EventJobFailed, EventChannelVideo}
+
+// Webhook delivery statuses.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Webhook is an endpoint a user registered to be notified of events.
+type Webhook struct {
+	ID     string `bson:"_id"`
+	UserID string `bson:"user_id"`
+	URL    string `bson:"url"`
+	// Empty subscribes to every event.
+	Events    []string  `bson:"events"`
+	Secret    string    `bson:"secret"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// Subscribes reports whether the webhook wants an event.
+func (w *Webhook) Subscribes(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event posted, or to be posted, to a webhook.
+type WebhookDelivery struct {
+	ID        string `bson:"_id"`
+	WebhookID string `bson:"webhook_id"`
+	UserID    string `bson:"user_id"`
+	Event     string `bson:"event"`
+	// The JSON body, kept as sent so it can be replayed.
+	Payload       string    `bson:"payload"`
+	Status        string    `bson:"status"`
+	Attempts      int       `bson:"attempts"`
+	ResponseCode  int       `bson:"response_code,omitempty"`
+	Error         string    `bson:"error,omitempty"`
+	CreatedAt     time.Time `bson:"created_at"`
+	NextAttemptAt time.Time `bson:"next_attempt_at"`
+}
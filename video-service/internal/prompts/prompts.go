@@ -0,0 +1,149 @@
+// Package prompts renders the LLM prompts used by the video service from
+// versioned templates. Changing a prompt means adding a new template version
+// rather than editing Go code, so summaries can be traced to the prompt that
+// produced them.
+//
+// Text taken from a video, such as a transcript, is untrusted: the templates
+// fence it in tags, pass it through the "untrusted" function to escape it,
+// and warn the model when guard.Detect finds instructions in it.
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/guard"
+	"videoservice/internal/models"
+)
+
+// Version identifies the template set in use.
+const Version = "v2"
+
+// Prompt is a rendered prompt. System holds the instructions, sent with the
+// model's system role where the provider has one; User holds the content
+// they apply to, such as a transcript. Keeping the two apart stops text in
+// a transcript from passing itself off as instructions.
+type Prompt struct {
+	System string
+	User   string
+	// Injections lists what in the untrusted text looked like instructions
+	// to the model, for the caller to log.
+	Injections []guard.Finding
+}
+
+//go:embed templates/*/*.tmpl
+var templateFS embed.FS
+
+var funcs = template.FuncMap{
+	"inc":       func(i int) int { return i + 1 },
+	"timestamp": helpers.FormatTimestamp,
+	"untrusted": guard.Escape,
+	"upper":     strings.ToUpper,
+}
+
+var templates = template.Must(template.New("prompts").Funcs(funcs).ParseFS(templateFS, "templates/"+Version+"/*.tmpl"))
+
+// Summary renders the prompt that summarizes a whole transcript.
+func Summary(transcript string, opts models.SummaryOptions) (Prompt, error) {
+	return render("summary", map[string]interface{}{
+		"Transcript": transcript,
+		"Options":    opts,
+		"Injections": guard.Detect(transcript),
+	})
+}
+
+// StructuredSummary renders the prompt that summarizes a timestamped
+// transcript into the fields of models.StructuredSummary.
+func StructuredSummary(transcript string, opts models.SummaryOptions) (Prompt, error) {
+	return render("structured", map[string]interface{}{
+		"Transcript": transcript,
+		"Options":    opts,
+		"Injections": guard.Detect(transcript),
+	})
+}
+
+// Chapters renders the prompt that splits a timestamped transcript into
+// titled chapters.
+func Chapters(transcript string) (Prompt, error) {
+	return render("chapters", map[string]interface{}{
+		"Transcript": transcript,
+		"Injections": guard.Detect(transcript),
+	})
+}
+
+// CombineSummaries renders the prompt that merges summaries of consecutive
+// parts of one transcript into a single summary.
+func CombineSummaries(partials []string, opts models.SummaryOptions) (Prompt, error) {
+	return render("combine", map[string]interface{}{
+		"Partials":   partials,
+		"Options":    opts,
+		"Injections": guard.Detect(strings.Join(partials, "\n\n")),
+	})
+}
+
+// Answer renders the prompt that answers a question from timestamped
+// transcript passages, given the user's notes on the video and the
+// conversation so far.
+func Answer(question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (Prompt, error) {
+	var excerpts []string
+	for _, p := range passages {
+		excerpts = append(excerpts, p.Text)
+	}
+	for _, n := range notes {
+		excerpts = append(excerpts, n.Quote)
+	}
+	return render("answer", map[string]interface{}{
+		"Question":   question,
+		"Passages":   passages,
+		"Notes":      notes,
+		"History":    history,
+		"Injections": guard.Detect(strings.Join(excerpts, "\n\n")),
+	})
+}
+
+// Compare renders the prompt that compares summaries of several videos,
+// optionally focused on a question, citing each video by its number.
+func Compare(videos []models.VideoSummary, focus string, opts models.SummaryOptions) (Prompt, error) {
+	var untrusted []string
+	for _, v := range videos {
+		untrusted = append(untrusted, v.Title, v.Summary)
+	}
+	return render("compare", map[string]interface{}{
+		"Videos":     videos,
+		"Focus":      focus,
+		"Options":    opts,
+		"Injections": guard.Detect(strings.Join(untrusted, "\n\n")),
+	})
+}
+
+// ChannelDigest renders the prompt that writes a newsletter-style digest of
+// a channel's recent uploads from their summaries.
+func ChannelDigest(channel string, videos []models.VideoSummary, opts models.SummaryOptions) (Prompt, error) {
+	untrusted := []string{channel}
+	for _, v := range videos {
+		untrusted = append(untrusted, v.Title, v.Summary)
+	}
+	return render("channel_digest", map[string]interface{}{
+		"Channel":    channel,
+		"Videos":     videos,
+		"Options":    opts,
+		"Injections": guard.Detect(strings.Join(untrusted, "\n\n")),
+	})
+}
+
+// render executes the "<name>.system" and "<name>.user" templates.
+func render(name string, data map[string]interface{}) (Prompt, error) {
+	var system, user bytes.Buffer
+	if err := templates.ExecuteTemplate(&system, name+".system", data); err != nil {
+		return Prompt{}, fmt.Errorf("failed to render %s prompt %s: %w", Version, name, err)
+	}
+	if err := templates.ExecuteTemplate(&user, name+".user", data); err != nil {
+		return Prompt{}, fmt.Errorf("failed to render %s prompt %s: %w", Version, name, err)
+	}
+	injections, _ := data["Injections"].([]guard.Finding)
+	return Prompt{System: system.String(), User: user.String(), Injections: injections}, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChapterRepository struct {
+	collection *mongo.Collection
+}
+
+func NewChapterRepository(db *mongo.Database) *ChapterRepository {
+	return &ChapterRepository{
+		collection: db.Collection("chapters"),
+	}
+}
+
+// Get returns the cached chapters of a video, or nil if none were generated.
+func (r *ChapterRepository) Get(ctx context.Context, videoID string) (*models.VideoChapters, error) {
+	var chapters models.VideoChapters
+	err := r.collection.FindOne(ctx, bson.M{"_id": videoID}).Decode(&chapters)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &chapters, nil
+}
+
+func (r *ChapterRepository) Save(ctx context.Context, chapters *models.VideoChapters) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": chapters.VideoID}, chapters, opts)
+	return err
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CollectionRepository struct {
+	collections *mongo.Collection
+	tags        *mongo.Collection
+}
+
+func NewCollectionRepository(db *mongo.Database) *CollectionRepository {
+	return &CollectionRepository{
+		collections: db.Collection("collections"),
+		tags:        db.Collection("video_tags"),
+	}
+}
+
+func (r *CollectionRepository) SaveCollection(ctx context.Context, collection *models.Collection) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collections.ReplaceOne(ctx, bson.M{"_id": collection.ID}, collection, opts)
+	return err
+}
+
+// GetCollection returns a collection, or nil if there is none with that ID.
+func (r *CollectionRepository) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	var collection models.Collection
+	err := r.collections.FindOne(ctx, bson.M{"_id": id}).Decode(&collection)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// ListCollections returns a user's collections, most recently updated
+// first.
+func (r *CollectionRepository) ListCollections(ctx context.Context, userID string) ([]models.Collection, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := r.collections.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var collections []models.Collection
+	if err := cursor.All(ctx, &collections); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// DeleteCollection deletes one of a user's collections, reporting whether it
+// existed. The tags of its videos are kept.
+func (r *CollectionRepository) DeleteCollection(ctx context.Context, userID, id string) (bool, error) {
+	result, err := r.collections.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// SaveVideoTags replaces the tags a user gave a video, removing the video's
+// entry when it has no tags left.
+func (r *CollectionRepository) SaveVideoTags(ctx context.Context, tags *models.VideoTags) error {
+	tags.ID = tags.UserID + ":" + tags.VideoID
+	if len(tags.Tags) == 0 {
+		_, err := r.tags.DeleteOne(ctx, bson.M{"_id": tags.ID})
+		return err
+	}
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.tags.ReplaceOne(ctx, bson.M{"_id": tags.ID}, tags, opts)
+	return err
+}
+
+// ListVideoTags returns every video a user tagged, most recently tagged
+// first.
+func (r *CollectionRepository) ListVideoTags(ctx context.Context, userID string) ([]models.VideoTags, error) {
+	return r.findTags(ctx, bson.M{"user_id": userID})
+}
+
+// FindVideoTags returns the tags a user gave the given videos.
+func (r *CollectionRepository) FindVideoTags(ctx context.Context, userID string, videoIDs []string) ([]models.VideoTags, error) {
+	return r.findTags(ctx, bson.M{"user_id": userID, "video_id": bson.M{"$in": videoIDs}})
+}
+
+func (r *CollectionRepository) findTags(ctx context.Context, filter bson.M) ([]models.VideoTags, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := r.tags.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []models.VideoTags
+	if err := cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
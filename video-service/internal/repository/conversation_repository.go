@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ConversationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewConversationRepository(db *mongo.Database) *ConversationRepository {
+	return &ConversationRepository{
+		collection: db.Collection("conversations"),
+	}
+}
+
+// Get returns the conversation of a user about a video, or nil if they have
+// not asked anything yet.
+func (r *ConversationRepository) Get(ctx context.Context, userID, videoID string) (*models.Conversation, error) {
+	var conversation models.Conversation
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID, "video_id": videoID}).Decode(&conversation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// Append adds messages to a conversation, keeping only the latest
+// models.MaxConversationMessages.
+func (r *ConversationRepository) Append(ctx context.Context, userID, videoID string, messages ...models.ChatMessage) error {
+	filter := bson.M{"user_id": userID, "video_id": videoID}
+	update := bson.M{
+		"$push": bson.M{"messages": bson.M{"$each": messages, "$slice": -models.MaxConversationMessages}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	return err
+}
+
+func (r *ConversationRepository) Delete(ctx context.Context, userID, videoID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "video_id": videoID})
+	return err
+}
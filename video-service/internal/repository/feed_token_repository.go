@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFeedTokenRepository(db *mongo.Database) *FeedTokenRepository {
+	return &FeedTokenRepository{
+		collection: db.Collection("feed_tokens"),
+	}
+}
+
+// SaveFeedToken sets a user's feed token, replacing any previous one.
+func (r *FeedTokenRepository) SaveFeedToken(ctx context.Context, token *models.FeedToken) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": token.UserID}, token, opts)
+	return err
+}
+
+// GetFeedToken returns a user's feed token, or nil if they have none.
+func (r *FeedTokenRepository) GetFeedToken(ctx context.Context, userID string) (*models.FeedToken, error) {
+	return r.findOne(ctx, bson.M{"_id": userID})
+}
+
+// FindFeedToken returns the feed token with the given secret, or nil if
+// there is none.
+func (r *FeedTokenRepository) FindFeedToken(ctx context.Context, token string) (*models.FeedToken, error) {
+	return r.findOne(ctx, bson.M{"token": token})
+}
+
+func (r *FeedTokenRepository) findOne(ctx context.Context, filter bson.M) (*models.FeedToken, error) {
+	var token models.FeedToken
+	err := r.collection.FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type HistoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewHistoryRepository(db *mongo.Database) *HistoryRepository {
+	return &HistoryRepository{
+		collection: db.Collection("history"),
+	}
+}
+
+// Record notes that a user did action on a video at the given time. The
+// video's details are updated only when entry has them.
+func (r *HistoryRepository) Record(ctx context.Context, entry *models.HistoryEntry, action string, at time.Time) error {
+	set := bson.M{
+		"user_id":          entry.UserID,
+		"video_id":         entry.VideoID,
+		action + "_at":     at,
+		"last_activity_at": at,
+	}
+	if entry.Title != "" {
+		set["title"] = entry.Title
+		set["channel_id"] = entry.ChannelID
+		set["channel_title"] = entry.ChannelTitle
+		set["thumbnail"] = entry.Thumbnail
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": entry.UserID + ":" + entry.VideoID}, bson.M{"$set": set}, opts)
+	return err
+}
+
+// ListHistory returns up to limit of a user's history entries, most recent
+// activity first.
+func (r *HistoryRepository) ListHistory(ctx context.Context, userID string, limit int) ([]models.HistoryEntry, error) {
+	opts := options.Find().SetSort(bson.M{"last_activity_at": -1}).SetLimit(int64(limit))
+	return r.find(ctx, bson.M{"user_id": userID}, opts)
+}
+
+// FindHistory returns a user's history entries for the given videos.
+func (r *HistoryRepository) FindHistory(ctx context.Context, userID string, videoIDs []string) ([]models.HistoryEntry, error) {
+	if len(videoIDs) == 0 {
+		return nil, nil
+	}
+	return r.find(ctx, bson.M{"user_id": userID, "video_id": bson.M{"$in": videoIDs}}, options.Find())
+}
+
+func (r *HistoryRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.HistoryEntry, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.HistoryEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobRepository(db *mongo.Database) *JobRepository {
+	return &JobRepository{
+		collection: db.Collection("jobs"),
+	}
+}
+
+// Get returns a job, or nil if there is none with that ID.
+func (r *JobRepository) Get(ctx context.Context, id string) (*models.Job, error) {
+	var job models.Job
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *JobRepository) Save(ctx context.Context, job *models.Job) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": job.ID}, job, opts)
+	return err
+}
+
+// List returns a user's most recent jobs, optionally only those in status.
+func (r *JobRepository) List(ctx context.Context, userID, status string, limit int) ([]models.Job, error) {
+	filter := bson.M{"user_id": userID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+	return r.find(ctx, filter, opts)
+}
+
+// Unfinished returns every queued or running job, oldest first.
+func (r *JobRepository) Unfinished(ctx context.Context) ([]models.Job, error) {
+	filter := bson.M{"status": bson.M{"$in": bson.A{models.JobStatusQueued, models.JobStatusRunning}}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	return r.find(ctx, filter, opts)
+}
+
+func (r *JobRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.Job, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
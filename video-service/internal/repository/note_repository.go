@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NoteRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNoteRepository(db *mongo.Database) *NoteRepository {
+	return &NoteRepository{collection: db.Collection("notes")}
+}
+
+func (r *NoteRepository) SaveNote(ctx context.Context, note *models.Note) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": note.ID}, note, opts)
+	return err
+}
+
+// ListNotes returns a user's notes, most recent first, or only those on
+// one video in transcript order when videoID is set.
+func (r *NoteRepository) ListNotes(ctx context.Context, userID, videoID string) ([]models.Note, error) {
+	filter := bson.M{"user_id": userID}
+	sort := bson.D{{Key: "created_at", Value: -1}}
+	if videoID != "" {
+		filter["video_id"] = videoID
+		sort = bson.D{{Key: "start_seconds", Value: 1}, {Key: "created_at", Value: 1}}
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notes []models.Note
+	if err := cursor.All(ctx, &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// FindNotes returns a user's notes on the given videos.
+func (r *NoteRepository) FindNotes(ctx context.Context, userID string, videoIDs []string) ([]models.Note, error) {
+	filter := bson.M{"user_id": userID, "video_id": bson.M{"$in": videoIDs}}
+	opts := options.Find().SetSort(bson.D{{Key: "start_seconds", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notes []models.Note
+	if err := cursor.All(ctx, &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// DeleteNote deletes one of a user's notes, reporting whether it existed.
+func (r *NoteRepository) DeleteNote(ctx context.Context, userID, id string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
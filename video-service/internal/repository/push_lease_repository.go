@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PushLeaseRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPushLeaseRepository(db *mongo.Database) *PushLeaseRepository {
+	return &PushLeaseRepository{
+		collection: db.Collection("push_leases"),
+	}
+}
+
+// GetLease returns the lease on a channel's notifications, or nil if none
+// has been requested.
+func (r *PushLeaseRepository) GetLease(ctx context.Context, channelID string) (*models.PushLease, error) {
+	var lease models.PushLease
+	err := r.collection.FindOne(ctx, bson.M{"_id": channelID}).Decode(&lease)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &lease, nil
+}
+
+func (r *PushLeaseRepository) ListLeases(ctx context.Context) ([]models.PushLease, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var leases []models.PushLease
+	if err := cursor.All(ctx, &leases); err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+func (r *PushLeaseRepository) SaveLease(ctx context.Context, lease *models.PushLease) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": lease.ChannelID}, lease, opts)
+	return err
+}
+
+func (r *PushLeaseRepository) DeleteLease(ctx context.Context, channelID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": channelID})
+	return err
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type QuotaRepository struct {
+	collection *mongo.Collection
+}
+
+func NewQuotaRepository(db *mongo.Database) *QuotaRepository {
+	return &QuotaRepository{
+		collection: db.Collection("quota_usage"),
+	}
+}
+
+// Add atomically adds delta to a user's request count for one quota kind and
+// period, creating the counter on first use, and returns the new count.
+func (r *QuotaRepository) Add(ctx context.Context, userID, kind, period string, delta int64) (int64, error) {
+	filter := bson.M{"_id": userID + ":" + kind + ":" + period}
+	update := bson.M{
+		"$inc": bson.M{"count": delta},
+		"$set": bson.M{"updated_at": time.Now()},
+		"$setOnInsert": bson.M{
+			"user_id": userID,
+			"kind":    kind,
+			"period":  period,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var doc struct {
+		Count int64 `bson:"count"`
+	}
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc); err != nil {
+		return 0, err
+	}
+	return doc.Count, nil
+}
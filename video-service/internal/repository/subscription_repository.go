@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SubscriptionRepository struct {
+	subscriptions *mongo.Collection
+	feed          *mongo.Collection
+}
+
+func NewSubscriptionRepository(db *mongo.Database) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		subscriptions: db.Collection("subscriptions"),
+		feed:          db.Collection("feed_items"),
+	}
+}
+
+func subscriptionID(userID, channelID string) string {
+	return userID + ":" + channelID
+}
+
+func (r *SubscriptionRepository) SaveSubscription(ctx context.Context, sub *models.Subscription) error {
+	sub.ID = subscriptionID(sub.UserID, sub.ChannelID)
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.subscriptions.ReplaceOne(ctx, bson.M{"_id": sub.ID}, sub, opts)
+	return err
+}
+
+// GetSubscription returns a user's subscription to a channel, or nil if they
+// do not follow it.
+func (r *SubscriptionRepository) GetSubscription(ctx context.Context, userID, channelID string) (*models.Subscription, error) {
+	var sub models.Subscription
+	err := r.subscriptions.FindOne(ctx, bson.M{"_id": subscriptionID(userID, channelID)}).Decode(&sub)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ListSubscriptions returns a user's subscriptions, oldest first.
+func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context, userID string) ([]models.Subscription, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+// DeleteSubscription unsubscribes a user from a channel, reporting whether
+// they followed it. Their feed keeps the channel's uploads.
+func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, userID, channelID string) (bool, error) {
+	result, err := r.subscriptions.DeleteOne(ctx, bson.M{"_id": subscriptionID(userID, channelID)})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// SubscribedChannels returns the IDs of the channels anyone follows.
+func (r *SubscriptionRepository) SubscribedChannels(ctx context.Context) ([]string, error) {
+	values, err := r.subscriptions.Distinct(ctx, "channel_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	channels := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			channels = append(channels, id)
+		}
+	}
+	return channels, nil
+}
+
+// ChannelSubscribers returns every subscription to a channel.
+func (r *SubscriptionRepository) ChannelSubscribers(ctx context.Context, channelID string) ([]models.Subscription, error) {
+	return r.find(ctx, bson.M{"channel_id": channelID})
+}
+
+// MarkVisited records a visit by the user at the given time on all of their
+// subscriptions.
+func (r *SubscriptionRepository) MarkVisited(ctx context.Context, userID string, at time.Time) error {
+	_, err := r.subscriptions.UpdateMany(ctx, bson.M{"user_id": userID}, bson.M{"$set": bson.M{"last_visited_at": at}})
+	return err
+}
+
+func (r *SubscriptionRepository) find(ctx context.Context, filter bson.M) ([]models.Subscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.subscriptions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subs []models.Subscription
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// AddFeedItem adds an upload to a user's feed unless it is already there,
+// reporting whether it was added.
+func (r *SubscriptionRepository) AddFeedItem(ctx context.Context, item *models.FeedItem) (bool, error) {
+	item.ID = item.UserID + ":" + item.VideoID
+	opts := options.Update().SetUpsert(true)
+	result, err := r.feed.UpdateOne(ctx, bson.M{"_id": item.ID}, bson.M{"$setOnInsert": item}, opts)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// ListFeed returns up to limit of the items added to a user's feed after
+// since, most recently added first.
+func (r *SubscriptionRepository) ListFeed(ctx context.Context, userID string, since time.Time, limit int) ([]models.FeedItem, error) {
+	filter := bson.M{"user_id": userID, "added_at": bson.M{"$gt": since}}
+	opts := options.Find().SetSort(bson.D{{Key: "added_at", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.feed.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []models.FeedItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
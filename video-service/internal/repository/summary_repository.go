@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SummaryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSummaryRepository(db *mongo.Database) *SummaryRepository {
+	return &SummaryRepository{
+		collection: db.Collection("summaries"),
+	}
+}
+
+// Get returns the cached summary of a video written with opts, or nil if
+// there is none.
+func (r *SummaryRepository) Get(ctx context.Context, videoID string, opts models.SummaryOptions) (*models.CachedSummary, error) {
+	var summary models.CachedSummary
+	err := r.collection.FindOne(ctx, bson.M{"_id": models.SummaryCacheKey(videoID, opts)}).Decode(&summary)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func (r *SummaryRepository) Save(ctx context.Context, summary *models.CachedSummary) error {
+	summary.ID = models.SummaryCacheKey(summary.VideoID, summary.Options)
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": summary.ID}, summary, opts)
+	return err
+}
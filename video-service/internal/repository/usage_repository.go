@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UsageRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUsageRepository(db *mongo.Database) *UsageRepository {
+	return &UsageRepository{
+		collection: db.Collection("llm_usage"),
+	}
+}
+
+func (r *UsageRepository) Record(ctx context.Context, records ...models.UsageRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(records))
+	for i, rec := range records {
+		docs[i] = rec
+	}
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+// Totals returns a user's usage since the given time per provider and model,
+// most expensive first.
+func (r *UsageRepository) Totals(ctx context.Context, userID string, since time.Time) ([]models.ModelUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               bson.M{"provider": "$provider", "model": "$model"},
+			"calls":             bson.M{"$sum": "$calls"},
+			"prompt_tokens":     bson.M{"$sum": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$completion_tokens"},
+			"cost_usd":          bson.M{"$sum": "$cost_usd"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":               0,
+			"provider":          "$_id.provider",
+			"model":             "$_id.model",
+			"calls":             1,
+			"prompt_tokens":     1,
+			"completion_tokens": 1,
+			"cost_usd":          1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "cost_usd", Value: -1}, {Key: "prompt_tokens", Value: -1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []models.ModelUsage
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
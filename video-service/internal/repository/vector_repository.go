@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VectorRepository stores transcript chunk embeddings in MongoDB and ranks
+// them by brute-force cosine similarity, so semantic search works against a
+// plain local Mongo without a vector index.
+type VectorRepository struct {
+	chunkCollection *mongo.Collection
+}
+
+func NewVectorRepository(db *mongo.Database) *VectorRepository {
+	return &VectorRepository{
+		chunkCollection: db.Collection("transcript_chunks"),
+	}
+}
+
+// UpsertChunks replaces every stored chunk of a video with the given ones.
+// Chunks are keyed by video and position, so indexing the same video twice
+// at once overwrites rather than duplicates them.
+func (r *VectorRepository) UpsertChunks(ctx context.Context, videoID string, chunks []models.TranscriptChunk) error {
+	if len(chunks) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(chunks))
+		now := time.Now()
+		for i, chunk := range chunks {
+			chunk.ID = chunkID(videoID, i)
+			chunk.VideoID = videoID
+			chunk.Index = i
+			chunk.IndexedAt = now
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": chunk.ID}).
+				SetReplacement(chunk).
+				SetUpsert(true))
+		}
+		if _, err := r.chunkCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	// Drop chunks left over from a longer earlier indexing.
+	_, err := r.chunkCollection.DeleteMany(ctx, bson.M{
+		"video_id":    videoID,
+		"chunk_index": bson.M{"$gte": len(chunks)},
+	})
+	return err
+}
+
+// chunkID is the _id of a video's chunk at index.
+func chunkID(videoID string, index int) string {
+	return fmt.Sprintf("%s:%d", videoID, index)
+}
+
+func (r *VectorRepository) HasVideo(ctx context.Context, videoID string) (bool, error) {
+	count, err := r.chunkCollection.CountDocuments(ctx, bson.M{"video_id": videoID})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Search returns the topK chunks most similar to query, optionally limited to
+// the given videos.
+func (r *VectorRepository) Search(ctx context.Context, query []float32, topK int, videoIDs []string) ([]models.ScoredChunk, error) {
+	filter := bson.M{}
+	if len(videoIDs) > 0 {
+		filter["video_id"] = bson.M{"$in": videoIDs}
+	}
+
+	cursor, err := r.chunkCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var top []models.ScoredChunk
+	for cursor.Next(ctx) {
+		var chunk models.TranscriptChunk
+		if err := cursor.Decode(&chunk); err != nil {
+			return nil, err
+		}
+		top = insertTopK(top, models.ScoredChunk{
+			TranscriptChunk: chunk,
+			Score:           CosineSimilarity(query, chunk.Embedding),
+		}, topK)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range top {
+		top[i].Embedding = nil
+	}
+	return top, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, or 0 when
+// the vectors differ in length or either has zero magnitude.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// insertTopK keeps top sorted by descending score and no longer than k.
+func insertTopK(top []models.ScoredChunk, c models.ScoredChunk, k int) []models.ScoredChunk {
+	if k <= 0 {
+		return top
+	}
+	if len(top) == k && c.Score <= top[k-1].Score {
+		return top
+	}
+
+	pos := len(top)
+	for pos > 0 && top[pos-1].Score < c.Score {
+		pos--
+	}
+	if len(top) < k {
+		top = append(top, models.ScoredChunk{})
+	}
+	copy(top[pos+1:], top[pos:len(top)-1])
+	top[pos] = c
+	return top
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"math"
+	"testing"
+
+	"videoservice/internal/models"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{"Identical", []float32{1, 2, 3}, []float32{1, 2, 3}, 1},
+		{"Orthogonal", []float32{1, 0}, []float32{0, 1}, 0},
+		{"Opposite", []float32{1, 1}, []float32{-1, -1}, -1},
+		{"LengthMismatch", []float32{1, 2}, []float32{1, 2, 3}, 0},
+		{"ZeroVector", []float32{0, 0}, []float32{1, 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInsertTopK(t *testing.T) {
+	var top []models.ScoredChunk
+	for i, score := range []float64{0.2, 0.9, 0.5, 0.1, 0.7} {
+		top = insertTopK(top, models.ScoredChunk{
+			TranscriptChunk: models.TranscriptChunk{Index: i},
+			Score:           score,
+		}, 3)
+	}
+
+	want := []float64{0.9, 0.7, 0.5}
+	if len(top) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(top))
+	}
+	for i, w := range want {
+		if top[i].Score != w {
+			t.Errorf("Position %d: expected score %v, got %v", i, w, top[i].Score)
+		}
+	}
+}
+
+func TestChunkID(t *testing.T) {
+	if got := chunkID("dQw4w9WgXcQ", 3); got != "dQw4w9WgXcQ:3" {
+		t.Errorf("Expected dQw4w9WgXcQ:3, got %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"videoservice/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+func NewWebhookRepository(db *mongo.Database) *WebhookRepository {
+	return &WebhookRepository{
+		webhooks:   db.Collection("webhooks"),
+		deliveries: db.Collection("webhook_deliveries"),
+	}
+}
+
+func (r *WebhookRepository) SaveWebhook(ctx context.Context, hook *models.Webhook) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.webhooks.ReplaceOne(ctx, bson.M{"_id": hook.ID}, hook, opts)
+	return err
+}
+
+// GetWebhook returns a webhook, or nil if there is none with that ID.
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	var hook models.Webhook
+	err := r.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&hook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// ListWebhooks returns a user's webhooks, oldest first.
+func (r *WebhookRepository) ListWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.webhooks.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var hooks []models.Webhook
+	if err := cursor.All(ctx, &hooks); err != nil {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// DeleteWebhook deletes one of a user's webhooks, reporting whether it
+// existed. Its deliveries are kept for the delivery log.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, userID, id string) (bool, error) {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.deliveries.ReplaceOne(ctx, bson.M{"_id": delivery.ID}, delivery, opts)
+	return err
+}
+
+// GetDelivery returns a delivery, or nil if there is none with that ID.
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.deliveries.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries returns a user's most recent deliveries, optionally only
+// those to one webhook.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	filter := bson.M{"user_id": userID}
+	if webhookID != "" {
+		filter["webhook_id"] = webhookID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+	return r.findDeliveries(ctx, filter, opts)
+}
+
+// DueDeliveries returns pending deliveries due by now, oldest first.
+func (r *WebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          models.DeliveryStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).SetLimit(int64(limit))
+	return r.findDeliveries(ctx, filter, opts)
+}
+
+func (r *WebhookRepository) findDeliveries(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]models.WebhookDelivery, error) {
+	cursor, err := r.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []models.WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
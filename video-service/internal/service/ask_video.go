@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	askPassages        = 6
+	maxHistoryMessages = 10
+)
+
+var citationPattern = regexp.MustCompile(`\[((?:\d+:)?\d{1,2}:\d{2})\]`)
+
+// ConversationStore keeps the per-user, per-video question history.
+type ConversationStore interface {
+	Get(ctx context.Context, userID, videoID string) (*models.Conversation, error)
+	Append(ctx context.Context, userID, videoID string, messages ...models.ChatMessage) error
+	Delete(ctx context.Context, userID, videoID string) error
+}
+
+func (s *VideoService) AskVideo(ctx context.Context, req *pb.AskVideoRequest) (*pb.AskVideoResponse, error) {
+	log.Printf("Answering question about video %s for user %s", req.VideoId, req.UserId)
+	if s.embedder == nil || s.vectorStore == nil || s.conversations == nil {
+		return nil, fmt.Errorf("question answering is not configured")
+	}
+
+	question := strings.TrimSpace(req.Question)
+	if question == "" {
+		return nil, fmt.Errorf("question is required")
+	}
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user id is required")
+	}
+
+	if req.ResetHistory {
+		if err := s.conversations.Delete(ctx, req.UserId, req.VideoId); err != nil {
+			log.Printf("Error resetting conversation for video %s: %v", req.VideoId, err)
+			return nil, fmt.Errorf("failed to reset conversation: %w", err)
+		}
+	}
+
+	var history []models.ChatMessage
+	conversation, err := s.conversations.Get(ctx, req.UserId, req.VideoId)
+	if err != nil {
+		log.Printf("Error loading conversation for video %s: %v", req.VideoId, err)
+		return nil, fmt.Errorf("failed to load conversation: %w", err)
+	}
+	if conversation != nil {
+		history = conversation.Messages
+	}
+
+	if err := s.ensureIndexed(ctx, req.UserId, req.VideoId); err != nil {
+		log.Printf("Error indexing video %s for question answering: %v", req.VideoId, err)
+		if status.Code(err) == codes.ResourceExhausted {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to index video: %w", err)
+	}
+
+	vectors, err := s.embedder.Embed(ctx, []string{question})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed question: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 question", len(vectors))
+	}
+
+	matches, err := s.vectorStore.Search(ctx, vectors[0], askPassages, []string{req.VideoId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transcript passages: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no transcript passages found for video")
+	}
+
+	passages := make([]models.TranscriptChunk, 0, len(matches))
+	for _, m := range matches {
+		passages = append(passages, m.TranscriptChunk)
+	}
+	// Present passages in the order they appear in the video.
+	sort.Slice(passages, func(i, j int) bool { return passages[i].StartSeconds < passages[j].StartSeconds })
+
+	recent := history
+	if len(recent) > maxHistoryMessages {
+		recent = recent[len(recent)-maxHistoryMessages:]
+	}
+
+	// The user's notes help but are not needed to answer.
+	byVideo, err := s.videoNotes(ctx, req.UserId, []string{req.VideoId})
+	if err != nil {
+		log.Printf("Error loading notes for video %s: %v", req.VideoId, err)
+	}
+	notes := byVideo[req.VideoId]
+	if len(notes) > askNotes {
+		notes = notes[:askNotes]
+	}
+
+	// Answers are written by the LLM, so they count like summaries.
+	release, err := s.checkQuota(ctx, req.UserId, QuotaSummaries)
+	if err != nil {
+		return nil, err
+	}
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, req.UserId, req.VideoId, models.OperationAsk, calls)
+	answer, err := s.llmClient.Answer(llmCtx, question, passages, notes, recent)
+	if err != nil {
+		log.Printf("Error answering question about video %s with LLM: %v", req.VideoId, err)
+		release()
+		return nil, llmError("failed to generate answer", err)
+	}
+
+	now := time.Now()
+	turn := []models.ChatMessage{
+		{Role: models.RoleUser, Content: question, CreatedAt: now},
+		{Role: models.RoleAssistant, Content: answer, Citations: extractCitations(answer, passages), CreatedAt: now},
+	}
+	if err := s.conversations.Append(ctx, req.UserId, req.VideoId, turn...); err != nil {
+		log.Printf("Error saving conversation for video %s: %v", req.VideoId, err)
+		return nil, fmt.Errorf("failed to save conversation: %w", err)
+	}
+
+	// The history is returned as the store now keeps it.
+	history = append(history, turn...)
+	if len(history) > models.MaxConversationMessages {
+		history = history[len(history)-models.MaxConversationMessages:]
+	}
+	return &pb.AskVideoResponse{
+		VideoId:   req.VideoId,
+		Answer:    answer,
+		Citations: convertCitationsToProto(turn[1].Citations),
+		History:   convertMessagesToProto(history),
+	}, nil
+}
+
+func (s *VideoService) GetConversation(ctx context.Context, req *pb.GetConversationRequest) (*pb.GetConversationResponse, error) {
+	if s.conversations == nil {
+		return nil, fmt.Errorf("question answering is not configured")
+	}
+
+	conversation, err := s.conversations.Get(ctx, req.UserId, req.VideoId)
+	if err != nil {
+		log.Printf("Error loading conversation for video %s: %v", req.VideoId, err)
+		return nil, fmt.Errorf("failed to load conversation: %w", err)
+	}
+
+	resp := &pb.GetConversationResponse{VideoId: req.VideoId}
+	if conversation != nil {
+		resp.History = convertMessagesToProto(conversation.Messages)
+	}
+	return resp, nil
+}
+
+// extractCitations resolves the [m:ss] markers in an answer to the passages
+// starting at those timestamps, ignoring markers that match no passage.
+func extractCitations(answer string, passages []models.TranscriptChunk) []models.Citation {
+	byTimestamp := make(map[string]models.TranscriptChunk, len(passages))
+	for _, p := range passages {
+		byTimestamp[helpers.FormatTimestamp(p.StartSeconds)] = p
+	}
+
+	var citations []models.Citation
+	seen := make(map[string]bool)
+	for _, match := range citationPattern.FindAllStringSubmatch(answer, -1) {
+		ts := match[1]
+		p, ok := byTimestamp[ts]
+		if !ok || seen[ts] {
+			continue
+		}
+		seen[ts] = true
+		citations = append(citations, models.Citation{
+			StartSeconds: p.StartSeconds,
+			EndSeconds:   p.EndSeconds,
+			Text:         p.Text,
+		})
+	}
+	return citations
+}
+
+func convertCitationsToProto(citations []models.Citation) []*pb.Citation {
+	result := make([]*pb.Citation, 0, len(citations))
+	for _, c := range citations {
+		result = append(result, &pb.Citation{
+			StartSeconds: c.StartSeconds,
+			EndSeconds:   c.EndSeconds,
+			Text:         c.Text,
+		})
+	}
+	return result
+}
+
+func convertMessagesToProto(messages []models.ChatMessage) []*pb.ChatMessage {
+	result := make([]*pb.ChatMessage, 0, len(messages))
+	for _, m := range messages {
+		result = append(result, &pb.ChatMessage{
+			Role:      m.Role,
+			Content:   m.Content,
+			Citations: convertCitationsToProto(m.Citations),
+			CreatedAt: m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return result
+}
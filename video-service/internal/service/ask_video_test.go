@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockConversationStore struct {
+	Conversations map[string]*models.Conversation
+}
+
+func (m *MockConversationStore) Get(ctx context.Context, userID, videoID string) (*models.Conversation, error) {
+	return m.Conversations[userID+"/"+videoID], nil
+}
+
+func (m *MockConversationStore) Append(ctx context.Context, userID, videoID string, messages ...models.ChatMessage) error {
+	key := userID + "/" + videoID
+	if m.Conversations[key] == nil {
+		m.Conversations[key] = &models.Conversation{UserID: userID, VideoID: videoID}
+	}
+	messages = append(m.Conversations[key].Messages, messages...)
+	if len(messages) > models.MaxConversationMessages {
+		messages = messages[len(messages)-models.MaxConversationMessages:]
+	}
+	m.Conversations[key].Messages = messages
+	return nil
+}
+
+func (m *MockConversationStore) Delete(ctx context.Context, userID, videoID string) error {
+	delete(m.Conversations, userID+"/"+videoID)
+	return nil
+}
+
+func TestAskVideo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"transcript": "we talk about cats here",
+			"segments": []map[string]interface{}{
+				{"text": "we talk about cats here", "start": 75.0, "duration": 5.0},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	var lastHistory []models.ChatMessage
+	var lastNotes []models.Note
+	mockLLM := &MockLLMClient{
+		AnswerFunc: func(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error) {
+			lastHistory = history
+			lastNotes = notes
+			return "Cats are discussed at [1:15], not at [9:99].", nil
+		},
+	}
+
+	conversations := &MockConversationStore{Conversations: map[string]*models.Conversation{}}
+	svc := &VideoService{
+		llmClient:            mockLLM,
+		transcriptServiceURL: ts.URL,
+		embedder:             &MockEmbedder{},
+		vectorStore:          &MockVectorStore{Chunks: map[string][]models.TranscriptChunk{}},
+		conversations:        conversations,
+		notes: &MockNoteStore{Notes: map[string]models.Note{
+			"mine":   {ID: "mine", UserID: "test-user", VideoID: "dQw4w9WgXcQ", StartSeconds: 70, Text: "cats!"},
+			"theirs": {ID: "theirs", UserID: "someone-else", VideoID: "dQw4w9WgXcQ", Text: "dogs"},
+		}},
+	}
+
+	req := &pb.AskVideoRequest{
+		VideoId:  "dQw4w9WgXcQ",
+		UserId:   "test-user",
+		Question: "what about cats?",
+	}
+
+	t.Run("AnswersWithCitations", func(t *testing.T) {
+		resp, err := svc.AskVideo(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(resp.Citations) != 1 {
+			t.Fatalf("Expected 1 citation, got %d", len(resp.Citations))
+		}
+		if resp.Citations[0].StartSeconds != 75 {
+			t.Errorf("Expected citation at 75s, got %v", resp.Citations[0].StartSeconds)
+		}
+		if len(resp.History) != 2 || resp.History[0].Role != models.RoleUser || resp.History[1].Role != models.RoleAssistant {
+			t.Errorf("Expected one user and one assistant message, got %+v", resp.History)
+		}
+		if len(lastNotes) != 1 || lastNotes[0].ID != "mine" {
+			t.Errorf("Expected the user's note on the video sent to the LLM, got %+v", lastNotes)
+		}
+	})
+
+	t.Run("ContinuesConversation", func(t *testing.T) {
+		resp, err := svc.AskVideo(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(lastHistory) != 2 {
+			t.Errorf("Expected previous turn to be sent to the LLM, got %d messages", len(lastHistory))
+		}
+		if len(resp.History) != 4 {
+			t.Errorf("Expected 4 messages in history, got %d", len(resp.History))
+		}
+	})
+
+	t.Run("ResetHistory", func(t *testing.T) {
+		resetReq := &pb.AskVideoRequest{
+			VideoId:      req.VideoId,
+			UserId:       req.UserId,
+			Question:     req.Question,
+			ResetHistory: true,
+		}
+		resp, err := svc.AskVideo(context.Background(), resetReq)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(lastHistory) != 0 {
+			t.Errorf("Expected no history after reset, got %d messages", len(lastHistory))
+		}
+		if len(resp.History) != 2 {
+			t.Errorf("Expected 2 messages in history, got %d", len(resp.History))
+		}
+	})
+
+	t.Run("KeepsRecentHistory", func(t *testing.T) {
+		long := make([]models.ChatMessage, models.MaxConversationMessages)
+		for i := range long {
+			long[i] = models.ChatMessage{Role: models.RoleUser, Content: fmt.Sprintf("question %d", i)}
+		}
+		conversations.Conversations[req.UserId+"/"+req.VideoId] = &models.Conversation{Messages: long}
+
+		resp, err := svc.AskVideo(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.History) != models.MaxConversationMessages {
+			t.Fatalf("Expected %d messages in history, got %d", models.MaxConversationMessages, len(resp.History))
+		}
+		if resp.History[0].Content != "question 2" || resp.History[len(resp.History)-1].Role != models.RoleAssistant {
+			t.Errorf("Expected the oldest messages dropped, got %q first", resp.History[0].Content)
+		}
+		if len(lastHistory) != maxHistoryMessages {
+			t.Errorf("Expected the last %d messages sent to the LLM, got %d", maxHistoryMessages, len(lastHistory))
+		}
+	})
+
+	t.Run("ConversationsArePerUser", func(t *testing.T) {
+		resp, err := svc.GetConversation(context.Background(), &pb.GetConversationRequest{
+			VideoId: req.VideoId,
+			UserId:  "someone-else",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.History) != 0 {
+			t.Errorf("Expected empty history for another user, got %d messages", len(resp.History))
+		}
+	})
+
+	t.Run("EmptyQuestion", func(t *testing.T) {
+		_, err := svc.AskVideo(context.Background(), &pb.AskVideoRequest{VideoId: req.VideoId, UserId: req.UserId})
+		if err == nil {
+			t.Fatal("Expected error for empty question, got nil")
+		}
+	})
+}
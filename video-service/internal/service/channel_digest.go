@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultDigestDays   = 7
+	maxDigestDays       = 31
+	defaultDigestVideos = 10
+	maxDigestVideos     = 20
+)
+
+// SummarizeChannel writes a newsletter-style digest of the uploads of a
+// channel in the last req.Days days. Uploads are summarized as for
+// SummarizeVideos: cached summaries are reused and the others count against
+// the summary quota.
+func (s *VideoService) SummarizeChannel(ctx context.Context, req *pb.SummarizeChannelRequest) (*pb.SummarizeChannelResponse, error) {
+	log.Printf("Summarizing channel: %s for user: %s", req.ChannelId, req.UserId)
+	if req.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel_id is required")
+	}
+	days := req.Days
+	if days == 0 {
+		days = defaultDigestDays
+	}
+	if days < 0 || days > maxDigestDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be between 1 and %d", maxDigestDays)
+	}
+	maxVideos := int(req.MaxVideos)
+	if maxVideos == 0 {
+		maxVideos = defaultDigestVideos
+	}
+	if maxVideos < 0 || maxVideos > maxDigestVideos {
+		return nil, status.Errorf(codes.InvalidArgument, "max_videos must be between 1 and %d", maxDigestVideos)
+	}
+	opts, err := models.ParseSummaryOptions("", "", req.Language)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	videos, err := s.GetChannelVideos(ctx, &pb.GetChannelVideosRequest{ChannelId: req.ChannelId, MaxResults: 50})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel videos: %w", err)
+	}
+	since := time.Now().AddDate(0, 0, -int(days))
+	return s.digestUploads(ctx, req.UserId, req.ChannelId, recentUploads(videos.Videos, since, maxVideos), since, opts)
+}
+
+// digestUploads summarizes a channel's uploads and writes the digest of
+// them. With no uploads there is nothing to write and the digest is empty.
+func (s *VideoService) digestUploads(ctx context.Context, userID, channelID string, uploads []*pb.VideoInfo, since time.Time, opts models.SummaryOptions) (*pb.SummarizeChannelResponse, error) {
+	resp := &pb.SummarizeChannelResponse{
+		ChannelId: channelID,
+		Since:     since.UTC().Format(time.RFC3339),
+	}
+	if len(uploads) == 0 {
+		log.Printf("No uploads from channel %s since %s", channelID, resp.Since)
+		return resp, nil
+	}
+	resp.ChannelTitle = uploads[0].ChannelTitle
+
+	for _, v := range uploads {
+		resp.Videos = append(resp.Videos, &pb.VideoDigestItem{
+			VideoId:      v.VideoId,
+			Title:        v.Title,
+			ChannelTitle: v.ChannelTitle,
+			PublishedAt:  v.PublishedAt,
+		})
+	}
+	summaries, release, err := s.digestSummaries(ctx, userID, resp.Videos, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, userID, "", models.OperationDigest, calls)
+	digest, err := s.llmClient.ChannelDigest(llmCtx, resp.ChannelTitle, summaries, opts)
+	if err != nil {
+		log.Printf("Error writing digest of channel %s with LLM: %v", channelID, err)
+		release()
+		return nil, llmError("failed to write channel digest", err)
+	}
+
+	log.Printf("Successfully wrote digest of %d uploads from channel: %s", len(summaries), channelID)
+	resp.Digest = digest
+	resp.GeneratedBy = calls.generatedBy()
+	return resp, nil
+}
+
+// recentUploads returns up to max of the videos published since the given
+// time, newest first. Videos without a valid publication date are skipped.
+func recentUploads(videos []*pb.VideoInfo, since time.Time, max int) []*pb.VideoInfo {
+	type upload struct {
+		video       *pb.VideoInfo
+		publishedAt time.Time
+	}
+	var recent []upload
+	for _, v := range videos {
+		publishedAt, err := time.Parse(time.RFC3339, v.PublishedAt)
+		if err != nil || publishedAt.Before(since) {
+			continue
+		}
+		recent = append(recent, upload{v, publishedAt})
+	}
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].publishedAt.After(recent[j].publishedAt) })
+
+	var result []*pb.VideoInfo
+	for i := 0; i < len(recent) && i < max; i++ {
+		result = append(result, recent[i].video)
+	}
+	return result
+}
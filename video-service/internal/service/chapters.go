@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+// YouTube only turns a description into chapters when there are at least
+// three of them, the first starts at 0:00 and each lasts ten seconds or more.
+const (
+	youtubeMinChapters       = 3
+	youtubeMinChapterSeconds = 10
+)
+
+// ChapterStore caches generated chapters per video.
+type ChapterStore interface {
+	Get(ctx context.Context, videoID string) (*models.VideoChapters, error)
+	Save(ctx context.Context, chapters *models.VideoChapters) error
+}
+
+func (s *VideoService) GenerateChapters(ctx context.Context, req *pb.GenerateChaptersRequest) (*pb.GenerateChaptersResponse, error) {
+	log.Printf("Generating chapters for video: %s for user: %s", req.VideoId, req.UserId)
+	if s.chapters == nil {
+		return nil, fmt.Errorf("chapter generation is not configured")
+	}
+
+	if req.Refresh && req.CachedOnly {
+		return nil, status.Error(codes.InvalidArgument, "refresh and cached only cannot be combined")
+	}
+
+	if !req.Refresh {
+		cached, err := s.chapters.Get(ctx, req.VideoId)
+		if err != nil {
+			log.Printf("Error loading cached chapters for video %s: %v", req.VideoId, err)
+			if req.CachedOnly {
+				return nil, fmt.Errorf("failed to load cached chapters: %w", err)
+			}
+		} else if cached != nil {
+			log.Printf("Cache hit for chapters of video: %s", req.VideoId)
+			return buildChaptersResponse(req.VideoId, cached.Chapters, true), nil
+		}
+		if req.CachedOnly {
+			return buildChaptersResponse(req.VideoId, nil, false), nil
+		}
+	}
+
+	// Generating chapters reads the transcript and writes with the LLM, so
+	// it counts against both quotas.
+	releaseTranscript, err := s.checkQuota(ctx, req.UserId, QuotaTranscripts)
+	if err != nil {
+		return nil, err
+	}
+	releaseSummary, err := s.checkQuota(ctx, req.UserId, QuotaSummaries)
+	if err != nil {
+		releaseTranscript()
+		return nil, err
+	}
+	release := func() {
+		releaseTranscript()
+		releaseSummary()
+	}
+
+	transcript, err := s.fetchTranscript(ctx, req.VideoId)
+	if err != nil {
+		log.Printf("Error getting transcript for chapters of %s: %v", req.VideoId, err)
+		release()
+		return nil, fmt.Errorf("failed to fetch transcript for chapters: %w", err)
+	}
+	if len(transcript.Segments) == 0 {
+		release()
+		return nil, fmt.Errorf("transcript has no timestamps, cannot generate chapters")
+	}
+
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, req.UserId, req.VideoId, models.OperationChapters, calls)
+	chapters, err := s.generateChapters(llmCtx, timestampedTranscript(transcript.Text, transcript.Segments))
+	if err != nil {
+		log.Printf("Error generating chapters for video %s with LLM: %v", req.VideoId, err)
+		release()
+		return nil, llmError("failed to generate chapters", err)
+	}
+	chapters = normalizeChapters(chapters)
+
+	if err := s.chapters.Save(ctx, &models.VideoChapters{
+		VideoID:     req.VideoId,
+		Chapters:    chapters,
+		GeneratedAt: time.Now(),
+	}); err != nil {
+		log.Printf("Error caching chapters for video %s: %v", req.VideoId, err)
+	}
+
+	log.Printf("Successfully generated %d chapters for video: %s", len(chapters), req.VideoId)
+	return buildChaptersResponse(req.VideoId, chapters, false), nil
+}
+
+// generateChapters asks the LLM for chapters, splitting long transcripts into
+// parts that are processed concurrently. The [m:ss] markers keep every part's
+// chapter times absolute.
+func (s *VideoService) generateChapters(ctx context.Context, transcript string) ([]models.Chapter, error) {
+	cfg := s.mapReduce.withDefaults()
+	if EstimateTokens(transcript) <= cfg.ThresholdTokens {
+		return s.llmClient.GenerateChapters(ctx, transcript)
+	}
+
+	parts := SplitByTokens(transcript, cfg.ChunkTokens)
+	results := make([][]models.Chapter, len(parts))
+	err := runBounded(ctx, len(parts), cfg.Concurrency, func(ctx context.Context, i int) error {
+		chapters, err := s.llmClient.GenerateChapters(ctx, parts[i])
+		if err != nil {
+			return fmt.Errorf("failed to generate chapters for part %d of %d: %w", i+1, len(parts), err)
+		}
+		results[i] = chapters
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var chapters []models.Chapter
+	for _, r := range results {
+		chapters = append(chapters, r...)
+	}
+	sort.SliceStable(chapters, func(i, j int) bool { return chapters[i].StartSeconds < chapters[j].StartSeconds })
+	return chapters, nil
+}
+
+// normalizeChapters makes the first chapter start at 0:00 and drops chapters
+// that would be shorter than YouTube allows.
+func normalizeChapters(chapters []models.Chapter) []models.Chapter {
+	if len(chapters) == 0 {
+		return chapters
+	}
+
+	result := []models.Chapter{{Title: chapters[0].Title, StartSeconds: 0}}
+	for _, c := range chapters[1:] {
+		if c.StartSeconds-result[len(result)-1].StartSeconds >= youtubeMinChapterSeconds {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// FormatYouTubeChapters renders chapters as "0:00 Title" lines for a video
+// description, or returns "" when YouTube would not accept them.
+func FormatYouTubeChapters(chapters []models.Chapter) string {
+	if len(chapters) < youtubeMinChapters || chapters[0].StartSeconds != 0 {
+		return ""
+	}
+
+	lines := make([]string, len(chapters))
+	for i, c := range chapters {
+		lines[i] = helpers.FormatTimestamp(c.StartSeconds) + " " + c.Title
+	}
+	return strings.Join(lines, "\n")
+}
+
+func buildChaptersResponse(videoID string, chapters []models.Chapter, cached bool) *pb.GenerateChaptersResponse {
+	resp := &pb.GenerateChaptersResponse{
+		VideoId:            videoID,
+		YoutubeDescription: FormatYouTubeChapters(chapters),
+		Cached:             cached,
+	}
+	for _, c := range chapters {
+		resp.Chapters = append(resp.Chapters, &pb.VideoChapter{
+			Title:        c.Title,
+			StartSeconds: c.StartSeconds,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockChapterStore struct {
+	Chapters map[string]*models.VideoChapters
+}
+
+func (m *MockChapterStore) Get(ctx context.Context, videoID string) (*models.VideoChapters, error) {
+	return m.Chapters[videoID], nil
+}
+
+func (m *MockChapterStore) Save(ctx context.Context, chapters *models.VideoChapters) error {
+	m.Chapters[chapters.VideoID] = chapters
+	return nil
+}
+
+func TestGenerateChapters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"transcript": "hello and welcome today we cover go then rust",
+			"segments": []map[string]interface{}{
+				{"text": "hello and welcome", "start": 3.0, "duration": 5.0},
+				{"text": "today we cover go", "start": 60.0, "duration": 5.0},
+				{"text": "then rust", "start": 125.0, "duration": 5.0},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	calls := 0
+	mockLLM := &MockLLMClient{
+		ChaptersFunc: func(ctx context.Context, transcript string) ([]models.Chapter, error) {
+			calls++
+			if !strings.Contains(transcript, "[2:05] then rust") {
+				t.Errorf("Expected a timestamped transcript, got %q", transcript)
+			}
+			return []models.Chapter{
+				{Title: "Welcome", StartSeconds: 3},
+				{Title: "Go", StartSeconds: 60},
+				{Title: "Too short", StartSeconds: 65},
+				{Title: "Rust", StartSeconds: 125},
+			}, nil
+		},
+	}
+	store := &MockChapterStore{Chapters: map[string]*models.VideoChapters{}}
+	svc := &VideoService{
+		llmClient:            mockLLM,
+		transcriptServiceURL: ts.URL,
+		chapters:             store,
+	}
+	req := &pb.GenerateChaptersRequest{VideoId: "dQw4w9WgXcQ", UserId: "test-user"}
+
+	t.Run("Generates", func(t *testing.T) {
+		resp, err := svc.GenerateChapters(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(resp.Chapters) != 3 {
+			t.Fatalf("Expected 3 chapters after dropping the short one, got %+v", resp.Chapters)
+		}
+		if resp.Chapters[0].StartSeconds != 0 {
+			t.Errorf("Expected first chapter at 0:00, got %v", resp.Chapters[0].StartSeconds)
+		}
+		want := "0:00 Welcome\n1:00 Go\n2:05 Rust"
+		if resp.YoutubeDescription != want {
+			t.Errorf("Expected description %q, got %q", want, resp.YoutubeDescription)
+		}
+		if resp.Cached {
+			t.Error("Expected freshly generated chapters")
+		}
+		if store.Chapters["dQw4w9WgXcQ"] == nil {
+			t.Error("Expected chapters to be cached")
+		}
+	})
+
+	t.Run("UsesCache", func(t *testing.T) {
+		resp, err := svc.GenerateChapters(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !resp.Cached || calls != 1 {
+			t.Errorf("Expected cached chapters without another LLM call, got cached=%v calls=%d", resp.Cached, calls)
+		}
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		resp, err := svc.GenerateChapters(context.Background(), &pb.GenerateChaptersRequest{VideoId: req.VideoId, Refresh: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Cached || calls != 2 {
+			t.Errorf("Expected regenerated chapters, got cached=%v calls=%d", resp.Cached, calls)
+		}
+	})
+
+	t.Run("CachedOnly", func(t *testing.T) {
+		resp, err := svc.GenerateChapters(context.Background(), &pb.GenerateChaptersRequest{VideoId: req.VideoId, CachedOnly: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !resp.Cached || len(resp.Chapters) != 3 {
+			t.Errorf("Expected the cached chapters, got %+v", resp)
+		}
+
+		resp, err = svc.GenerateChapters(context.Background(), &pb.GenerateChaptersRequest{VideoId: "uncached123", CachedOnly: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.Chapters) != 0 || calls != 2 {
+			t.Errorf("Expected no chapters and no LLM call on a cache miss, got %+v calls=%d", resp, calls)
+		}
+
+		_, err = svc.GenerateChapters(context.Background(), &pb.GenerateChaptersRequest{VideoId: req.VideoId, CachedOnly: true, Refresh: true})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, err := (&VideoService{}).GenerateChapters(context.Background(), req)
+		if err == nil {
+			t.Fatal("Expected error when chapters are not configured, got nil")
+		}
+	})
+}
+
+func TestFormatYouTubeChapters(t *testing.T) {
+	if got := FormatYouTubeChapters([]models.Chapter{{Title: "A"}, {Title: "B", StartSeconds: 30}}); got != "" {
+		t.Errorf("Expected no description for fewer than 3 chapters, got %q", got)
+	}
+
+	got := FormatYouTubeChapters([]models.Chapter{
+		{Title: "Intro"},
+		{Title: "Middle", StartSeconds: 600},
+		{Title: "End", StartSeconds: 3725},
+	})
+	if want := "0:00 Intro\n10:00 Middle\n1:02:05 End"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
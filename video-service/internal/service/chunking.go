@@ -0,0 +1,77 @@
+package service
+
+import (
+	"strings"
+
+	"videoservice/internal/models"
+)
+
+const (
+	defaultChunkWords   = 200
+	defaultChunkOverlap = 40
+)
+
+type timedWord struct {
+	text  string
+	start float64
+	end   float64
+}
+
+// ChunkTranscript splits timed transcript segments into overlapping windows of
+// roughly windowWords words, each sharing overlapWords words with the previous
+// one so that a passage cut at a boundary is still found intact. Every chunk
+// carries the start time of its first word and the end time of its last.
+func ChunkTranscript(videoID string, segments []models.TranscriptSegment, windowWords, overlapWords int) []models.TranscriptChunk {
+	if windowWords <= 0 {
+		windowWords = defaultChunkWords
+	}
+	if overlapWords < 0 || overlapWords >= windowWords {
+		overlapWords = 0
+	}
+
+	var words []timedWord
+	for _, seg := range segments {
+		for _, w := range strings.Fields(seg.Text) {
+			words = append(words, timedWord{text: w, start: seg.Start, end: seg.Start + seg.Duration})
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	step := windowWords - overlapWords
+	var chunks []models.TranscriptChunk
+	for start := 0; start < len(words); start += step {
+		end := start + windowWords
+		if end > len(words) {
+			end = len(words)
+		}
+
+		texts := make([]string, 0, end-start)
+		for _, w := range words[start:end] {
+			texts = append(texts, w.text)
+		}
+
+		chunks = append(chunks, models.TranscriptChunk{
+			VideoID:      videoID,
+			Index:        len(chunks),
+			Text:         strings.Join(texts, " "),
+			StartSeconds: words[start].start,
+			EndSeconds:   words[end-1].end,
+		})
+
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
+
+// segmentsOrPlain returns the timed segments of a transcript, falling back to
+// a single untimed segment when the transcript service only returned text.
+func segmentsOrPlain(text string, segments []models.TranscriptSegment) []models.TranscriptSegment {
+	if len(segments) > 0 {
+		return segments
+	}
+	return []models.TranscriptSegment{{Text: text}}
+}
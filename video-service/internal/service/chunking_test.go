@@ -0,0 +1,65 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"videoservice/internal/models"
+)
+
+func TestChunkTranscript(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "one two three", Start: 0, Duration: 3},
+		{Text: "four five six", Start: 3, Duration: 3},
+		{Text: "seven eight nine ten", Start: 6, Duration: 4},
+	}
+
+	t.Run("OverlappingWindows", func(t *testing.T) {
+		chunks := ChunkTranscript("vid", segments, 4, 2)
+		if len(chunks) != 4 {
+			t.Fatalf("Expected 4 chunks, got %d", len(chunks))
+		}
+
+		if chunks[0].Text != "one two three four" {
+			t.Errorf("Unexpected first chunk %q", chunks[0].Text)
+		}
+		if chunks[1].Text != "three four five six" {
+			t.Errorf("Expected second chunk to overlap by two words, got %q", chunks[1].Text)
+		}
+		if chunks[3].Text != "seven eight nine ten" {
+			t.Errorf("Unexpected last chunk %q", chunks[3].Text)
+		}
+
+		if chunks[1].StartSeconds != 0 || chunks[1].EndSeconds != 6 {
+			t.Errorf("Expected second chunk to span 0-6s, got %v-%v", chunks[1].StartSeconds, chunks[1].EndSeconds)
+		}
+		for i, c := range chunks {
+			if c.Index != i || c.VideoID != "vid" {
+				t.Errorf("Chunk %d has index %d and video %q", i, c.Index, c.VideoID)
+			}
+		}
+	})
+
+	t.Run("ShortTranscriptIsOneChunk", func(t *testing.T) {
+		chunks := ChunkTranscript("vid", segments, 50, 10)
+		if len(chunks) != 1 {
+			t.Fatalf("Expected 1 chunk, got %d", len(chunks))
+		}
+		if strings.Count(chunks[0].Text, " ") != 9 {
+			t.Errorf("Expected all 10 words in chunk, got %q", chunks[0].Text)
+		}
+	})
+
+	t.Run("InvalidOverlapIsIgnored", func(t *testing.T) {
+		chunks := ChunkTranscript("vid", segments, 5, 5)
+		if len(chunks) != 2 {
+			t.Fatalf("Expected 2 non-overlapping chunks, got %d", len(chunks))
+		}
+	})
+
+	t.Run("EmptyTranscript", func(t *testing.T) {
+		if chunks := ChunkTranscript("vid", nil, 4, 2); chunks != nil {
+			t.Errorf("Expected no chunks, got %v", chunks)
+		}
+	})
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	maxCollectionsPerUser    = 100
+	maxCollectionItems       = 500
+	maxCollectionName        = 100
+	maxCollectionDescription = 1000
+
+	maxTagsPerVideo = 20
+	maxTagLength    = 50
+)
+
+var videoIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{11}$`)
+
+// CollectionStore persists users' collections and the tags they give videos.
+type CollectionStore interface {
+	SaveCollection(ctx context.Context, collection *models.Collection) error
+	// GetCollection returns a collection, or nil if there is none with that
+	// ID.
+	GetCollection(ctx context.Context, id string) (*models.Collection, error)
+	// ListCollections returns a user's collections, most recently updated
+	// first.
+	ListCollections(ctx context.Context, userID string) ([]models.Collection, error)
+	// DeleteCollection reports whether the user had a collection with that
+	// ID.
+	DeleteCollection(ctx context.Context, userID, id string) (bool, error)
+
+	// SaveVideoTags replaces the tags a user gave a video; no tags removes
+	// them.
+	SaveVideoTags(ctx context.Context, tags *models.VideoTags) error
+	// ListVideoTags returns every video a user tagged, most recently tagged
+	// first.
+	ListVideoTags(ctx context.Context, userID string) ([]models.VideoTags, error)
+	// FindVideoTags returns the tags a user gave the given videos.
+	FindVideoTags(ctx context.Context, userID string, videoIDs []string) ([]models.VideoTags, error)
+}
+
+func (s *VideoService) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.Collection, error) {
+	if s.collections == nil {
+		return nil, fmt.Errorf("collections are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	name, description, err := collectionText(req.Name, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.collections.ListCollections(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	if len(existing) >= maxCollectionsPerUser {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d collections can be created", maxCollectionsPerUser)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	now := time.Now()
+	collection := &models.Collection{
+		ID:          id,
+		UserID:      req.UserId,
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.collections.SaveCollection(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to save collection: %w", err)
+	}
+
+	log.Printf("Created collection %s for user: %s", collection.ID, req.UserId)
+	return convertCollectionToProto(collection, nil), nil
+}
+
+func (s *VideoService) ListCollections(ctx context.Context, req *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	if s.collections == nil {
+		return nil, fmt.Errorf("collections are not configured")
+	}
+	collections, err := s.collections.ListCollections(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	resp := &pb.ListCollectionsResponse{}
+	for i := range collections {
+		collection := convertCollectionToProto(&collections[i], nil)
+		collection.Items = nil
+		resp.Collections = append(resp.Collections, collection)
+	}
+	return resp, nil
+}
+
+// GetCollection returns a collection with its videos and their tags,
+// optionally only the videos with one tag.
+func (s *VideoService) GetCollection(ctx context.Context, req *pb.GetCollectionRequest) (*pb.Collection, error) {
+	collection, err := s.userCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.itemTags(ctx, req.UserId, collection.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := convertCollectionToProto(collection, tags)
+	if tag := normalizeTag(req.Tag); tag != "" {
+		var items []*pb.CollectionItem
+		for _, item := range resp.Items {
+			if slices.Contains(item.Tags, tag) {
+				items = append(items, item)
+			}
+		}
+		resp.Items = items
+	}
+	return resp, nil
+}
+
+func (s *VideoService) UpdateCollection(ctx context.Context, req *pb.UpdateCollectionRequest) (*pb.Collection, error) {
+	name, description, err := collectionText(req.Name, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.userCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	collection.Name = name
+	collection.Description = description
+	return s.saveCollection(ctx, collection)
+}
+
+func (s *VideoService) DeleteCollection(ctx context.Context, req *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	if s.collections == nil {
+		return nil, fmt.Errorf("collections are not configured")
+	}
+	deleted, err := s.collections.DeleteCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "collection %s not found", req.CollectionId)
+	}
+	log.Printf("Deleted collection %s for user: %s", req.CollectionId, req.UserId)
+	return &pb.DeleteCollectionResponse{}, nil
+}
+
+// AddToCollection saves a video to a collection with its current details.
+// Adding a video that is already there leaves it in place.
+func (s *VideoService) AddToCollection(ctx context.Context, req *pb.AddToCollectionRequest) (*pb.Collection, error) {
+	if !videoIDPattern.MatchString(req.VideoId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid video id %q", req.VideoId)
+	}
+	collection, err := s.userCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(collection.Items, func(item models.CollectionItem) bool { return item.VideoID == req.VideoId }) {
+		if len(collection.Items) >= maxCollectionItems {
+			return nil, status.Errorf(codes.InvalidArgument, "a collection can hold at most %d videos", maxCollectionItems)
+		}
+		item := models.CollectionItem{VideoID: req.VideoId, AddedAt: time.Now()}
+		if video := s.videoMetadata(ctx, req.VideoId); video != nil {
+			item.Title = video.Title
+			item.ChannelID = video.ChannelId
+			item.ChannelTitle = video.ChannelTitle
+			item.Thumbnail = video.ThumbnailUrl
+			item.PublishedAt = video.PublishedAt
+		}
+		collection.Items = append(collection.Items, item)
+	}
+	return s.saveCollection(ctx, collection)
+}
+
+func (s *VideoService) RemoveFromCollection(ctx context.Context, req *pb.RemoveFromCollectionRequest) (*pb.Collection, error) {
+	collection, err := s.userCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(collection.Items, func(item models.CollectionItem) bool { return item.VideoID == req.VideoId })
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "video %s is not in the collection", req.VideoId)
+	}
+	collection.Items = slices.Delete(collection.Items, i, i+1)
+	return s.saveCollection(ctx, collection)
+}
+
+// SetVideoTags replaces the tags a user gave a video. Tags are trimmed,
+// lowercased and deduplicated; no tags removes them.
+func (s *VideoService) SetVideoTags(ctx context.Context, req *pb.SetVideoTagsRequest) (*pb.VideoTags, error) {
+	if s.collections == nil {
+		return nil, fmt.Errorf("collections are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if !videoIDPattern.MatchString(req.VideoId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid video id %q", req.VideoId)
+	}
+	var tags []string
+	for _, tag := range req.Tags {
+		tag = normalizeTag(tag)
+		if tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, status.Errorf(codes.InvalidArgument, "tags must be at most %d characters", maxTagLength)
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxTagsPerVideo {
+		return nil, status.Errorf(codes.InvalidArgument, "a video can have at most %d tags", maxTagsPerVideo)
+	}
+
+	entry := &models.VideoTags{UserID: req.UserId, VideoID: req.VideoId, Tags: tags, UpdatedAt: time.Now()}
+	if err := s.collections.SaveVideoTags(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save tags: %w", err)
+	}
+	return convertVideoTagsToProto(entry), nil
+}
+
+// ListTags returns every tag a user used with the number of videos having
+// it and, when asked for one tag, those videos.
+func (s *VideoService) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	if s.collections == nil {
+		return nil, fmt.Errorf("collections are not configured")
+	}
+	tagged, err := s.collections.ListVideoTags(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	resp := &pb.ListTagsResponse{}
+	wanted := normalizeTag(req.Tag)
+	counts := make(map[string]int32)
+	for i := range tagged {
+		for _, tag := range tagged[i].Tags {
+			counts[tag]++
+		}
+		if wanted != "" && slices.Contains(tagged[i].Tags, wanted) {
+			resp.Videos = append(resp.Videos, convertVideoTagsToProto(&tagged[i]))
+		}
+	}
+	for tag, n := range counts {
+		resp.Tags = append(resp.Tags, &pb.TagCount{Tag: tag, Videos: n})
+	}
+	sort.Slice(resp.Tags, func(i, j int) bool {
+		if resp.Tags[i].Videos != resp.Tags[j].Videos {
+			return resp.Tags[i].Videos > resp.Tags[j].Videos
+		}
+		return resp.Tags[i].Tag < resp.Tags[j].Tag
+	})
+	return resp, nil
+}
+
+// userCollection loads one of a user's collections, reporting other users'
+// collections as not found.
+func (s *VideoService) userCollection(ctx context.Context, userID, id string) (*models.Collection, error) {
+	if s.collections == nil {
+		return nil, fmt.Errorf("collections are not configured")
+	}
+	collection, err := s.collections.GetCollection(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load collection: %w", err)
+	}
+	if collection == nil || collection.UserID != userID {
+		return nil, status.Errorf(codes.NotFound, "collection %s not found", id)
+	}
+	return collection, nil
+}
+
+// saveCollection stores a changed collection and returns it with its tags.
+func (s *VideoService) saveCollection(ctx context.Context, collection *models.Collection) (*pb.Collection, error) {
+	collection.UpdatedAt = time.Now()
+	if err := s.collections.SaveCollection(ctx, collection); err != nil {
+		return nil, fmt.Errorf("failed to save collection: %w", err)
+	}
+	tags, err := s.itemTags(ctx, collection.UserID, collection.Items)
+	if err != nil {
+		return nil, err
+	}
+	return convertCollectionToProto(collection, tags), nil
+}
+
+// itemTags returns the tags the user gave each of the items' videos.
+func (s *VideoService) itemTags(ctx context.Context, userID string, items []models.CollectionItem) (map[string][]string, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	videoIDs := make([]string, len(items))
+	for i := range items {
+		videoIDs[i] = items[i].VideoID
+	}
+	tagged, err := s.collections.FindVideoTags(ctx, userID, videoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tags: %w", err)
+	}
+	tags := make(map[string][]string, len(tagged))
+	for _, entry := range tagged {
+		tags[entry.VideoID] = entry.Tags
+	}
+	return tags, nil
+}
+
+// collectionText validates and trims the name and description of a
+// collection.
+func collectionText(name, description string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	description = strings.TrimSpace(description)
+	if name == "" {
+		return "", "", status.Error(codes.InvalidArgument, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxCollectionName {
+		return "", "", status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxCollectionName)
+	}
+	if utf8.RuneCountInString(description) > maxCollectionDescription {
+		return "", "", status.Errorf(codes.InvalidArgument, "description must be at most %d characters", maxCollectionDescription)
+	}
+	return name, description, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// convertCollectionToProto converts a collection with the tags of its
+// videos, listing the most recently added videos first.
+func convertCollectionToProto(collection *models.Collection, tags map[string][]string) *pb.Collection {
+	result := &pb.Collection{
+		CollectionId: collection.ID,
+		Name:         collection.Name,
+		Description:  collection.Description,
+		VideoCount:   int32(len(collection.Items)),
+		CreatedAt:    collection.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:    collection.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	for i := len(collection.Items) - 1; i >= 0; i-- {
+		item := &collection.Items[i]
+		result.Items = append(result.Items, &pb.CollectionItem{
+			VideoId:      item.VideoID,
+			Title:        item.Title,
+			ChannelId:    item.ChannelID,
+			ChannelTitle: item.ChannelTitle,
+			ThumbnailUrl: item.Thumbnail,
+			PublishedAt:  item.PublishedAt,
+			AddedAt:      item.AddedAt.UTC().Format(time.RFC3339),
+			Tags:         tags[item.VideoID],
+		})
+	}
+	return result
+}
+
+func convertVideoTagsToProto(tags *models.VideoTags) *pb.VideoTags {
+	return &pb.VideoTags{
+		VideoId:   tags.VideoID,
+		Tags:      tags.Tags,
+		UpdatedAt: tags.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockCollectionStore struct {
+	mu          sync.Mutex
+	Collections map[string]models.Collection
+	Tags        map[string]models.VideoTags
+}
+
+func (m *MockCollectionStore) SaveCollection(ctx context.Context, collection *models.Collection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Collections == nil {
+		m.Collections = map[string]models.Collection{}
+	}
+	saved := *collection
+	saved.Items = slices.Clone(collection.Items)
+	m.Collections[collection.ID] = saved
+	return nil
+}
+
+func (m *MockCollectionStore) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	collection, ok := m.Collections[id]
+	if !ok {
+		return nil, nil
+	}
+	collection.Items = slices.Clone(collection.Items)
+	return &collection, nil
+}
+
+func (m *MockCollectionStore) ListCollections(ctx context.Context, userID string) ([]models.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var collections []models.Collection
+	for _, collection := range m.Collections {
+		if collection.UserID == userID {
+			collections = append(collections, collection)
+		}
+	}
+	return collections, nil
+}
+
+func (m *MockCollectionStore) DeleteCollection(ctx context.Context, userID, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	collection, ok := m.Collections[id]
+	if !ok || collection.UserID != userID {
+		return false, nil
+	}
+	delete(m.Collections, id)
+	return true, nil
+}
+
+func (m *MockCollectionStore) SaveVideoTags(ctx context.Context, tags *models.VideoTags) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Tags == nil {
+		m.Tags = map[string]models.VideoTags{}
+	}
+	tags.ID = tags.UserID + ":" + tags.VideoID
+	if len(tags.Tags) == 0 {
+		delete(m.Tags, tags.ID)
+		return nil
+	}
+	m.Tags[tags.ID] = *tags
+	return nil
+}
+
+func (m *MockCollectionStore) ListVideoTags(ctx context.Context, userID string) ([]models.VideoTags, error) {
+	return m.findTags(func(tags models.VideoTags) bool { return tags.UserID == userID }), nil
+}
+
+func (m *MockCollectionStore) FindVideoTags(ctx context.Context, userID string, videoIDs []string) ([]models.VideoTags, error) {
+	return m.findTags(func(tags models.VideoTags) bool {
+		return tags.UserID == userID && slices.Contains(videoIDs, tags.VideoID)
+	}), nil
+}
+
+func (m *MockCollectionStore) findTags(match func(models.VideoTags) bool) []models.VideoTags {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var found []models.VideoTags
+	for _, tags := range m.Tags {
+		if match(tags) {
+			found = append(found, tags)
+		}
+	}
+	return found
+}
+
+func TestCollections(t *testing.T) {
+	svc := &VideoService{collections: &MockCollectionStore{}}
+	ctx := context.Background()
+
+	collection, err := svc.CreateCollection(ctx, &pb.CreateCollectionRequest{UserId: "user-1", Name: "  Reading list ", Description: "Talks to read"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if collection.Name != "Reading list" || collection.VideoCount != 0 {
+		t.Errorf("Expected an empty collection with a trimmed name, got %v", collection)
+	}
+	id := collection.CollectionId
+
+	for _, videoID := range []string{"talkNumber1", "talkNumber2", "talkNumber1"} {
+		if collection, err = svc.AddToCollection(ctx, &pb.AddToCollectionRequest{UserId: "user-1", CollectionId: id, VideoId: videoID}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if collection.VideoCount != 2 || collection.Items[0].VideoId != "talkNumber2" {
+		t.Errorf("Expected two videos, most recently added first, got %v", collection.Items)
+	}
+
+	if _, err := svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber1", Tags: []string{" Go ", "go", "Machine  Learning", ""}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	collection, err = svc.GetCollection(ctx, &pb.GetCollectionRequest{UserId: "user-1", CollectionId: id, Tag: "GO"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(collection.Items) != 1 || collection.Items[0].VideoId != "talkNumber1" || !slices.Equal(collection.Items[0].Tags, []string{"go", "machine learning"}) {
+		t.Errorf("Expected only the tagged video with its normalized tags, got %v", collection.Items)
+	}
+
+	if collection, err = svc.UpdateCollection(ctx, &pb.UpdateCollectionRequest{UserId: "user-1", CollectionId: id, Name: "Watched"}); err != nil || collection.Name != "Watched" || collection.Description != "" {
+		t.Errorf("Expected the collection renamed, got %v, %v", collection, err)
+	}
+	if collection, err = svc.RemoveFromCollection(ctx, &pb.RemoveFromCollectionRequest{UserId: "user-1", CollectionId: id, VideoId: "talkNumber2"}); err != nil || collection.VideoCount != 1 {
+		t.Errorf("Expected the video removed, got %v, %v", collection, err)
+	}
+	if _, err := svc.RemoveFromCollection(ctx, &pb.RemoveFromCollectionRequest{UserId: "user-1", CollectionId: id, VideoId: "talkNumber2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a video not in the collection, got %v", err)
+	}
+
+	if _, err := svc.GetCollection(ctx, &pb.GetCollectionRequest{UserId: "user-2", CollectionId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected another user's collection not found, got %v", err)
+	}
+	if _, err := svc.DeleteCollection(ctx, &pb.DeleteCollectionRequest{UserId: "user-2", CollectionId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected another user's collection not deleted, got %v", err)
+	}
+	if _, err := svc.DeleteCollection(ctx, &pb.DeleteCollectionRequest{UserId: "user-1", CollectionId: id}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp, _ := svc.ListCollections(ctx, &pb.ListCollectionsRequest{UserId: "user-1"}); len(resp.Collections) != 0 {
+		t.Errorf("Expected the collection deleted, got %v", resp.Collections)
+	}
+}
+
+func TestCollections_InvalidRequests(t *testing.T) {
+	svc := &VideoService{collections: &MockCollectionStore{}}
+	ctx := context.Background()
+	collection, _ := svc.CreateCollection(ctx, &pb.CreateCollectionRequest{UserId: "user-1", Name: "List"})
+
+	checks := map[string]error{}
+	_, checks["no name"] = svc.CreateCollection(ctx, &pb.CreateCollectionRequest{UserId: "user-1", Name: "  "})
+	_, checks["long name"] = svc.CreateCollection(ctx, &pb.CreateCollectionRequest{UserId: "user-1", Name: strings.Repeat("a", maxCollectionName+1)})
+	_, checks["no user"] = svc.CreateCollection(ctx, &pb.CreateCollectionRequest{Name: "List"})
+	_, checks["bad video"] = svc.AddToCollection(ctx, &pb.AddToCollectionRequest{UserId: "user-1", CollectionId: collection.CollectionId, VideoId: "nope"})
+	_, checks["long tag"] = svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber1", Tags: []string{strings.Repeat("a", maxTagLength+1)}})
+	for name, err := range checks {
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestListTags(t *testing.T) {
+	svc := &VideoService{collections: &MockCollectionStore{}}
+	ctx := context.Background()
+	svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber1", Tags: []string{"go", "talks"}})
+	svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber2", Tags: []string{"go"}})
+	svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber3", Tags: []string{"rust"}})
+	svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber3"})
+	svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-2", VideoId: "talkNumber3", Tags: []string{"go"}})
+
+	resp, err := svc.ListTags(ctx, &pb.ListTagsRequest{UserId: "user-1", Tag: "go"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Tags) != 2 || resp.Tags[0].Tag != "go" || resp.Tags[0].Videos != 2 || resp.Tags[1].Tag != "talks" {
+		t.Errorf("Expected the user's tags, most used first, got %v", resp.Tags)
+	}
+	if len(resp.Videos) != 2 {
+		t.Errorf("Expected the two videos tagged go, got %v", resp.Videos)
+	}
+}
+
+func TestExportCollection(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("videoId") == "missingVid1" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "no captions"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "Talk " + r.URL.Query().Get("videoId")})
+	}))
+	defer ts.Close()
+
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	summaries := &MockSummaryStore{Summaries: map[string]*models.CachedSummary{
+		models.SummaryCacheKey("cachedVid01", opts): {Summary: "Cached summary"},
+	}}
+	store := &MockCollectionStore{Collections: map[string]models.Collection{
+		"list": {ID: "list", UserID: "user-1", Name: "Go Talks: 2026!", Items: []models.CollectionItem{
+			{VideoID: "cachedVid01", Title: "Cached", ChannelTitle: "Chan", PublishedAt: "2026-10-01T00:00:00Z", AddedAt: time.Now()},
+			{VideoID: "freshVid001", Title: "Fresh", AddedAt: time.Now()},
+			{VideoID: "missingVid1", Title: "Missing", AddedAt: time.Now()},
+		}},
+	}}
+	quotas := &MockQuotaStore{}
+	svc := &VideoService{
+		llmClient: &MockLLMClient{SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			return "Summary of " + text, nil
+		}},
+		collections:          store,
+		summaries:            summaries,
+		quotas:               quotas,
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaSummaries: {Daily: 5}}},
+		transcriptServiceURL: ts.URL,
+		notes: &MockNoteStore{Notes: map[string]models.Note{
+			"note": {ID: "note", UserID: "user-1", VideoID: "freshVid001", StartSeconds: 75, EndSeconds: 90, Quote: "the key point", Text: "Remember this"},
+		}},
+	}
+	svc.SetVideoTags(context.Background(), &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "cachedVid01", Tags: []string{"machine learning"}})
+
+	resp, err := svc.ExportCollection(context.Background(), &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	doc := string(resp.Content)
+	if resp.Filename != "go-talks-2026.md" || !strings.HasPrefix(resp.ContentType, "text/markdown") {
+		t.Errorf("Expected a Markdown file named after the collection, got %q %q", resp.Filename, resp.ContentType)
+	}
+	for _, want := range []string{"# Go Talks: 2026!", "## [Cached](https://www.youtube.com/watch?v=cachedVid01)", "Chan · published 2026-10-01 · #machine-learning", "Cached summary", "Summary of Talk freshVid001", "_No summary: ", "- [1:15](https://www.youtube.com/watch?v=freshVid001&t=75)–1:30 Remember this", "  > the key point"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected the export to contain %q, got:\n%s", want, doc)
+		}
+	}
+	if strings.Index(doc, "Missing") > strings.Index(doc, "Cached") {
+		t.Errorf("Expected the most recently added videos first, got:\n%s", doc)
+	}
+	if total := quotas.total(); total != 1 {
+		t.Errorf("Expected only the generated summary to count against the quota, got %d", total)
+	}
+
+	resp, err = svc.ExportCollection(context.Background(), &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list", Format: ExportJSON})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var export exportedCollection
+	if err := json.Unmarshal(resp.Content, &export); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if export.Name != "Go Talks: 2026!" || len(export.Videos) != 3 || export.Videos[2].Summary != "Cached summary" || export.Videos[0].Error == "" {
+		t.Errorf("Expected every video with its summary or error, got %+v", export)
+	}
+	if notes := export.Videos[1].Notes; len(notes) != 1 || notes[0].Quote != "the key point" {
+		t.Errorf("Expected the note on the fresh video, got %+v", notes)
+	}
+
+	resp, err = svc.ExportCollection(context.Background(), &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list", Format: ExportHTML})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if doc := string(resp.Content); !strings.Contains(doc, "<h1>Go Talks: 2026!</h1>") || strings.Count(doc, "<article>") != 3 {
+		t.Errorf("Expected a document with every video, got:\n%s", doc)
+	}
+	resp, err = svc.ExportCollection(context.Background(), &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list", Format: ExportEPUB})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEPUB(t, resp.Content, 3)
+
+	if _, err := svc.ExportCollection(context.Background(), &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list", Format: "pdf"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
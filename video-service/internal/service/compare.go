@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	maxCompareVideos = 10
+	maxFocusLength   = 500
+)
+
+// SummarizeVideos summarizes each video and then writes a digest comparing
+// them, optionally focused on a question. Cached summaries are reused; every
+// other video counts against the summary quota. Videos that cannot be
+// summarized are reported in the response, left out of the digest and given
+// back.
+func (s *VideoService) SummarizeVideos(ctx context.Context, req *pb.SummarizeVideosRequest) (*pb.SummarizeVideosResponse, error) {
+	videoIDs := uniqueStrings(req.VideoIds)
+	log.Printf("Comparing %d videos for user: %s", len(videoIDs), req.UserId)
+	if len(videoIDs) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "at least two different videos are needed for a comparison")
+	}
+	if len(videoIDs) > maxCompareVideos {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d videos can be compared at once", maxCompareVideos)
+	}
+	focus := strings.TrimSpace(req.Focus)
+	if len(focus) > maxFocusLength {
+		return nil, status.Errorf(codes.InvalidArgument, "focus must be at most %d characters", maxFocusLength)
+	}
+	opts, err := models.ParseSummaryOptions("", "", req.Language)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	items := make([]*pb.VideoDigestItem, len(videoIDs))
+	for i, videoID := range videoIDs {
+		items[i] = &pb.VideoDigestItem{VideoId: videoID}
+	}
+	summaries, release, err := s.digestSummaries(ctx, req.UserId, items, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, req.UserId, "", models.OperationCompare, calls)
+	digest, err := s.llmClient.CompareVideos(llmCtx, summaries, focus, opts)
+	if err != nil {
+		log.Printf("Error comparing videos with LLM: %v", err)
+		release()
+		return nil, llmError("failed to compare videos", err)
+	}
+
+	log.Printf("Successfully compared %d of %d videos", len(summaries), len(videoIDs))
+	return &pb.SummarizeVideosResponse{
+		Digest:      digest,
+		Videos:      items,
+		GeneratedBy: calls.generatedBy(),
+	}, nil
+}
+
+// digestSummaries fills in the summary of each item, in parallel, for a
+// digest covering them. Cached summaries are reused; each other video counts
+// against the user's summary quota, which is given back if it cannot be
+// summarized. It returns the summaries to write the digest from, numbered by
+// position in items, and a function that gives back the remaining quota if
+// the digest itself fails.
+func (s *VideoService) digestSummaries(ctx context.Context, userID string, items []*pb.VideoDigestItem, opts models.SummaryOptions) ([]models.VideoSummary, func(), error) {
+	release, err := s.prepareSummaries(ctx, userID, items, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var summaries []models.VideoSummary
+	for i, item := range items {
+		if item.Error != "" {
+			continue
+		}
+		summaries = append(summaries, models.VideoSummary{
+			Number:       i + 1,
+			VideoID:      item.VideoId,
+			Title:        item.Title,
+			ChannelTitle: item.ChannelTitle,
+			PublishedAt:  item.PublishedAt,
+			Summary:      item.Summary,
+		})
+	}
+	if len(summaries) == 0 {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "none of the videos could be summarized: %s", items[0].Error)
+	}
+	return summaries, release, nil
+}
+
+// prepareSummaries fills in the summary of each item, in parallel. Cached
+// summaries are reused; each other video counts against the user's summary
+// quota, which is given back if it cannot be summarized, leaving the reason
+// in the item's Error. It returns a function that gives back the quota of
+// the videos that were summarized.
+func (s *VideoService) prepareSummaries(ctx context.Context, userID string, items []*pb.VideoDigestItem, opts models.SummaryOptions) (func(), error) {
+	releases := make([]func(), len(items))
+	releaseAll := func() {
+		for _, release := range releases {
+			if release != nil {
+				release()
+			}
+		}
+	}
+	for i, item := range items {
+		if item.Summary = s.cachedSummary(ctx, item.VideoId, opts); item.Summary != "" {
+			item.Cached = true
+			continue
+		}
+		release, err := s.checkQuota(ctx, userID, QuotaSummaries)
+		if err != nil {
+			releaseAll()
+			return nil, err
+		}
+		releases[i] = release
+	}
+
+	runBounded(ctx, len(items), s.mapReduce.withDefaults().Concurrency, func(ctx context.Context, i int) error {
+		s.summarizeForDigest(ctx, userID, items[i], opts)
+		return nil
+	})
+
+	for i, item := range items {
+		if item.Error != "" && releases[i] != nil {
+			releases[i]()
+			releases[i] = nil
+		}
+	}
+	return releaseAll, nil
+}
+
+// summarizeForDigest fills in the details of one video for a digest and,
+// unless it was cached, its summary. Failures are reported in the item's
+// Error rather than returned, so that one missing transcript does not sink
+// the whole digest.
+func (s *VideoService) summarizeForDigest(ctx context.Context, userID string, item *pb.VideoDigestItem, opts models.SummaryOptions) {
+	if item.Title == "" {
+		if video := s.videoMetadata(ctx, item.VideoId); video != nil {
+			item.Title = video.Title
+			item.ChannelTitle = video.ChannelTitle
+			item.PublishedAt = video.PublishedAt
+		}
+	}
+	if item.Summary != "" {
+		return
+	}
+
+	transcript, err := s.transcriptForSummary(ctx, &pb.SummarizeVideoRequest{VideoId: item.VideoId})
+	if err != nil {
+		item.Error = err.Error()
+		return
+	}
+
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, userID, item.VideoId, models.OperationSummarize, calls)
+	summary, err := s.summarizeTranscript(llmCtx, transcript.Text, opts, nil)
+	if err != nil {
+		log.Printf("Error summarizing video %s for a digest: %v", item.VideoId, err)
+		item.Error = status.Convert(llmError("failed to generate summary", err)).Message()
+		return
+	}
+	s.cacheSummary(ctx, item.VideoId, opts, summary)
+	item.Summary = summary
+	item.GeneratedBy = calls.generatedBy()
+}
+
+// videoMetadata returns a video's details from the cache or YouTube, or nil
+// if they are unavailable. Digests are still useful without titles.
+func (s *VideoService) videoMetadata(ctx context.Context, videoID string) *pb.VideoInfo {
+	if s.videoRepo == nil || s.youtubeClient == nil {
+		return nil
+	}
+	resp, err := s.GetVideoDetails(ctx, &pb.GetVideoDetailsRequest{VideoId: videoID})
+	if err != nil {
+		return nil
+	}
+	return resp.Video
+}
+
+// uniqueStrings returns the non-empty values in order, without duplicates.
+func uniqueStrings(values []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+func TestSummarizeVideos(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		videoID := r.URL.Query().Get("videoId")
+		if videoID == "missingVid1" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "no captions"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "Talk " + videoID})
+	}))
+	defer ts.Close()
+
+	newService := func(llm *MockLLMClient, store *MockQuotaStore) *VideoService {
+		return &VideoService{
+			llmClient:            llm,
+			quotas:               store,
+			planQuotas:           map[string]PlanQuotas{"free": {QuotaSummaries: {Daily: 3}}},
+			transcriptServiceURL: ts.URL,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		var compared []models.VideoSummary
+		var gotFocus string
+		llm := &MockLLMClient{
+			SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+				return "Summary of " + text, nil
+			},
+			CompareFunc: func(ctx context.Context, videos []models.VideoSummary, focus string) (string, error) {
+				compared, gotFocus = videos, focus
+				return "They agree [1][3].", nil
+			},
+		}
+		store := &MockQuotaStore{}
+		svc := newService(llm, store)
+
+		resp, err := svc.SummarizeVideos(context.Background(), &pb.SummarizeVideosRequest{
+			VideoIds: []string{"talkNumber1", "missingVid1", "talkNumber3", "talkNumber1"},
+			UserId:   "user-1",
+			Focus:    "  pricing  ",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Digest != "They agree [1][3]." || len(resp.Videos) != 3 {
+			t.Fatalf("Expected a digest and three deduplicated videos, got %+v", resp)
+		}
+		if resp.Videos[0].Summary != "Summary of Talk talkNumber1" || resp.Videos[1].Error == "" {
+			t.Errorf("Expected per-video summaries and errors, got %+v", resp.Videos)
+		}
+		if len(compared) != 2 || compared[0].Number != 1 || compared[1].Number != 3 || gotFocus != "pricing" {
+			t.Errorf("Expected videos 1 and 3 compared on the focus, got %+v, %q", compared, gotFocus)
+		}
+		if total := store.total(); total != 2 {
+			t.Errorf("Expected only the summarized videos to count against the quota, got %d", total)
+		}
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		svc := newService(&MockLLMClient{}, &MockQuotaStore{})
+		for _, req := range []*pb.SummarizeVideosRequest{
+			{VideoIds: []string{"talkNumber1", "talkNumber1"}},
+			{VideoIds: strings.Fields("a b c d e f g h i j k")},
+			{VideoIds: []string{"talkNumber1", "talkNumber2"}, Focus: strings.Repeat("x", maxFocusLength+1)},
+		} {
+			if _, err := svc.SummarizeVideos(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument for %v, got %v", req.VideoIds, err)
+			}
+		}
+	})
+
+	t.Run("QuotaCoversEveryVideo", func(t *testing.T) {
+		store := &MockQuotaStore{}
+		svc := newService(&MockLLMClient{}, store)
+		_, err := svc.SummarizeVideos(context.Background(), &pb.SummarizeVideosRequest{
+			VideoIds: []string{"talkNumber1", "talkNumber2", "talkNumber3", "talkNumber4"},
+			UserId:   "user-1",
+		})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("Expected ResourceExhausted, got %v", err)
+		}
+		if total := store.total(); total != 0 {
+			t.Errorf("Expected the quota given back, got %d", total)
+		}
+	})
+
+	t.Run("CompareFailure", func(t *testing.T) {
+		store := &MockQuotaStore{}
+		svc := newService(&MockLLMClient{
+			CompareFunc: func(ctx context.Context, videos []models.VideoSummary, focus string) (string, error) {
+				return "", errors.New("model down")
+			},
+		}, store)
+		_, err := svc.SummarizeVideos(context.Background(), &pb.SummarizeVideosRequest{
+			VideoIds: []string{"talkNumber1", "talkNumber2"},
+			UserId:   "user-1",
+		})
+		if err == nil || !strings.Contains(err.Error(), "failed to compare videos") {
+			t.Errorf("Expected the comparison error, got %v", err)
+		}
+		if total := store.total(); total != 0 {
+			t.Errorf("Expected the quota given back, got %d", total)
+		}
+	})
+}
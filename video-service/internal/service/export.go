@@ -0,0 +1,543 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+// Export formats.
+const (
+	ExportMarkdown = "md"
+	ExportJSON     = "json"
+	ExportHTML     = "html"
+	ExportEPUB     = "epub"
+)
+
+// transcriptParagraphSeconds is roughly how much of a video each paragraph
+// of an exported transcript covers.
+const transcriptParagraphSeconds = 60
+
+var filenameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+var exportContentTypes = map[string]string{
+	ExportMarkdown: "text/markdown; charset=utf-8",
+	ExportJSON:     "application/json",
+	ExportHTML:     "text/html; charset=utf-8",
+	ExportEPUB:     "application/epub+zip",
+}
+
+// exportedVideo is a video in an export.
+type exportedVideo struct {
+	VideoID         string   `json:"video_id"`
+	Title           string   `json:"title"`
+	ChannelTitle    string   `json:"channel_title"`
+	URL             string   `json:"url"`
+	PublishedAt     string   `json:"published_at,omitempty"`
+	DurationSeconds int64    `json:"duration_seconds,omitempty"`
+	AddedAt         string   `json:"added_at,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Summary         string   `json:"summary,omitempty"`
+	// Why the video has no summary.
+	Error      string              `json:"error,omitempty"`
+	Chapters   []exportedChapter   `json:"chapters,omitempty"`
+	Notes      []exportedNote      `json:"notes,omitempty"`
+	Transcript []exportedParagraph `json:"transcript,omitempty"`
+}
+
+// exportedChapter is a chapter of a video in an export.
+type exportedChapter struct {
+	StartSeconds float64 `json:"start_seconds"`
+	URL          string  `json:"url"`
+	Title        string  `json:"title"`
+}
+
+// exportedNote is a user's note on a video in an export.
+type exportedNote struct {
+	StartSeconds float64 `json:"start_seconds"`
+	EndSeconds   float64 `json:"end_seconds"`
+	URL          string  `json:"url"`
+	Quote        string  `json:"quote,omitempty"`
+	Text         string  `json:"text,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+// exportedParagraph is a stretch of a video's transcript in an export.
+type exportedParagraph struct {
+	StartSeconds float64 `json:"start_seconds"`
+	URL          string  `json:"url"`
+	Text         string  `json:"text"`
+}
+
+// exportedCollection is the JSON export of a collection. HTML and EPUB
+// exports of a single video use it too, with the video as the only entry.
+type exportedCollection struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	ExportedAt  string          `json:"exported_at"`
+	Videos      []exportedVideo `json:"videos"`
+}
+
+// ExportCollection renders a collection and the default summary of each of
+// its videos, with the user's notes on them, their cached chapters and,
+// optionally, their transcripts, as a single document. Cached summaries are
+// reused; every other video counts against the summary quota, and every
+// exported transcript against the transcript quota. Videos that cannot be
+// summarized are exported without a summary.
+func (s *VideoService) ExportCollection(ctx context.Context, req *pb.ExportCollectionRequest) (*pb.ExportCollectionResponse, error) {
+	format, err := exportFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.GetCollection(ctx, &pb.GetCollectionRequest{UserId: req.UserId, CollectionId: req.CollectionId})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Exporting collection %s with %d videos for user: %s", collection.CollectionId, len(collection.Items), req.UserId)
+
+	videoIDs := make([]string, len(collection.Items))
+	items := make([]*pb.VideoDigestItem, len(collection.Items))
+	for i, item := range collection.Items {
+		videoIDs[i] = item.VideoId
+		items[i] = &pb.VideoDigestItem{
+			VideoId:      item.VideoId,
+			Title:        item.Title,
+			ChannelTitle: item.ChannelTitle,
+			PublishedAt:  item.PublishedAt,
+		}
+	}
+	if len(items) > 0 {
+		opts, _ := models.ParseSummaryOptions("", "", "")
+		if _, err := s.prepareSummaries(ctx, req.UserId, items, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	notes, err := s.videoNotes(ctx, req.UserId, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	export := exportedCollection{
+		Name:        collection.Name,
+		Description: collection.Description,
+		ExportedAt:  time.Now().UTC().Format(time.RFC3339),
+		Videos:      make([]exportedVideo, len(items)),
+	}
+	for i, item := range items {
+		video := &export.Videos[i]
+		*video = exportedVideo{
+			VideoID:      item.VideoId,
+			Title:        item.Title,
+			ChannelTitle: item.ChannelTitle,
+			URL:          videoURL(item.VideoId),
+			PublishedAt:  item.PublishedAt,
+			AddedAt:      collection.Items[i].AddedAt,
+			Tags:         collection.Items[i].Tags,
+			Summary:      item.Summary,
+			Error:        item.Error,
+			Notes:        exportNotes(item.VideoId, notes[item.VideoId]),
+		}
+		if cached := s.cachedChapters(ctx, item.VideoId); cached != nil {
+			video.Chapters = exportChapters(item.VideoId, cached.Chapters)
+		}
+	}
+	if req.Transcript {
+		if err := s.exportTranscripts(ctx, req.UserId, collection.CollectionId, export.Videos); err != nil {
+			return nil, err
+		}
+	}
+
+	var content []byte
+	switch format {
+	case ExportJSON:
+		content, err = json.MarshalIndent(export, "", "  ")
+	case ExportHTML:
+		content, err = renderExportHTML(&export, false)
+	case ExportEPUB:
+		content, err = renderExportEPUB(&export, "collection:"+collection.CollectionId, false)
+	default:
+		content = []byte(renderCollectionMarkdown(&export))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render export: %w", err)
+	}
+	return &pb.ExportCollectionResponse{
+		Filename:    exportFilename(collection.Name, collection.CollectionId) + "." + format,
+		ContentType: exportContentTypes[format],
+		Content:     content,
+	}, nil
+}
+
+// ExportVideo renders one video as a document for a knowledge base: its
+// details, default summary, chapters, the user's tags and notes and,
+// optionally, its transcript. The summary and chapters are generated if
+// they are not cached yet, counting against the quotas like the transcript.
+func (s *VideoService) ExportVideo(ctx context.Context, req *pb.ExportVideoRequest) (*pb.ExportVideoResponse, error) {
+	format, err := exportFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	if !videoIDPattern.MatchString(req.VideoId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid video id %q", req.VideoId)
+	}
+	log.Printf("Exporting video %s as %s for user: %s", req.VideoId, format, req.UserId)
+
+	video := exportedVideo{VideoID: req.VideoId, URL: videoURL(req.VideoId)}
+	if req.Transcript {
+		release, err := s.checkQuota(ctx, req.UserId, QuotaTranscripts)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.exportTranscript(ctx, &video); err != nil {
+			release()
+			return nil, fmt.Errorf("failed to fetch transcript: %w", err)
+		}
+	}
+
+	item := &pb.VideoDigestItem{VideoId: req.VideoId}
+	if info := s.videoMetadata(ctx, req.VideoId); info != nil {
+		item.Title = info.Title
+		item.ChannelTitle = info.ChannelTitle
+		item.PublishedAt = info.PublishedAt
+		if info.DurationSeconds > 0 {
+			video.DurationSeconds = info.DurationSeconds
+		}
+	}
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	if _, err := s.prepareSummaries(ctx, req.UserId, []*pb.VideoDigestItem{item}, opts); err != nil {
+		return nil, err
+	}
+	video.Title = item.Title
+	video.ChannelTitle = item.ChannelTitle
+	video.PublishedAt = item.PublishedAt
+	video.Summary = item.Summary
+	video.Error = item.Error
+
+	// Chapters, tags and notes make the document richer but are not needed
+	// for it.
+	if s.chapters != nil && item.Error == "" {
+		chapters, err := s.GenerateChapters(ctx, &pb.GenerateChaptersRequest{VideoId: req.VideoId, UserId: req.UserId})
+		if err != nil {
+			log.Printf("Exporting video %s without chapters: %v", req.VideoId, err)
+		} else {
+			for _, c := range chapters.Chapters {
+				video.Chapters = append(video.Chapters, exportedChapter{
+					StartSeconds: c.StartSeconds,
+					URL:          timestampURL(req.VideoId, c.StartSeconds),
+					Title:        c.Title,
+				})
+			}
+		}
+	}
+	if s.collections != nil {
+		if tags, err := s.collections.FindVideoTags(ctx, req.UserId, []string{req.VideoId}); err != nil {
+			log.Printf("Exporting video %s without tags: %v", req.VideoId, err)
+		} else if len(tags) > 0 {
+			video.Tags = tags[0].Tags
+		}
+	}
+	if notes, err := s.videoNotes(ctx, req.UserId, []string{req.VideoId}); err != nil {
+		log.Printf("Exporting video %s without notes: %v", req.VideoId, err)
+	} else {
+		video.Notes = exportNotes(req.VideoId, notes[req.VideoId])
+	}
+
+	title := video.Title
+	if title == "" {
+		title = video.VideoID
+	}
+	export := exportedCollection{
+		Name:       title,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Videos:     []exportedVideo{video},
+	}
+	var content []byte
+	switch format {
+	case ExportJSON:
+		content, err = renderNotionJSON(&video)
+	case ExportHTML:
+		content, err = renderExportHTML(&export, true)
+	case ExportEPUB:
+		content, err = renderExportEPUB(&export, "video:"+req.VideoId, true)
+	default:
+		content = []byte(renderVideoMarkdown(&video, export.ExportedAt))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render export: %w", err)
+	}
+	return &pb.ExportVideoResponse{
+		Filename:    exportFilename(video.Title, video.VideoID) + "." + format,
+		ContentType: exportContentTypes[format],
+		Content:     content,
+	}, nil
+}
+
+// exportTranscripts adds the transcripts of a collection's videos, each
+// counting against the user's transcript quota. Nothing is fetched when the
+// quota does not cover them all.
+func (s *VideoService) exportTranscripts(ctx context.Context, userID, collectionID string, videos []exportedVideo) error {
+	releases := make([]func(), len(videos))
+	for i := range videos {
+		release, err := s.checkQuota(ctx, userID, QuotaTranscripts)
+		if err != nil {
+			for _, release := range releases[:i] {
+				release()
+			}
+			return err
+		}
+		releases[i] = release
+	}
+
+	// A missing transcript is already explained by the summary's error.
+	runBounded(ctx, len(videos), s.mapReduce.withDefaults().Concurrency, func(ctx context.Context, i int) error {
+		if err := s.exportTranscript(ctx, &videos[i]); err != nil {
+			log.Printf("Exporting collection %s without the transcript of %s: %v", collectionID, videos[i].VideoID, err)
+			releases[i]()
+		}
+		return nil
+	})
+	return nil
+}
+
+// exportTranscript adds a video's transcript to its export in paragraphs of
+// about a minute, and its duration if that is not known yet.
+func (s *VideoService) exportTranscript(ctx context.Context, video *exportedVideo) error {
+	transcript, err := s.fetchTranscript(ctx, video.VideoID)
+	if err != nil {
+		return err
+	}
+	if len(transcript.Segments) == 0 {
+		video.Transcript = []exportedParagraph{{URL: video.URL, Text: strings.TrimSpace(transcript.Text)}}
+		return nil
+	}
+
+	var paragraph *exportedParagraph
+	for _, seg := range transcript.Segments {
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		if paragraph == nil || seg.Start >= paragraph.StartSeconds+transcriptParagraphSeconds {
+			video.Transcript = append(video.Transcript, exportedParagraph{
+				StartSeconds: seg.Start,
+				URL:          timestampURL(video.VideoID, seg.Start),
+				Text:         text,
+			})
+			paragraph = &video.Transcript[len(video.Transcript)-1]
+			continue
+		}
+		paragraph.Text += " " + text
+	}
+	if video.DurationSeconds == 0 {
+		last := transcript.Segments[len(transcript.Segments)-1]
+		video.DurationSeconds = int64(last.Start + last.Duration)
+	}
+	return nil
+}
+
+// cachedChapters returns the chapters generated for a video earlier, if any.
+func (s *VideoService) cachedChapters(ctx context.Context, videoID string) *models.VideoChapters {
+	if s.chapters == nil {
+		return nil
+	}
+	cached, err := s.chapters.Get(ctx, videoID)
+	if err != nil {
+		log.Printf("Error loading cached chapters for video %s: %v", videoID, err)
+		return nil
+	}
+	return cached
+}
+
+func exportChapters(videoID string, chapters []models.Chapter) []exportedChapter {
+	var result []exportedChapter
+	for _, c := range chapters {
+		result = append(result, exportedChapter{
+			StartSeconds: c.StartSeconds,
+			URL:          timestampURL(videoID, c.StartSeconds),
+			Title:        c.Title,
+		})
+	}
+	return result
+}
+
+func exportNotes(videoID string, notes []models.Note) []exportedNote {
+	var result []exportedNote
+	for _, note := range notes {
+		result = append(result, exportedNote{
+			StartSeconds: note.StartSeconds,
+			EndSeconds:   note.EndSeconds,
+			URL:          timestampURL(videoID, note.StartSeconds),
+			Quote:        note.Quote,
+			Text:         note.Text,
+			CreatedAt:    note.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+// renderCollectionMarkdown writes a collection as one Markdown document with
+// a section per video.
+func renderCollectionMarkdown(export *exportedCollection) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", export.Name)
+	if export.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", export.Description)
+	}
+	fmt.Fprintf(&b, "_Exported from TextTube on %s, %d videos._\n", export.ExportedAt[:10], len(export.Videos))
+	for _, video := range export.Videos {
+		title := video.Title
+		if title == "" {
+			title = video.VideoID
+		}
+		fmt.Fprintf(&b, "\n## [%s](%s)\n\n", title, video.URL)
+		var meta []string
+		if video.ChannelTitle != "" {
+			meta = append(meta, video.ChannelTitle)
+		}
+		if len(video.PublishedAt) >= 10 {
+			meta = append(meta, "published "+video.PublishedAt[:10])
+		}
+		for _, tag := range video.Tags {
+			meta = append(meta, "#"+strings.ReplaceAll(tag, " ", "-"))
+		}
+		if len(meta) > 0 {
+			fmt.Fprintf(&b, "%s\n\n", strings.Join(meta, " · "))
+		}
+		if video.Summary != "" {
+			fmt.Fprintf(&b, "%s\n", strings.TrimSpace(video.Summary))
+		} else {
+			fmt.Fprintf(&b, "_No summary: %s_\n", video.Error)
+		}
+		writeVideoSections(&b, &video, "###")
+	}
+	return b.String()
+}
+
+// renderVideoMarkdown writes a video as a Markdown document with YAML
+// front matter, as note-taking apps like Obsidian read it.
+func renderVideoMarkdown(video *exportedVideo, exportedAt string) string {
+	title := video.Title
+	if title == "" {
+		title = video.VideoID
+	}
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", yamlString(title))
+	if video.ChannelTitle != "" {
+		fmt.Fprintf(&b, "channel: %s\n", yamlString(video.ChannelTitle))
+	}
+	fmt.Fprintf(&b, "url: %s\n", yamlString(video.URL))
+	if len(video.PublishedAt) >= 10 {
+		fmt.Fprintf(&b, "published: %s\n", video.PublishedAt[:10])
+	}
+	if video.DurationSeconds > 0 {
+		fmt.Fprintf(&b, "duration: %s\n", yamlString(helpers.FormatTimestamp(float64(video.DurationSeconds))))
+	}
+	if len(video.Tags) > 0 {
+		tags, _ := json.Marshal(video.Tags)
+		fmt.Fprintf(&b, "tags: %s\n", tags)
+	}
+	fmt.Fprintf(&b, "exported: %s\n", exportedAt)
+	b.WriteString("---\n\n")
+
+	fmt.Fprintf(&b, "# %s\n\n## Summary\n\n", title)
+	if video.Summary != "" {
+		fmt.Fprintf(&b, "%s\n", strings.TrimSpace(video.Summary))
+	} else {
+		fmt.Fprintf(&b, "_No summary: %s_\n", video.Error)
+	}
+	writeVideoSections(&b, video, "##")
+	return b.String()
+}
+
+// writeVideoSections writes the chapters, notes and transcript of a video
+// under headings of the given level.
+func writeVideoSections(b *strings.Builder, video *exportedVideo, heading string) {
+	if len(video.Chapters) > 0 {
+		fmt.Fprintf(b, "\n%s Chapters\n\n", heading)
+		for _, c := range video.Chapters {
+			fmt.Fprintf(b, "- [%s](%s) %s\n", helpers.FormatTimestamp(c.StartSeconds), c.URL, c.Title)
+		}
+	}
+	if len(video.Notes) > 0 {
+		fmt.Fprintf(b, "\n%s Notes\n\n", heading)
+		for _, note := range video.Notes {
+			renderNoteMarkdown(b, note)
+		}
+	}
+	if len(video.Transcript) > 0 {
+		fmt.Fprintf(b, "\n%s Transcript\n", heading)
+		for _, p := range video.Transcript {
+			fmt.Fprintf(b, "\n[%s](%s) %s\n", helpers.FormatTimestamp(p.StartSeconds), p.URL, p.Text)
+		}
+	}
+}
+
+// renderNoteMarkdown writes a note as a list item linking to where it
+// starts, with its quote as an indented block quote.
+func renderNoteMarkdown(b *strings.Builder, note exportedNote) {
+	fmt.Fprintf(b, "- [%s](%s)", helpers.FormatTimestamp(note.StartSeconds), note.URL)
+	if note.EndSeconds > note.StartSeconds {
+		fmt.Fprintf(b, "–%s", helpers.FormatTimestamp(note.EndSeconds))
+	}
+	if note.Text != "" {
+		fmt.Fprintf(b, " %s", strings.ReplaceAll(note.Text, "\n", "\n  "))
+	}
+	b.WriteString("\n")
+	if note.Quote != "" {
+		fmt.Fprintf(b, "\n  > %s\n\n", strings.ReplaceAll(note.Quote, "\n", "\n  > "))
+	}
+}
+
+// yamlString quotes a front matter value. JSON strings are valid YAML
+// double-quoted scalars.
+func yamlString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// exportFormat validates an export format, defaulting to Markdown.
+func exportFormat(format string) (string, error) {
+	if format == "" {
+		return ExportMarkdown, nil
+	}
+	if _, ok := exportContentTypes[format]; !ok {
+		return "", status.Errorf(codes.InvalidArgument, "unknown export format %q", format)
+	}
+	return format, nil
+}
+
+func videoURL(videoID string) string {
+	return "https://www.youtube.com/watch?v=" + videoID
+}
+
+// timestampURL links to a moment of a video.
+func timestampURL(videoID string, seconds float64) string {
+	return fmt.Sprintf("%s&t=%d", videoURL(videoID), int(seconds))
+}
+
+// exportFilename makes a file name from a title, falling back to id when
+// nothing of the title is left.
+func exportFilename(title, id string) string {
+	name := strings.Trim(filenameUnsafe.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(name) > 60 {
+		name = strings.TrimRight(name[:60], "-")
+	}
+	if name == "" {
+		return id
+	}
+	return name
+}
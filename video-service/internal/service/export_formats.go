@@ -0,0 +1,311 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+
+	"videoservice/internal/client/helpers"
+)
+
+// notionTextLimit is the most characters Notion takes in one rich text
+// object.
+const notionTextLimit = 2000
+
+//go:embed templates/export.tmpl
+var exportTemplateFS embed.FS
+
+var (
+	markdownNumbered = regexp.MustCompile(`^\d+[.)]\s+`)
+
+	// Summaries are rendered without raw HTML and without links to
+	// dangerous URLs, goldmark's defaults. EPUB needs XHTML.
+	exportMarkdown = goldmark.New(goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify))
+	epubMarkdown   = goldmark.New(
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify),
+		goldmark.WithRendererOptions(html.WithXHTML()),
+	)
+
+	exportHTMLTemplates = parseExportTemplates(exportMarkdown)
+	epubTemplates       = parseExportTemplates(epubMarkdown)
+)
+
+func parseExportTemplates(md goldmark.Markdown) *template.Template {
+	funcs := template.FuncMap{
+		"timestamp": helpers.FormatTimestamp,
+		"duration":  func(seconds int64) string { return helpers.FormatTimestamp(float64(seconds)) },
+		"date": func(value string) string {
+			if len(value) < 10 {
+				return value
+			}
+			return value[:10]
+		},
+		"markdown": func(src string) (template.HTML, error) {
+			var buf bytes.Buffer
+			if err := md.Convert([]byte(src), &buf); err != nil {
+				return "", err
+			}
+			return template.HTML(buf.String()), nil
+		},
+		"inc": func(i int) int { return i + 1 },
+		"videoView": func(video exportedVideo, nested bool) videoView {
+			return videoView{video, nested}
+		},
+	}
+	return template.Must(template.New("export").Funcs(funcs).ParseFS(exportTemplateFS, "templates/export.tmpl"))
+}
+
+// exportView is what the export templates render: a collection, or a single
+// video when Single is set.
+type exportView struct {
+	*exportedCollection
+	Single bool
+}
+
+// videoView is one video in the export templates. Nested videos are
+// sections of a collection document rather than documents of their own.
+type videoView struct {
+	exportedVideo
+	Nested bool
+}
+
+// renderExportHTML writes a standalone HTML document laid out for printing
+// to PDF, one video per page.
+func renderExportHTML(export *exportedCollection, single bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := exportHTMLTemplates.ExecuteTemplate(&buf, "document.html", exportView{export, single}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// epubFile is a file of an EPUB book and the template that renders it.
+type epubFile struct {
+	name     string
+	template string
+	data     interface{}
+}
+
+// renderExportEPUB writes an EPUB 3 book with a chapter per video. id
+// identifies what was exported in the book's metadata.
+func renderExportEPUB(export *exportedCollection, id string, single bool) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	// The mimetype comes first and uncompressed so that readers can detect
+	// the format.
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write([]byte("application/epub+zip")); err != nil {
+		return nil, err
+	}
+
+	view := exportView{export, single}
+	files := []epubFile{
+		{"META-INF/container.xml", "container.xml", nil},
+		{"OEBPS/content.opf", "content.opf", struct {
+			exportView
+			ID string
+		}{view, id}},
+		{"OEBPS/nav.xhtml", "nav.xhtml", view},
+		{"OEBPS/style.css", "style.css", nil},
+	}
+	for i, video := range export.Videos {
+		files = append(files, epubFile{fmt.Sprintf("OEBPS/video-%d.xhtml", i+1), "chapter.xhtml", videoView{video, false}})
+	}
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if err := epubTemplates.ExecuteTemplate(w, file.template, file.data); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// notionPage is a video in the shape of a Notion page: plain properties for
+// a database row and the page content as Notion API blocks, ready to append
+// with the blocks endpoint.
+type notionPage struct {
+	Properties notionProperties `json:"properties"`
+	Children   []notionBlock    `json:"children"`
+}
+
+type notionProperties struct {
+	Title     string   `json:"Title"`
+	Channel   string   `json:"Channel,omitempty"`
+	URL       string   `json:"URL"`
+	Published string   `json:"Published,omitempty"`
+	Duration  string   `json:"Duration,omitempty"`
+	Tags      []string `json:"Tags,omitempty"`
+}
+
+type notionBlock map[string]interface{}
+
+type notionRichText struct {
+	Type        string             `json:"type"`
+	Text        notionText         `json:"text"`
+	Annotations *notionAnnotations `json:"annotations,omitempty"`
+}
+
+type notionText struct {
+	Content string      `json:"content"`
+	Link    *notionLink `json:"link,omitempty"`
+}
+
+type notionLink struct {
+	URL string `json:"url"`
+}
+
+type notionAnnotations struct {
+	Bold   bool `json:"bold,omitempty"`
+	Italic bool `json:"italic,omitempty"`
+}
+
+// renderNotionJSON writes a video as a Notion-style page.
+func renderNotionJSON(video *exportedVideo) ([]byte, error) {
+	page := notionPage{
+		Properties: notionProperties{
+			Title:   video.Title,
+			Channel: video.ChannelTitle,
+			URL:     video.URL,
+			Tags:    video.Tags,
+		},
+		Children: []notionBlock{notionHeading("Summary")},
+	}
+	if page.Properties.Title == "" {
+		page.Properties.Title = video.VideoID
+	}
+	if len(video.PublishedAt) >= 10 {
+		page.Properties.Published = video.PublishedAt[:10]
+	}
+	if video.DurationSeconds > 0 {
+		page.Properties.Duration = helpers.FormatTimestamp(float64(video.DurationSeconds))
+	}
+
+	if video.Summary != "" {
+		page.Children = append(page.Children, markdownToNotion(video.Summary)...)
+	} else {
+		page.Children = append(page.Children, newNotionBlock("paragraph", notionPlain("No summary: "+video.Error, &notionAnnotations{Italic: true})))
+	}
+	if len(video.Chapters) > 0 {
+		page.Children = append(page.Children, notionHeading("Chapters"))
+		for _, c := range video.Chapters {
+			page.Children = append(page.Children, newNotionBlock("bulleted_list_item",
+				append(notionTimestamp(c.StartSeconds, c.URL), notionPlain(" "+c.Title, nil)...)))
+		}
+	}
+	if len(video.Notes) > 0 {
+		page.Children = append(page.Children, notionHeading("Notes"))
+		for _, note := range video.Notes {
+			text := notionTimestamp(note.StartSeconds, note.URL)
+			if note.Quote != "" {
+				text = append(text, notionPlain(" “"+note.Quote+"”", &notionAnnotations{Italic: true})...)
+			}
+			if note.Text != "" {
+				text = append(text, notionPlain(" "+note.Text, nil)...)
+			}
+			page.Children = append(page.Children, newNotionBlock("bulleted_list_item", text))
+		}
+	}
+	if len(video.Transcript) > 0 {
+		page.Children = append(page.Children, notionHeading("Transcript"))
+		for _, p := range video.Transcript {
+			page.Children = append(page.Children, newNotionBlock("paragraph",
+				append(notionTimestamp(p.StartSeconds, p.URL), notionPlain(" "+p.Text, nil)...)))
+		}
+	}
+	return json.MarshalIndent(page, "", "  ")
+}
+
+// markdownToNotion turns the Markdown of a summary into Notion blocks:
+// headings, list items, quotes and paragraphs, keeping bold text.
+func markdownToNotion(src string) []notionBlock {
+	var blocks []notionBlock
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, newNotionBlock("paragraph", notionInline(strings.Join(paragraph, " "))))
+			paragraph = nil
+		}
+	}
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			flush()
+			blocks = append(blocks, newNotionBlock("heading_3", notionInline(strings.TrimLeft(line, "# "))))
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			flush()
+			blocks = append(blocks, newNotionBlock("bulleted_list_item", notionInline(line[2:])))
+		case markdownNumbered.MatchString(line):
+			flush()
+			blocks = append(blocks, newNotionBlock("numbered_list_item", notionInline(markdownNumbered.ReplaceAllString(line, ""))))
+		case strings.HasPrefix(line, ">"):
+			flush()
+			blocks = append(blocks, newNotionBlock("quote", notionInline(strings.TrimSpace(line[1:]))))
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+func newNotionBlock(kind string, text []notionRichText) notionBlock {
+	return notionBlock{"object": "block", "type": kind, kind: map[string]interface{}{"rich_text": text}}
+}
+
+func notionHeading(title string) notionBlock {
+	return newNotionBlock("heading_2", notionPlain(title, nil))
+}
+
+func notionTimestamp(seconds float64, url string) []notionRichText {
+	return []notionRichText{{Type: "text", Text: notionText{Content: helpers.FormatTimestamp(seconds), Link: &notionLink{URL: url}}}}
+}
+
+// notionInline splits text on ** into plain and bold runs.
+func notionInline(text string) []notionRichText {
+	var result []notionRichText
+	for i, part := range strings.Split(text, "**") {
+		var annotations *notionAnnotations
+		if i%2 == 1 {
+			annotations = &notionAnnotations{Bold: true}
+		}
+		result = append(result, notionPlain(part, annotations)...)
+	}
+	return result
+}
+
+// notionPlain makes rich text of text, split into pieces Notion accepts.
+func notionPlain(text string, annotations *notionAnnotations) []notionRichText {
+	var result []notionRichText
+	for text != "" {
+		piece := text
+		if utf8.RuneCountInString(piece) > notionTextLimit {
+			piece = string([]rune(piece)[:notionTextLimit])
+		}
+		text = text[len(piece):]
+		result = append(result, notionRichText{Type: "text", Text: notionText{Content: piece}, Annotations: annotations})
+	}
+	return result
+}
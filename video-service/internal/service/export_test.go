@@ -0,0 +1,182 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+func TestExportVideo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("videoId") == "missingVid1" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "no captions"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"transcript": "hello and welcome today we cover go then rust",
+			"segments": []map[string]interface{}{
+				{"text": "hello and welcome", "start": 3.0, "duration": 5.0},
+				{"text": "today we cover go", "start": 60.0, "duration": 5.0},
+				{"text": "then rust & more", "start": 125.0, "duration": 5.0},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	svc := &VideoService{
+		llmClient: &MockLLMClient{SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			return "## Key points\n\n- **Go** is <fast>\n- Rust is safe\n\nSee [the docs](javascript:alert(1)).", nil
+		}},
+		chapters: &MockChapterStore{Chapters: map[string]*models.VideoChapters{
+			"talkNumber1": {VideoID: "talkNumber1", Chapters: []models.Chapter{{Title: "Intro", StartSeconds: 0}, {Title: "Go", StartSeconds: 60}}},
+		}},
+		collections: &MockCollectionStore{},
+		notes: &MockNoteStore{Notes: map[string]models.Note{
+			"note": {ID: "note", UserID: "user-1", VideoID: "talkNumber1", StartSeconds: 60, EndSeconds: 65, Quote: "today we cover go", Text: "The main part"},
+		}},
+		summaries:            &MockSummaryStore{Summaries: map[string]*models.CachedSummary{}},
+		quotas:               &MockQuotaStore{},
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaSummaries: {Daily: 5}}},
+		transcriptServiceURL: ts.URL,
+	}
+	ctx := context.Background()
+	svc.SetVideoTags(ctx, &pb.SetVideoTagsRequest{UserId: "user-1", VideoId: "talkNumber1", Tags: []string{"go"}})
+	export := func(format string) *pb.ExportVideoResponse {
+		t.Helper()
+		resp, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "talkNumber1", Format: format, Transcript: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return resp
+	}
+
+	t.Run("Markdown", func(t *testing.T) {
+		resp := export("")
+		doc := string(resp.Content)
+		if resp.Filename != "talkNumber1.md" || !strings.HasPrefix(resp.ContentType, "text/markdown") {
+			t.Errorf("Expected a Markdown file, got %q %q", resp.Filename, resp.ContentType)
+		}
+		for _, want := range []string{
+			"---\ntitle: \"talkNumber1\"\n",
+			"url: \"https://www.youtube.com/watch?v=talkNumber1\"\n",
+			"duration: \"2:10\"\n",
+			"tags: [\"go\"]\n",
+			"# talkNumber1\n\n## Summary\n\n## Key points",
+			"## Chapters\n\n- [0:00](https://www.youtube.com/watch?v=talkNumber1&t=0) Intro\n- [1:00](https://www.youtube.com/watch?v=talkNumber1&t=60) Go\n",
+			"## Notes\n\n- [1:00](https://www.youtube.com/watch?v=talkNumber1&t=60)–1:05 The main part\n\n  > today we cover go",
+			"## Transcript\n\n[0:03](https://www.youtube.com/watch?v=talkNumber1&t=3) hello and welcome today we cover go\n\n[2:05](https://www.youtube.com/watch?v=talkNumber1&t=125) then rust & more\n",
+		} {
+			if !strings.Contains(doc, want) {
+				t.Errorf("Expected the export to contain %q, got:\n%s", want, doc)
+			}
+		}
+	})
+
+	t.Run("NotionJSON", func(t *testing.T) {
+		var page struct {
+			Properties map[string]interface{} `json:"properties"`
+			Children   []map[string]json.RawMessage
+		}
+		if err := json.Unmarshal(export(ExportJSON).Content, &page); err != nil {
+			t.Fatalf("Expected JSON, got %v", err)
+		}
+		if page.Properties["Duration"] != "2:10" || page.Properties["URL"] != "https://www.youtube.com/watch?v=talkNumber1" {
+			t.Errorf("Expected the video's properties, got %v", page.Properties)
+		}
+		var types []string
+		for _, block := range page.Children {
+			var kind string
+			json.Unmarshal(block["type"], &kind)
+			types = append(types, kind)
+		}
+		want := "heading_2 heading_3 bulleted_list_item bulleted_list_item paragraph heading_2 bulleted_list_item bulleted_list_item heading_2 bulleted_list_item heading_2 paragraph paragraph"
+		if got := strings.Join(types, " "); got != want {
+			t.Errorf("Expected blocks %q, got %q", want, got)
+		}
+		if !bytes.Contains(page.Children[2]["bulleted_list_item"], []byte(`"bold": true`)) {
+			t.Errorf("Expected bold text kept, got %s", page.Children[2]["bulleted_list_item"])
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		doc := string(export(ExportHTML).Content)
+		for _, want := range []string{"<h1>talkNumber1</h1>", "<strong>Go</strong> is", "@page", "<blockquote>today we cover go</blockquote>", "then rust &amp; more"} {
+			if !strings.Contains(doc, want) {
+				t.Errorf("Expected the export to contain %q, got:\n%s", want, doc)
+			}
+		}
+		if strings.Contains(doc, "<fast>") || strings.Contains(doc, "javascript:") {
+			t.Errorf("Expected raw HTML and dangerous links dropped from the summary, got:\n%s", doc)
+		}
+	})
+
+	t.Run("EPUB", func(t *testing.T) {
+		resp := export(ExportEPUB)
+		if resp.ContentType != "application/epub+zip" {
+			t.Errorf("Expected an EPUB, got %q", resp.ContentType)
+		}
+		checkEPUB(t, resp.Content, 1)
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		if _, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "talkNumber1", Format: "pdf"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for an unknown format, got %v", err)
+		}
+		if _, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "nope"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for a bad video id, got %v", err)
+		}
+		if _, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "missingVid1", Transcript: true}); err == nil {
+			t.Error("Expected an error when the transcript is missing")
+		}
+	})
+}
+
+// checkEPUB checks that an EPUB starts with its uncompressed mimetype and
+// that its XML files are well-formed.
+func checkEPUB(t *testing.T, content []byte, videos int) {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("Expected a zip file, got %v", err)
+	}
+	if first := r.File[0]; first.Name != "mimetype" || first.Method != zip.Store {
+		t.Errorf("Expected an uncompressed mimetype first, got %s", first.Name)
+	}
+	chapters := 0
+	for _, f := range r.File {
+		if strings.HasSuffix(f.Name, ".xhtml") && strings.Contains(f.Name, "video-") {
+			chapters++
+		}
+		if !strings.HasSuffix(f.Name, ".xhtml") && !strings.HasSuffix(f.Name, ".xml") && !strings.HasSuffix(f.Name, ".opf") {
+			continue
+		}
+		rc, _ := f.Open()
+		dec := xml.NewDecoder(rc)
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("Expected %s to be well-formed, got %v", f.Name, err)
+				break
+			}
+		}
+		rc.Close()
+	}
+	if chapters != videos {
+		t.Errorf("Expected %d chapters, got %d", videos, chapters)
+	}
+}
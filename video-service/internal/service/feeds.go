@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultSummaryFeedLimit = 20
+	maxSummaryFeedLimit     = 50
+)
+
+// FeedTokenStore persists the secrets in the URLs of users' summary feeds.
+type FeedTokenStore interface {
+	// SaveFeedToken sets a user's feed token, replacing any previous one.
+	SaveFeedToken(ctx context.Context, token *models.FeedToken) error
+	// GetFeedToken returns a user's feed token, or nil if they have none.
+	GetFeedToken(ctx context.Context, userID string) (*models.FeedToken, error)
+	// FindFeedToken returns the feed token with the given secret, or nil if
+	// there is none.
+	FindFeedToken(ctx context.Context, token string) (*models.FeedToken, error)
+}
+
+// GetFeedToken returns the token of a user's summary feed, creating it on
+// first use or replacing it when asked to.
+func (s *VideoService) GetFeedToken(ctx context.Context, req *pb.GetFeedTokenRequest) (*pb.FeedToken, error) {
+	if s.feedTokens == nil {
+		return nil, fmt.Errorf("feeds are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	if !req.Rotate {
+		token, err := s.feedTokens.GetFeedToken(ctx, req.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load feed token: %w", err)
+		}
+		if token != nil {
+			return convertFeedTokenToProto(token), nil
+		}
+	}
+
+	secret, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	token := &models.FeedToken{UserID: req.UserId, Token: secret, CreatedAt: time.Now()}
+	if err := s.feedTokens.SaveFeedToken(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to save feed token: %w", err)
+	}
+	log.Printf("Created feed token for user: %s", req.UserId)
+	return convertFeedTokenToProto(token), nil
+}
+
+// GetSummaryFeed returns the recent uploads of the channels a feed token's
+// user follows, or of one channel someone follows, that have a cached
+// default summary.
+// Nothing is summarized here: feed readers poll without anyone asking, so
+// summaries come from users and subscription prefetching.
+func (s *VideoService) GetSummaryFeed(ctx context.Context, req *pb.GetSummaryFeedRequest) (*pb.SummaryFeed, error) {
+	if (req.FeedToken == "") == (req.ChannelId == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of feed token and channel id is required")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSummaryFeedLimit
+	}
+	if limit < 0 || limit > maxSummaryFeedLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxSummaryFeedLimit)
+	}
+
+	feed := &pb.SummaryFeed{}
+	var uploads []*pb.SummaryFeedItem
+	if req.FeedToken != "" {
+		var err error
+		if uploads, err = s.followedUploads(ctx, req.FeedToken); err != nil {
+			return nil, err
+		}
+	} else {
+		if !channelIDPattern.MatchString(req.ChannelId) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %q", req.ChannelId)
+		}
+		// Only followed channels have feeds, so that anyone cannot make the
+		// service fetch the uploads of any channel from YouTube.
+		if s.subscriptions == nil {
+			return nil, fmt.Errorf("subscriptions are not configured")
+		}
+		subs, err := s.subscriptions.ChannelSubscribers(ctx, req.ChannelId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscribers: %w", err)
+		}
+		if len(subs) == 0 {
+			return nil, status.Errorf(codes.NotFound, "no feed for channel %s", req.ChannelId)
+		}
+		if s.videoRepo == nil || s.youtubeClient == nil {
+			return nil, fmt.Errorf("channel videos are not configured")
+		}
+		videos, err := s.GetChannelVideos(ctx, &pb.GetChannelVideosRequest{ChannelId: req.ChannelId, MaxResults: maxSummaryFeedLimit})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channel videos: %w", err)
+		}
+		feed.ChannelId = req.ChannelId
+		for _, video := range videos.Videos {
+			feed.ChannelTitle = video.ChannelTitle
+			uploads = append(uploads, &pb.SummaryFeedItem{
+				VideoId:      video.VideoId,
+				Title:        video.Title,
+				ChannelId:    video.ChannelId,
+				ChannelTitle: video.ChannelTitle,
+				ThumbnailUrl: video.ThumbnailUrl,
+				PublishedAt:  video.PublishedAt,
+			})
+		}
+	}
+
+	feed.Items = s.summarizedUploads(ctx, uploads, limit)
+	return feed, nil
+}
+
+// followedUploads returns the uploads in the feed of a feed token's user
+// from the channels they still follow, most recently found first.
+func (s *VideoService) followedUploads(ctx context.Context, secret string) ([]*pb.SummaryFeedItem, error) {
+	if s.feedTokens == nil {
+		return nil, fmt.Errorf("feeds are not configured")
+	}
+	if s.subscriptions == nil {
+		return nil, fmt.Errorf("subscriptions are not configured")
+	}
+	token, err := s.feedTokens.FindFeedToken(ctx, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feed token: %w", err)
+	}
+	if token == nil {
+		return nil, status.Error(codes.NotFound, "unknown feed")
+	}
+
+	subs, err := s.subscriptions.ListSubscriptions(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	followed := make(map[string]bool, len(subs))
+	for _, sub := range subs {
+		followed[sub.ChannelID] = true
+	}
+	items, err := s.subscriptions.ListFeed(ctx, token.UserID, time.Time{}, maxNewVideos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feed: %w", err)
+	}
+
+	var uploads []*pb.SummaryFeedItem
+	for i := range items {
+		if !followed[items[i].ChannelID] {
+			continue
+		}
+		uploads = append(uploads, &pb.SummaryFeedItem{
+			VideoId:      items[i].VideoID,
+			Title:        items[i].Title,
+			ChannelId:    items[i].ChannelID,
+			ChannelTitle: items[i].ChannelTitle,
+			ThumbnailUrl: items[i].Thumbnail,
+			PublishedAt:  items[i].PublishedAt,
+		})
+	}
+	return uploads, nil
+}
+
+// summarizedUploads returns the first limit uploads that have a cached
+// default summary, with it.
+func (s *VideoService) summarizedUploads(ctx context.Context, uploads []*pb.SummaryFeedItem, limit int) []*pb.SummaryFeedItem {
+	if s.summaries == nil {
+		return nil
+	}
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	var items []*pb.SummaryFeedItem
+	for _, item := range uploads {
+		if len(items) == limit {
+			break
+		}
+		cached, err := s.summaries.Get(ctx, item.VideoId, opts)
+		if err != nil {
+			log.Printf("Error loading cached summary of video %s: %v", item.VideoId, err)
+			continue
+		}
+		if cached == nil {
+			continue
+		}
+		item.Summary = cached.Summary
+		item.SummarizedAt = cached.GeneratedAt.UTC().Format(time.RFC3339)
+		items = append(items, item)
+	}
+	return items
+}
+
+func convertFeedTokenToProto(token *models.FeedToken) *pb.FeedToken {
+	return &pb.FeedToken{
+		Token:     token.Token,
+		CreatedAt: token.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
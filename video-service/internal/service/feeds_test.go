@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockFeedTokenStore struct {
+	mu     sync.Mutex
+	Tokens map[string]models.FeedToken
+}
+
+func (m *MockFeedTokenStore) SaveFeedToken(ctx context.Context, token *models.FeedToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Tokens == nil {
+		m.Tokens = map[string]models.FeedToken{}
+	}
+	m.Tokens[token.UserID] = *token
+	return nil
+}
+
+func (m *MockFeedTokenStore) GetFeedToken(ctx context.Context, userID string) (*models.FeedToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.Tokens[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (m *MockFeedTokenStore) FindFeedToken(ctx context.Context, secret string) (*models.FeedToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.Tokens {
+		if token.Token == secret {
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestGetFeedToken(t *testing.T) {
+	svc := &VideoService{feedTokens: &MockFeedTokenStore{}}
+	ctx := context.Background()
+
+	first, err := svc.GetFeedToken(ctx, &pb.GetFeedTokenRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if first.Token == "" || first.CreatedAt == "" {
+		t.Errorf("Expected a new token, got %v", first)
+	}
+	again, _ := svc.GetFeedToken(ctx, &pb.GetFeedTokenRequest{UserId: "user-1"})
+	if again.Token != first.Token {
+		t.Errorf("Expected the same token, got %q and %q", first.Token, again.Token)
+	}
+	rotated, _ := svc.GetFeedToken(ctx, &pb.GetFeedTokenRequest{UserId: "user-1", Rotate: true})
+	if rotated.Token == first.Token {
+		t.Error("Expected a new token after rotating")
+	}
+	other, _ := svc.GetFeedToken(ctx, &pb.GetFeedTokenRequest{UserId: "user-2"})
+	if other.Token == rotated.Token {
+		t.Error("Expected users to have different tokens")
+	}
+
+	if _, err := svc.GetFeedToken(ctx, &pb.GetFeedTokenRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetSummaryFeed(t *testing.T) {
+	const otherChannelID = "UCzyxwvutsrqponmlkjihgfe"
+	now := time.Now()
+	subs := &MockSubscriptionStore{}
+	subs.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: testChannelID})
+	for i, item := range []models.FeedItem{
+		{VideoID: "summarized1", ChannelID: testChannelID, Title: "Newest"},
+		{VideoID: "notSummed01", ChannelID: testChannelID},
+		{VideoID: "unfollowed1", ChannelID: otherChannelID},
+		{VideoID: "summarized2", ChannelID: testChannelID, Title: "Older"},
+	} {
+		item.UserID = "user-1"
+		item.AddedAt = now.Add(-time.Duration(i) * time.Hour)
+		subs.AddFeedItem(context.Background(), &item)
+	}
+
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	summaries := &MockSummaryStore{Summaries: map[string]*models.CachedSummary{}}
+	for _, id := range []string{"summarized1", "summarized2", "unfollowed1"} {
+		summaries.Save(context.Background(), &models.CachedSummary{VideoID: id, Options: opts, Summary: "Summary of " + id, GeneratedAt: now})
+	}
+
+	tokens := &MockFeedTokenStore{}
+	tokens.SaveFeedToken(context.Background(), &models.FeedToken{UserID: "user-1", Token: "secret"})
+	svc := &VideoService{feedTokens: tokens, subscriptions: subs, summaries: summaries}
+	ctx := context.Background()
+
+	feed, err := svc.GetSummaryFeed(ctx, &pb.GetSummaryFeedRequest{FeedToken: "secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(feed.Items) != 2 || feed.Items[0].VideoId != "summarized1" || feed.Items[1].VideoId != "summarized2" {
+		t.Fatalf("Expected the summarized uploads of followed channels, newest first, got %v", feed.Items)
+	}
+	if item := feed.Items[0]; item.Title != "Newest" || item.Summary != "Summary of summarized1" || item.SummarizedAt == "" {
+		t.Errorf("Expected the upload with its summary, got %v", item)
+	}
+
+	feed, _ = svc.GetSummaryFeed(ctx, &pb.GetSummaryFeedRequest{FeedToken: "secret", Limit: 1})
+	if len(feed.Items) != 1 {
+		t.Errorf("Expected 1 item, got %d", len(feed.Items))
+	}
+
+	if _, err := svc.GetSummaryFeed(ctx, &pb.GetSummaryFeedRequest{FeedToken: "guess"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown token, got %v", err)
+	}
+	if _, err := svc.GetSummaryFeed(ctx, &pb.GetSummaryFeedRequest{ChannelId: otherChannelID}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a channel nobody follows, got %v", err)
+	}
+	for _, req := range []*pb.GetSummaryFeedRequest{
+		{},
+		{FeedToken: "secret", ChannelId: testChannelID},
+		{ChannelId: "not-a-channel"},
+		{FeedToken: "secret", Limit: 51},
+	} {
+		if _, err := svc.GetSummaryFeed(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
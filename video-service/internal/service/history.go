@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultFeedLimit = 10
+	maxFeedLimit     = 50
+)
+
+// HistoryStore persists what users did with which videos.
+type HistoryStore interface {
+	// Record notes that a user did action on a video at the given time,
+	// updating the video's details when entry has them.
+	Record(ctx context.Context, entry *models.HistoryEntry, action string, at time.Time) error
+	// ListHistory returns up to limit of a user's entries, most recent
+	// activity first.
+	ListHistory(ctx context.Context, userID string, limit int) ([]models.HistoryEntry, error)
+	// FindHistory returns a user's entries for the given videos.
+	FindHistory(ctx context.Context, userID string, videoIDs []string) ([]models.HistoryEntry, error)
+}
+
+// recordHistory notes in a user's history that they did action on a video.
+// video may be nil when its details are not at hand. Failures are only
+// logged.
+func (s *VideoService) recordHistory(ctx context.Context, userID, videoID, action string, video *models.Video) {
+	if s.history == nil || userID == "" {
+		return
+	}
+	entry := &models.HistoryEntry{UserID: userID, VideoID: videoID}
+	if video != nil {
+		entry.Title = video.Title
+		entry.ChannelID = video.ChannelID
+		entry.ChannelTitle = video.ChannelTitle
+		entry.Thumbnail = video.Thumbnail
+	}
+	if err := s.history.Record(context.WithoutCancel(ctx), entry, action, time.Now()); err != nil {
+		log.Printf("Error recording %s of video %s for user %s: %v", action, videoID, userID, err)
+	}
+}
+
+// GetFeed returns the uploads from a user's followed channels that are new
+// since their last visit and that they have not opened yet, together with
+// their history to continue reading from.
+func (s *VideoService) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.GetFeedResponse, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("history is not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultFeedLimit
+	}
+	if limit < 0 || limit > maxFeedLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxFeedLimit)
+	}
+
+	resp := &pb.GetFeedResponse{}
+	if s.subscriptions != nil {
+		subs, err := s.subscriptions.ListSubscriptions(ctx, req.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		uploads, err := s.newUploads(ctx, req.UserId, subs)
+		if err != nil {
+			return nil, err
+		}
+		videoIDs := make([]string, len(uploads))
+		for i := range uploads {
+			videoIDs[i] = uploads[i].VideoID
+		}
+		opened, err := s.history.FindHistory(ctx, req.UserId, videoIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load history: %w", err)
+		}
+		seen := make(map[string]bool, len(opened))
+		for _, entry := range opened {
+			seen[entry.VideoID] = true
+		}
+		for i := range uploads {
+			if !seen[uploads[i].VideoID] && len(resp.NewVideos) < limit {
+				resp.NewVideos = append(resp.NewVideos, convertFeedItemToProto(&uploads[i]))
+			}
+		}
+	}
+
+	entries, err := s.history.ListHistory(ctx, req.UserId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history: %w", err)
+	}
+	for i := range entries {
+		item := convertHistoryToProto(&entries[i])
+		if item.Title == "" {
+			s.fillHistoryItem(ctx, item)
+		}
+		resp.ContinueReading = append(resp.ContinueReading, item)
+	}
+	return resp, nil
+}
+
+// fillHistoryItem adds the details of a video that were not at hand when it
+// was recorded, if they can be found.
+func (s *VideoService) fillHistoryItem(ctx context.Context, item *pb.HistoryItem) {
+	if s.videoRepo == nil || s.youtubeClient == nil {
+		return
+	}
+	resp, err := s.GetVideoDetails(ctx, &pb.GetVideoDetailsRequest{VideoId: item.VideoId})
+	if err != nil {
+		log.Printf("Error loading details of video %s: %v", item.VideoId, err)
+		return
+	}
+	item.Title = resp.Video.Title
+	item.ChannelId = resp.Video.ChannelId
+	item.ChannelTitle = resp.Video.ChannelTitle
+	item.ThumbnailUrl = resp.Video.ThumbnailUrl
+}
+
+func convertHistoryToProto(entry *models.HistoryEntry) *pb.HistoryItem {
+	result := &pb.HistoryItem{
+		VideoId:        entry.VideoID,
+		Title:          entry.Title,
+		ChannelId:      entry.ChannelID,
+		ChannelTitle:   entry.ChannelTitle,
+		ThumbnailUrl:   entry.Thumbnail,
+		LastActivityAt: entry.LastActivityAt.UTC().Format(time.RFC3339),
+	}
+	if !entry.ViewedAt.IsZero() {
+		result.ViewedAt = entry.ViewedAt.UTC().Format(time.RFC3339)
+	}
+	if !entry.TranscriptReadAt.IsZero() {
+		result.TranscriptReadAt = entry.TranscriptReadAt.UTC().Format(time.RFC3339)
+	}
+	if !entry.SummarizedAt.IsZero() {
+		result.SummarizedAt = entry.SummarizedAt.UTC().Format(time.RFC3339)
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockHistoryStore struct {
+	mu      sync.Mutex
+	Entries map[string]models.HistoryEntry
+}
+
+func (m *MockHistoryStore) Record(ctx context.Context, entry *models.HistoryEntry, action string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Entries == nil {
+		m.Entries = map[string]models.HistoryEntry{}
+	}
+	id := entry.UserID + ":" + entry.VideoID
+	current, ok := m.Entries[id]
+	if !ok {
+		current = models.HistoryEntry{ID: id, UserID: entry.UserID, VideoID: entry.VideoID}
+	}
+	if entry.Title != "" {
+		current.Title = entry.Title
+		current.ChannelID = entry.ChannelID
+		current.ChannelTitle = entry.ChannelTitle
+		current.Thumbnail = entry.Thumbnail
+	}
+	switch action {
+	case models.HistoryViewed:
+		current.ViewedAt = at
+	case models.HistoryTranscriptRead:
+		current.TranscriptReadAt = at
+	case models.HistorySummarized:
+		current.SummarizedAt = at
+	}
+	current.LastActivityAt = at
+	m.Entries[id] = current
+	return nil
+}
+
+func (m *MockHistoryStore) ListHistory(ctx context.Context, userID string, limit int) ([]models.HistoryEntry, error) {
+	entries := m.find(func(entry models.HistoryEntry) bool { return entry.UserID == userID })
+	sort.Slice(entries, func(i, j int) bool { return entries[i].LastActivityAt.After(entries[j].LastActivityAt) })
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (m *MockHistoryStore) FindHistory(ctx context.Context, userID string, videoIDs []string) ([]models.HistoryEntry, error) {
+	return m.find(func(entry models.HistoryEntry) bool {
+		return entry.UserID == userID && slices.Contains(videoIDs, entry.VideoID)
+	}), nil
+}
+
+func (m *MockHistoryStore) find(match func(models.HistoryEntry) bool) []models.HistoryEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []models.HistoryEntry
+	for _, entry := range m.Entries {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func TestRecordHistory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	defer ts.Close()
+
+	history := &MockHistoryStore{}
+	svc := &VideoService{
+		llmClient: &MockLLMClient{SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			return "Summary", nil
+		}},
+		history:              history,
+		transcriptServiceURL: ts.URL,
+	}
+
+	if _, err := svc.GetVideoTranscript(context.Background(), &pb.GetVideoTranscriptRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	entry := history.Entries["user-1:dQw4w9WgXcQ"]
+	if entry.TranscriptReadAt.IsZero() || !entry.SummarizedAt.IsZero() {
+		t.Errorf("Expected the transcript read recorded, got %+v", entry)
+	}
+
+	if _, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	entry = history.Entries["user-1:dQw4w9WgXcQ"]
+	if entry.SummarizedAt.IsZero() || entry.TranscriptReadAt.IsZero() || entry.LastActivityAt != entry.SummarizedAt {
+		t.Errorf("Expected the summary recorded alongside the transcript read, got %+v", entry)
+	}
+
+	if _, err := svc.GetVideoTranscript(context.Background(), &pb.GetVideoTranscriptRequest{VideoId: "abcdefghijk"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history.Entries) != 1 {
+		t.Errorf("Expected nothing recorded without a user, got %+v", history.Entries)
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	now := time.Now()
+	subs := &MockSubscriptionStore{}
+	subs.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: testChannelID, CreatedAt: now.Add(-72 * time.Hour), LastVisitedAt: now.Add(-24 * time.Hour)})
+	for _, item := range []models.FeedItem{
+		{UserID: "user-1", VideoID: "unopened001", ChannelID: testChannelID, Title: "Unopened", AddedAt: now.Add(-2 * time.Hour)},
+		{UserID: "user-1", VideoID: "opened00001", ChannelID: testChannelID, Title: "Opened", AddedAt: now.Add(-time.Hour)},
+		{UserID: "user-1", VideoID: "visited0001", ChannelID: testChannelID, Title: "Before the visit", AddedAt: now.Add(-48 * time.Hour)},
+	} {
+		subs.AddFeedItem(context.Background(), &item)
+	}
+	history := &MockHistoryStore{}
+	history.Record(context.Background(), &models.HistoryEntry{UserID: "user-1", VideoID: "older000001", Title: "Older"}, models.HistorySummarized, now.Add(-3*time.Hour))
+	history.Record(context.Background(), &models.HistoryEntry{UserID: "user-1", VideoID: "opened00001", Title: "Opened"}, models.HistoryViewed, now.Add(-30*time.Minute))
+	history.Record(context.Background(), &models.HistoryEntry{UserID: "user-2", VideoID: "unopened001"}, models.HistoryViewed, now)
+	svc := &VideoService{subscriptions: subs, history: history}
+
+	resp, err := svc.GetFeed(context.Background(), &pb.GetFeedRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.NewVideos) != 1 || resp.NewVideos[0].VideoId != "unopened001" {
+		t.Errorf("Expected only the new upload the user has not opened, got %v", resp.NewVideos)
+	}
+	if len(resp.ContinueReading) != 2 || resp.ContinueReading[0].VideoId != "opened00001" || resp.ContinueReading[1].SummarizedAt == "" || resp.ContinueReading[0].SummarizedAt != "" {
+		t.Errorf("Expected the user's history, most recent first, got %v", resp.ContinueReading)
+	}
+
+	resp, err = svc.GetFeed(context.Background(), &pb.GetFeedRequest{UserId: "user-1", Limit: 1})
+	if err != nil || len(resp.ContinueReading) != 1 {
+		t.Errorf("Expected the history limited, got %v, %v", resp, err)
+	}
+	for _, req := range []*pb.GetFeedRequest{{}, {UserId: "user-1", Limit: -1}, {UserId: "user-1", Limit: maxFeedLimit + 1}} {
+		if _, err := svc.GetFeed(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %+v, got %v", req, err)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultJobWorkers     = 2
+	defaultJobMaxAttempts = 3
+	defaultJobQueueSize   = 100
+	defaultJobRetryDelay  = 5 * time.Second
+
+	defaultJobListLimit = 20
+	maxJobListLimit     = 100
+)
+
+// Progress milestones of a summary job.
+const (
+	jobProgressTranscript = 10
+	jobProgressGenerating = 30
+	jobProgressDone       = 100
+)
+
+// JobConfig controls the background job queue. Jobs are run by at most
+// Workers goroutines and tried up to MaxAttempts times, waiting RetryDelay
+// times the attempt number between attempts. At most QueueSize jobs wait for
+// a worker; submissions beyond that are rejected. Zero fields fall back to
+// the defaults.
+type JobConfig struct {
+	Workers     int
+	MaxAttempts int
+	QueueSize   int
+	RetryDelay  time.Duration
+}
+
+func (c JobConfig) withDefaults() JobConfig {
+	if c.Workers <= 0 {
+		c.Workers = defaultJobWorkers
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultJobMaxAttempts
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultJobQueueSize
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = defaultJobRetryDelay
+	}
+	return c
+}
+
+// JobStore persists background jobs.
+type JobStore interface {
+	// Get returns a job, or nil if there is none with that ID.
+	Get(ctx context.Context, id string) (*models.Job, error)
+	// Save creates or replaces a job.
+	Save(ctx context.Context, job *models.Job) error
+	// List returns a user's jobs, most recent first, optionally only those
+	// in one status.
+	List(ctx context.Context, userID, status string, limit int) ([]models.Job, error)
+	// Unfinished returns the queued and running jobs of all users, oldest
+	// first.
+	Unfinished(ctx context.Context) ([]models.Job, error)
+}
+
+// jobQueue hands submitted jobs to the workers. The quota release functions
+// of the jobs submitted to this process are kept in memory, so a job that
+// fails for good after a restart keeps its quota charge.
+type jobQueue struct {
+	pending  chan *models.Job
+	releases sync.Map // job ID -> func()
+}
+
+// SubmitSummaryJob queues a summary to be generated in the background and
+// returns the queued job. The summary counts against the user's quota when
+// it is submitted, and is given back if the job fails.
+func (s *VideoService) SubmitSummaryJob(ctx context.Context, req *pb.SummarizeVideoRequest) (*pb.Job, error) {
+	if s.jobs == nil {
+		return nil, fmt.Errorf("background jobs are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if req.VideoId == "" {
+		return nil, status.Error(codes.InvalidArgument, "video id is required")
+	}
+	opts, err := summaryOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	release, err := s.checkQuota(ctx, req.UserId, QuotaSummaries)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	now := time.Now()
+	job := &models.Job{
+		ID:      id,
+		UserID:  req.UserId,
+		Type:    models.JobTypeSummary,
+		VideoID: req.VideoId,
+		Status:  models.JobStatusQueued,
+		Summary: &models.SummaryJob{
+			Options:    opts,
+			Structured: req.Structured,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.jobs.Save(ctx, job); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	// A worker owns the job once it is queued.
+	resp := convertJobToProto(job)
+	s.jobQueue.releases.Store(job.ID, release)
+	select {
+	case s.jobQueue.pending <- job:
+	default:
+		s.failJob(ctx, job, "the job queue is full")
+		return nil, status.Error(codes.Unavailable, "too many jobs are queued, try again later")
+	}
+
+	log.Printf("Queued summary job %s for video: %s for user: %s", job.ID, req.VideoId, req.UserId)
+	return resp, nil
+}
+
+func (s *VideoService) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	if s.jobs == nil {
+		return nil, fmt.Errorf("background jobs are not configured")
+	}
+	job, err := s.jobs.Get(ctx, req.JobId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job: %w", err)
+	}
+	// Other users' jobs are reported as missing rather than forbidden, so
+	// job IDs cannot be probed.
+	if job == nil || job.UserID != req.UserId {
+		return nil, status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+	}
+	return convertJobToProto(job), nil
+}
+
+func (s *VideoService) ListJobs(ctx context.Context, req *pb.ListJobsRequest) (*pb.ListJobsResponse, error) {
+	if s.jobs == nil {
+		return nil, fmt.Errorf("background jobs are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	switch req.Status {
+	case "", models.JobStatusQueued, models.JobStatusRunning, models.JobStatusSucceeded, models.JobStatusFailed:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown job status %q", req.Status)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultJobListLimit
+	}
+	if limit > maxJobListLimit {
+		limit = maxJobListLimit
+	}
+
+	jobs, err := s.jobs.List(ctx, req.UserId, req.Status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	resp := &pb.ListJobsResponse{}
+	for i := range jobs {
+		resp.Jobs = append(resp.Jobs, convertJobToProto(&jobs[i]))
+	}
+	return resp, nil
+}
+
+// RunJobs processes background jobs until ctx is cancelled, first queueing
+// the jobs left unfinished by a previous run. It returns once every worker
+// has stopped; jobs interrupted by the shutdown stay queued for the next run.
+func (s *VideoService) RunJobs(ctx context.Context) {
+	if s.jobs == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.jobConfig.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.jobQueue.pending:
+					s.processJob(ctx, job)
+				}
+			}
+		}()
+	}
+
+	unfinished, err := s.jobs.Unfinished(ctx)
+	if err != nil {
+		log.Printf("Error loading unfinished jobs: %v", err)
+	}
+	if len(unfinished) > 0 {
+		log.Printf("Resuming %d unfinished jobs", len(unfinished))
+	}
+	for i := range unfinished {
+		// Jobs submitted since the service started are queued already.
+		if _, ok := s.jobQueue.releases.Load(unfinished[i].ID); ok {
+			continue
+		}
+		select {
+		case s.jobQueue.pending <- &unfinished[i]:
+		case <-ctx.Done():
+		}
+	}
+
+	wg.Wait()
+}
+
+// processJob runs a job, retrying failures that may be temporary, and
+// records its outcome.
+func (s *VideoService) processJob(ctx context.Context, job *models.Job) {
+	for {
+		job.Attempts++
+		job.Status = models.JobStatusRunning
+		job.Progress = 0
+		s.saveJob(ctx, job)
+
+		err := s.runJob(ctx, job)
+		if err == nil {
+			job.Status = models.JobStatusSucceeded
+			job.Progress = jobProgressDone
+			job.Error = ""
+			s.saveJob(ctx, job)
+			s.jobQueue.releases.Delete(job.ID)
+			log.Printf("Job %s succeeded after %d attempts", job.ID, job.Attempts)
+			s.notify(ctx, job.UserID, models.EventSummaryCompleted, summaryEvent{
+				VideoID: job.VideoID,
+				JobID:   job.ID,
+				Summary: convertJobToProto(job).Summary,
+			})
+			s.recordHistory(ctx, job.UserID, job.VideoID, models.HistorySummarized, nil)
+			return
+		}
+
+		if ctx.Err() != nil {
+			// Shutting down: leave the job for the next run without counting
+			// the interrupted attempt.
+			job.Attempts--
+			job.Status = models.JobStatusQueued
+			job.Progress = 0
+			s.saveJob(ctx, job)
+			return
+		}
+
+		log.Printf("Job %s attempt %d failed: %v", job.ID, job.Attempts, err)
+		if !retryableJobError(err) || job.Attempts >= s.jobConfig.MaxAttempts {
+			s.failJob(ctx, job, status.Convert(err).Message())
+			s.notify(ctx, job.UserID, models.EventJobFailed, convertJobToProto(job))
+			return
+		}
+
+		job.Status = models.JobStatusQueued
+		job.Error = status.Convert(err).Message()
+		s.saveJob(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.jobConfig.RetryDelay * time.Duration(job.Attempts)):
+		}
+	}
+}
+
+func (s *VideoService) runJob(ctx context.Context, job *models.Job) error {
+	switch job.Type {
+	case models.JobTypeSummary:
+		return s.runSummaryJob(ctx, job)
+	}
+	return status.Errorf(codes.InvalidArgument, "unknown job type %q", job.Type)
+}
+
+func (s *VideoService) runSummaryJob(ctx context.Context, job *models.Job) error {
+	req := &pb.SummarizeVideoRequest{
+		VideoId:    job.VideoID,
+		UserId:     job.UserID,
+		Structured: job.Summary.Structured,
+	}
+
+	job.Progress = jobProgressTranscript
+	s.saveJob(ctx, job)
+	transcript, err := s.transcriptForSummary(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	job.Progress = jobProgressGenerating
+	s.saveJob(ctx, job)
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, job.UserID, job.VideoID, models.OperationSummarize, calls)
+	summary, structured, err := s.generateSummary(llmCtx, req, transcript, job.Summary.Options, nil)
+	if err != nil {
+		return llmError("failed to generate summary", err)
+	}
+
+	result := &models.SummaryResult{Summary: summary, Structured: structured}
+	for _, m := range calls.generatedBy() {
+		result.GeneratedBy = append(result.GeneratedBy, models.ModelInfo{Provider: m.Provider, Model: m.Model})
+	}
+	job.Summary.Result = result
+	return nil
+}
+
+// failJob marks a job as failed for good and gives its quota back.
+func (s *VideoService) failJob(ctx context.Context, job *models.Job, reason string) {
+	// The release function also marks the job as queued by this process, so
+	// it is only dropped once the job is stored as failed.
+	release, ok := s.jobQueue.releases.Load(job.ID)
+	if ok {
+		release.(func())()
+	}
+	job.Status = models.JobStatusFailed
+	job.Error = reason
+	s.saveJob(ctx, job)
+	s.jobQueue.releases.Delete(job.ID)
+}
+
+// saveJob stores the current state of a job. Progress is best effort, so
+// failures are only logged.
+func (s *VideoService) saveJob(ctx context.Context, job *models.Job) {
+	job.UpdatedAt = time.Now()
+	// Record the state even when the job was interrupted by a shutdown.
+	if err := s.jobs.Save(context.WithoutCancel(ctx), job); err != nil {
+		log.Printf("Error saving job %s: %v", job.ID, err)
+	}
+}
+
+// retryableJobError reports whether a failed attempt may succeed if tried
+// again. Invalid requests and content blocked by the model's filters will not.
+func retryableJobError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.NotFound:
+		return false
+	}
+	return true
+}
+
+// newID returns a random ID for jobs, webhooks and deliveries.
+func newID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func convertJobToProto(job *models.Job) *pb.Job {
+	result := &pb.Job{
+		JobId:     job.ID,
+		UserId:    job.UserID,
+		Type:      job.Type,
+		VideoId:   job.VideoID,
+		Status:    job.Status,
+		Progress:  int32(job.Progress),
+		Attempts:  int32(job.Attempts),
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: job.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if job.Summary != nil && job.Summary.Result != nil {
+		r := job.Summary.Result
+		result.Summary = &pb.SummarizeVideoResponse{
+			Summary:    r.Summary,
+			VideoId:    job.VideoID,
+			Style:      string(job.Summary.Options.Style),
+			Length:     string(job.Summary.Options.Length),
+			Language:   job.Summary.Options.Language,
+			Structured: convertStructuredToProto(r.Structured),
+		}
+		for _, m := range r.GeneratedBy {
+			result.Summary.GeneratedBy = append(result.Summary.GeneratedBy, &pb.ModelInfo{Provider: m.Provider, Model: m.Model})
+		}
+	}
+	return result
+}
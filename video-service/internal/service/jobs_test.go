@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockJobStore struct {
+	mu   sync.Mutex
+	Jobs map[string]models.Job
+}
+
+func (m *MockJobStore) Get(ctx context.Context, id string) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.Jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyJob(job), nil
+}
+
+func (m *MockJobStore) Save(ctx context.Context, job *models.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Jobs == nil {
+		m.Jobs = map[string]models.Job{}
+	}
+	m.Jobs[job.ID] = *copyJob(*job)
+	return nil
+}
+
+// copyJob copies the summary along with the job, as a round trip through
+// the database would.
+func copyJob(job models.Job) *models.Job {
+	if job.Summary != nil {
+		summary := *job.Summary
+		job.Summary = &summary
+	}
+	return &job
+}
+
+func (m *MockJobStore) List(ctx context.Context, userID, status string, limit int) ([]models.Job, error) {
+	return m.find(func(j models.Job) bool {
+		return j.UserID == userID && (status == "" || j.Status == status)
+	}, limit), nil
+}
+
+func (m *MockJobStore) Unfinished(ctx context.Context) ([]models.Job, error) {
+	return m.find(func(j models.Job) bool { return !j.Finished() }, 0), nil
+}
+
+func (m *MockJobStore) find(match func(models.Job) bool, limit int) []models.Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var jobs []models.Job
+	for _, job := range m.Jobs {
+		if match(job) {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+func newJobTestService(t *testing.T, llm LLMClient, store JobStore, cfg JobConfig) *VideoService {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	t.Cleanup(ts.Close)
+
+	cfg.RetryDelay = time.Millisecond
+	return NewVideoService(nil, nil, llm, WithJobs(store, cfg), func(s *VideoService) {
+		s.transcriptServiceURL = ts.URL
+	})
+}
+
+// runJobs runs the workers until the test ends.
+func runJobs(t *testing.T, svc *VideoService) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.RunJobs(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// waitForJob polls a job until it has finished.
+func waitForJob(t *testing.T, svc *VideoService, jobID, userID string) *pb.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := svc.GetJob(context.Background(), &pb.GetJobRequest{JobId: jobID, UserId: userID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if job.Status == models.JobStatusSucceeded || job.Status == models.JobStatusFailed {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Job %s did not finish", jobID)
+	return nil
+}
+
+func TestSummaryJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc := newJobTestService(t, &MockLLMClient{}, &MockJobStore{}, JobConfig{})
+		runJobs(t, svc)
+
+		job, err := svc.SubmitSummaryJob(context.Background(), &pb.SummarizeVideoRequest{
+			VideoId: "dQw4w9WgXcQ",
+			UserId:  "user-1",
+			Style:   "tldr",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if job.Status != models.JobStatusQueued || job.JobId == "" {
+			t.Fatalf("Expected a queued job with an ID, got %+v", job)
+		}
+
+		job = waitForJob(t, svc, job.JobId, "user-1")
+		if job.Status != models.JobStatusSucceeded {
+			t.Fatalf("Expected the job to succeed, got %q (%s)", job.Status, job.Error)
+		}
+		if job.Progress != 100 || job.Attempts != 1 {
+			t.Errorf("Expected progress 100 after 1 attempt, got %d after %d", job.Progress, job.Attempts)
+		}
+		if job.Summary.GetSummary() != "Mock summary" || job.Summary.Style != "tldr" {
+			t.Errorf("Expected the tldr mock summary, got %+v", job.Summary)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		var mu sync.Mutex
+		calls := 0
+		llm := &MockLLMClient{
+			SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				if calls == 1 {
+					return "", fmt.Errorf("temporary failure")
+				}
+				return "Mock summary", nil
+			},
+		}
+		svc := newJobTestService(t, llm, &MockJobStore{}, JobConfig{})
+		runJobs(t, svc)
+
+		job, err := svc.SubmitSummaryJob(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		job = waitForJob(t, svc, job.JobId, "user-1")
+		if job.Status != models.JobStatusSucceeded || job.Attempts != 2 {
+			t.Errorf("Expected success on the second attempt, got %q after %d", job.Status, job.Attempts)
+		}
+	})
+
+	t.Run("FailureReleasesQuota", func(t *testing.T) {
+		llm := &MockLLMClient{
+			SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+				return "", fmt.Errorf("provider down")
+			},
+		}
+		quotas := &MockQuotaStore{}
+		svc := newJobTestService(t, llm, &MockJobStore{}, JobConfig{MaxAttempts: 2})
+		svc.quotas = quotas
+		svc.planQuotas = map[string]PlanQuotas{"free": {QuotaSummaries: {Daily: 5}}}
+		runJobs(t, svc)
+
+		job, err := svc.SubmitSummaryJob(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		job = waitForJob(t, svc, job.JobId, "user-1")
+		if job.Status != models.JobStatusFailed || job.Attempts != 2 {
+			t.Errorf("Expected failure after 2 attempts, got %q after %d", job.Status, job.Attempts)
+		}
+		if job.Error == "" {
+			t.Error("Expected the failure reason, got none")
+		}
+		if total := quotas.total(); total != 0 {
+			t.Errorf("Expected the failed job not to count against the quota, got %v", quotas.Counts)
+		}
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		svc := newJobTestService(t, &MockLLMClient{}, &MockJobStore{}, JobConfig{})
+		_, err := svc.SubmitSummaryJob(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1", Style: "haiku"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		store := &MockJobStore{}
+		svc := newJobTestService(t, &MockLLMClient{}, store, JobConfig{QueueSize: 1})
+		req := &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}
+		if _, err := svc.SubmitSummaryJob(context.Background(), req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, err := svc.SubmitSummaryJob(context.Background(), req)
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("Expected Unavailable, got %v", err)
+		}
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	store := &MockJobStore{}
+	store.Save(context.Background(), &models.Job{ID: "job-1", UserID: "user-1", Type: models.JobTypeSummary, Status: models.JobStatusQueued})
+	svc := newJobTestService(t, &MockLLMClient{}, store, JobConfig{})
+
+	if _, err := svc.GetJob(context.Background(), &pb.GetJobRequest{JobId: "job-1", UserId: "user-1"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	_, err := svc.GetJob(context.Background(), &pb.GetJobRequest{JobId: "job-1", UserId: "user-2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another user's job, got %v", err)
+	}
+	_, err = svc.GetJob(context.Background(), &pb.GetJobRequest{JobId: "missing", UserId: "user-1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	store := &MockJobStore{}
+	now := time.Now()
+	for i, s := range []string{models.JobStatusSucceeded, models.JobStatusFailed, models.JobStatusSucceeded} {
+		store.Save(context.Background(), &models.Job{
+			ID:        fmt.Sprintf("job-%d", i),
+			UserID:    "user-1",
+			Status:    s,
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	store.Save(context.Background(), &models.Job{ID: "other", UserID: "user-2", Status: models.JobStatusSucceeded})
+	svc := newJobTestService(t, &MockLLMClient{}, store, JobConfig{})
+
+	resp, err := svc.ListJobs(context.Background(), &pb.ListJobsRequest{UserId: "user-1", Status: models.JobStatusSucceeded})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Jobs) != 2 || resp.Jobs[0].JobId != "job-2" {
+		t.Errorf("Expected the user's 2 succeeded jobs, newest first, got %v", resp.Jobs)
+	}
+
+	_, err = svc.ListJobs(context.Background(), &pb.ListJobsRequest{UserId: "user-1", Status: "paused"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestRunJobsResumesUnfinished(t *testing.T) {
+	store := &MockJobStore{}
+	store.Save(context.Background(), &models.Job{
+		ID:      "job-1",
+		UserID:  "user-1",
+		Type:    models.JobTypeSummary,
+		VideoID: "dQw4w9WgXcQ",
+		Status:  models.JobStatusRunning,
+		Summary: &models.SummaryJob{Options: models.SummaryOptions{Style: models.DefaultSummaryStyle}},
+	})
+	svc := newJobTestService(t, &MockLLMClient{}, store, JobConfig{})
+	runJobs(t, svc)
+
+	job := waitForJob(t, svc, "job-1", "user-1")
+	if job.Status != models.JobStatusSucceeded {
+		t.Errorf("Expected the resumed job to succeed, got %q (%s)", job.Status, job.Error)
+	}
+}
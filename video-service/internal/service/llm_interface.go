@@ -1,7 +1,61 @@
 package service
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/client"
+	"videoservice/internal/models"
+)
 
 type LLMClient interface {
-	Summarize(ctx context.Context, text string) (string, error)
+	Summarize(ctx context.Context, text string, opts models.SummaryOptions) (string, error)
+	// SummarizeStream behaves like Summarize but calls onChunk with each
+	// piece of the summary as soon as the model produces it. Returning an
+	// error from onChunk aborts generation.
+	SummarizeStream(ctx context.Context, text string, opts models.SummaryOptions, onChunk func(string) error) (string, error)
+	// CombineSummaries merges summaries of consecutive parts of one transcript
+	// into a single summary. A nil onChunk disables streaming.
+	CombineSummaries(ctx context.Context, partials []string, opts models.SummaryOptions, onChunk func(string) error) (string, error)
+	// SummarizeStructured returns the summary as validated structured data.
+	// The transcript should carry [m:ss] markers so that chapters can be
+	// given start times.
+	SummarizeStructured(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error)
+	// GenerateChapters splits a transcript carrying [m:ss] markers into
+	// titled chapters, sorted by start time.
+	GenerateChapters(ctx context.Context, transcript string) ([]models.Chapter, error)
+	// Answer replies to question using only the given transcript passages,
+	// citing them by their [m:ss] start timestamps. The user's notes on the
+	// video, if any, are context about what they care about.
+	Answer(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error)
+	// CompareVideos writes a digest comparing summaries of several videos,
+	// focused on a question if one is given, that cites each video by its
+	// Number.
+	CompareVideos(ctx context.Context, videos []models.VideoSummary, focus string, opts models.SummaryOptions) (string, error)
+	// ChannelDigest writes a newsletter-style digest of a channel's recent
+	// uploads that cites each video by its Number.
+	ChannelDigest(ctx context.Context, channel string, videos []models.VideoSummary, opts models.SummaryOptions) (string, error)
+}
+
+// Embedder turns text into vectors for semantic search. Implementations must
+// return one embedding per input, in the same order.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// llmError wraps an error from an LLM call made to do action. Output blocked
+// by the model's safety or recitation filters becomes FailedPrecondition, so
+// callers can tell the user that retrying will not help.
+func llmError(action string, err error) error {
+	switch {
+	case errors.Is(err, client.ErrSafetyBlocked):
+		return status.Errorf(codes.FailedPrecondition, "%s: the model's safety filters blocked the content", action)
+	case errors.Is(err, client.ErrRecitationBlocked):
+		return status.Errorf(codes.FailedPrecondition, "%s: the model stopped because the output recited existing material", action)
+	}
+	return fmt.Errorf("%s: %w", action, err)
 }
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"videoservice/internal/client"
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+// RouteProvider is one LLM the Router can send calls to.
+type RouteProvider struct {
+	// Name and Model identify the provider in logs and in the generated_by
+	// field of summaries.
+	Name   string
+	Model  string
+	Client LLMClient
+	// Timeout bounds each call, including the whole of a streamed one. Zero
+	// means no limit beyond the caller's context.
+	Timeout time.Duration
+	// Limiter caps the provider's request rate; nil means unlimited.
+	Limiter *rate.Limiter
+	// MaxInputTokens is the largest estimated input the provider is given.
+	// Zero means no limit.
+	MaxInputTokens int
+}
+
+// Router is an LLMClient that sends each call to the first provider, in
+// priority order, that can take it: one whose MaxInputTokens fits the input
+// and whose rate limit is not exhausted. When a provider fails, the call
+// fails over to the next one. Listing a cheap model with a small
+// MaxInputTokens ahead of a long-context model therefore routes short inputs
+// to the cheap model and long ones to the long-context model.
+type Router struct {
+	providers []RouteProvider
+}
+
+func NewRouter(providers ...RouteProvider) *Router {
+	return &Router{providers: providers}
+}
+
+func (r *Router) Summarize(ctx context.Context, text string, opts models.SummaryOptions) (string, error) {
+	return routeCall(ctx, r, EstimateTokens(text), func(ctx context.Context, c LLMClient) (string, error) {
+		return c.Summarize(ctx, text, opts)
+	})
+}
+
+func (r *Router) SummarizeStream(ctx context.Context, text string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	return routeStream(ctx, r, EstimateTokens(text), onChunk, func(ctx context.Context, c LLMClient, onChunk func(string) error) (string, error) {
+		return c.SummarizeStream(ctx, text, opts, onChunk)
+	})
+}
+
+func (r *Router) CombineSummaries(ctx context.Context, partials []string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	tokens := EstimateTokens(strings.Join(partials, "\n\n"))
+	if onChunk == nil {
+		return routeCall(ctx, r, tokens, func(ctx context.Context, c LLMClient) (string, error) {
+			return c.CombineSummaries(ctx, partials, opts, nil)
+		})
+	}
+	return routeStream(ctx, r, tokens, onChunk, func(ctx context.Context, c LLMClient, onChunk func(string) error) (string, error) {
+		return c.CombineSummaries(ctx, partials, opts, onChunk)
+	})
+}
+
+func (r *Router) SummarizeStructured(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+	return routeCall(ctx, r, EstimateTokens(transcript), func(ctx context.Context, c LLMClient) (*models.StructuredSummary, error) {
+		return c.SummarizeStructured(ctx, transcript, opts)
+	})
+}
+
+func (r *Router) GenerateChapters(ctx context.Context, transcript string) ([]models.Chapter, error) {
+	return routeCall(ctx, r, EstimateTokens(transcript), func(ctx context.Context, c LLMClient) ([]models.Chapter, error) {
+		return c.GenerateChapters(ctx, transcript)
+	})
+}
+
+func (r *Router) Answer(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error) {
+	tokens := EstimateTokens(question)
+	for _, p := range passages {
+		tokens += EstimateTokens(p.Text)
+	}
+	for _, n := range notes {
+		tokens += EstimateTokens(n.Quote) + EstimateTokens(n.Text)
+	}
+	for _, m := range history {
+		tokens += EstimateTokens(m.Content)
+	}
+	return routeCall(ctx, r, tokens, func(ctx context.Context, c LLMClient) (string, error) {
+		return c.Answer(ctx, question, passages, notes, history)
+	})
+}
+
+func (r *Router) CompareVideos(ctx context.Context, videos []models.VideoSummary, focus string, opts models.SummaryOptions) (string, error) {
+	tokens := EstimateTokens(focus)
+	for _, v := range videos {
+		tokens += EstimateTokens(v.Summary)
+	}
+	return routeCall(ctx, r, tokens, func(ctx context.Context, c LLMClient) (string, error) {
+		return c.CompareVideos(ctx, videos, focus, opts)
+	})
+}
+
+func (r *Router) ChannelDigest(ctx context.Context, channel string, videos []models.VideoSummary, opts models.SummaryOptions) (string, error) {
+	var tokens int
+	for _, v := range videos {
+		tokens += EstimateTokens(v.Summary)
+	}
+	return routeCall(ctx, r, tokens, func(ctx context.Context, c LLMClient) (string, error) {
+		return c.ChannelDigest(ctx, channel, videos, opts)
+	})
+}
+
+// routeCall runs call against each eligible provider in turn until one
+// succeeds, and records which one did.
+func routeCall[T any](ctx context.Context, r *Router, tokens int, call func(context.Context, LLMClient) (T, error)) (T, error) {
+	return route(ctx, r, tokens, call, func() bool { return true })
+}
+
+// routeStream is routeCall for streamed calls. Once a provider has streamed
+// text to onChunk the call can no longer fail over, since the caller would
+// see the start of the output twice.
+func routeStream(ctx context.Context, r *Router, tokens int, onChunk func(string) error, call func(context.Context, LLMClient, func(string) error) (string, error)) (string, error) {
+	streamed := false
+	return route(ctx, r, tokens, func(ctx context.Context, c LLMClient) (string, error) {
+		streamed = false
+		return call(ctx, c, func(chunk string) error {
+			streamed = true
+			return onChunk(chunk)
+		})
+	}, func() bool { return !streamed })
+}
+
+// route tries the providers that accept an input of the given size in
+// priority order, skipping those whose rate limit is exhausted, until a call
+// succeeds or canFailover reports that the failed call cannot be retried.
+// If every provider is rate limited it waits for the first one.
+func route[T any](ctx context.Context, r *Router, tokens int, call func(context.Context, LLMClient) (T, error), canFailover func() bool) (T, error) {
+	var zero T
+	var fits []RouteProvider
+	for _, p := range r.providers {
+		if p.MaxInputTokens == 0 || tokens <= p.MaxInputTokens {
+			fits = append(fits, p)
+		}
+	}
+	if len(fits) == 0 {
+		return zero, fmt.Errorf("no LLM provider accepts an input of about %d tokens", tokens)
+	}
+
+	attempted := false
+	var errs []error
+	for _, p := range fits {
+		if p.Limiter != nil && !p.Limiter.Allow() {
+			errs = append(errs, fmt.Errorf("%s: rate limited", p.Name))
+			continue
+		}
+
+		attempted = true
+		result, err := callProvider(ctx, p, call)
+		if err == nil {
+			recordCall(ctx, p)
+			return result, nil
+		}
+		if ctx.Err() != nil || !canFailover() {
+			return zero, err
+		}
+		log.Printf("LLM provider %s (%s) failed, trying the next one: %v", p.Name, p.Model, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	if attempted {
+		return zero, fmt.Errorf("all LLM providers failed: %w", errors.Join(errs...))
+	}
+
+	p := fits[0]
+	log.Printf("All LLM providers are rate limited, waiting for %s (%s)", p.Name, p.Model)
+	if err := p.Limiter.Wait(ctx); err != nil {
+		return zero, fmt.Errorf("waiting for LLM rate limit: %w", err)
+	}
+	result, err := callProvider(ctx, p, call)
+	if err != nil {
+		return zero, err
+	}
+	recordCall(ctx, p)
+	return result, nil
+}
+
+// callProvider calls p, applying its timeout and recording the tokens it
+// reports using, whether or not the call succeeds.
+func callProvider[T any](ctx context.Context, p RouteProvider, call func(context.Context, LLMClient) (T, error)) (T, error) {
+	ctx = client.WithUsageHook(ctx, func(u client.Usage) {
+		recordUsage(ctx, p, u)
+	})
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	return call(ctx, p.Client)
+}
+
+type callRecorderKey struct{}
+
+// callRecorder collects the providers that served the LLM calls made with a
+// context, and the tokens each provider and model used.
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []*pb.ModelInfo
+	usage []*models.UsageRecord
+}
+
+// withCallRecorder returns a context in which the Router records the
+// providers it uses.
+func withCallRecorder(ctx context.Context) (context.Context, *callRecorder) {
+	rec := &callRecorder{}
+	return context.WithValue(ctx, callRecorderKey{}, rec), rec
+}
+
+func recordCall(ctx context.Context, p RouteProvider) {
+	rec, ok := ctx.Value(callRecorderKey{}).(*callRecorder)
+	if !ok {
+		return
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, c := range rec.calls {
+		if c.Provider == p.Name && c.Model == p.Model {
+			return
+		}
+	}
+	rec.calls = append(rec.calls, &pb.ModelInfo{Provider: p.Name, Model: p.Model})
+}
+
+func recordUsage(ctx context.Context, p RouteProvider, u client.Usage) {
+	rec, ok := ctx.Value(callRecorderKey{}).(*callRecorder)
+	if !ok {
+		return
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, r := range rec.usage {
+		if r.Provider == p.Name && r.Model == p.Model {
+			r.Calls++
+			r.PromptTokens += u.PromptTokens
+			r.CompletionTokens += u.CompletionTokens
+			return
+		}
+	}
+	rec.usage = append(rec.usage, &models.UsageRecord{
+		Provider:         p.Name,
+		Model:            p.Model,
+		Calls:            1,
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+	})
+}
+
+// generatedBy returns the distinct providers recorded so far, in the order
+// they were first used.
+func (rec *callRecorder) generatedBy() []*pb.ModelInfo {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]*pb.ModelInfo(nil), rec.calls...)
+}
+
+// usageRecords returns the recorded token usage, one record per provider and
+// model.
+func (rec *callRecorder) usageRecords() []models.UsageRecord {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	records := make([]models.UsageRecord, len(rec.usage))
+	for i, r := range rec.usage {
+		records[i] = *r
+	}
+	return records
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"videoservice/internal/models"
+)
+
+func failingLLM(err error) *MockLLMClient {
+	return &MockLLMClient{
+		SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			return "", err
+		},
+	}
+}
+
+func answeringLLM(answer string) *MockLLMClient {
+	return &MockLLMClient{
+		SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			return answer, nil
+		},
+	}
+}
+
+func TestRouterFailover(t *testing.T) {
+	router := NewRouter(
+		RouteProvider{Name: "primary", Model: "a", Client: failingLLM(errors.New("503 unavailable"))},
+		RouteProvider{Name: "backup", Model: "b", Client: answeringLLM("from backup")},
+	)
+
+	ctx, calls := withCallRecorder(context.Background())
+	summary, err := router.Summarize(ctx, "transcript", models.SummaryOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if summary != "from backup" {
+		t.Errorf("Expected the backup's summary, got %q", summary)
+	}
+
+	served := calls.generatedBy()
+	if len(served) != 1 || served[0].Provider != "backup" || served[0].Model != "b" {
+		t.Errorf("Expected the backup to be recorded, got %v", served)
+	}
+}
+
+func TestRouterAllFail(t *testing.T) {
+	router := NewRouter(
+		RouteProvider{Name: "primary", Client: failingLLM(errors.New("boom"))},
+		RouteProvider{Name: "backup", Client: failingLLM(errors.New("bang"))},
+	)
+
+	_, err := router.Summarize(context.Background(), "transcript", models.SummaryOptions{})
+	if err == nil || !strings.Contains(err.Error(), "boom") || !strings.Contains(err.Error(), "bang") {
+		t.Errorf("Expected both providers' errors, got %v", err)
+	}
+}
+
+func TestRouterTimeout(t *testing.T) {
+	slow := &MockLLMClient{
+		SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		},
+	}
+	router := NewRouter(
+		RouteProvider{Name: "slow", Client: slow, Timeout: 10 * time.Millisecond},
+		RouteProvider{Name: "fast", Client: answeringLLM("fast")},
+	)
+
+	summary, err := router.Summarize(context.Background(), "transcript", models.SummaryOptions{})
+	if err != nil || summary != "fast" {
+		t.Errorf("Expected a timed-out provider to fail over, got %q, %v", summary, err)
+	}
+}
+
+func TestRouterMaxInputTokens(t *testing.T) {
+	router := NewRouter(
+		RouteProvider{Name: "cheap", Client: answeringLLM("cheap"), MaxInputTokens: 50},
+		RouteProvider{Name: "long-context", Client: answeringLLM("long")},
+	)
+
+	short, _ := router.Summarize(context.Background(), words(10), models.SummaryOptions{})
+	long, _ := router.Summarize(context.Background(), words(500), models.SummaryOptions{})
+	if short != "cheap" || long != "long" {
+		t.Errorf("Expected short input on the cheap model and long input on the long-context one, got %q and %q", short, long)
+	}
+
+	_, err := NewRouter(RouteProvider{Name: "cheap", Client: answeringLLM("cheap"), MaxInputTokens: 50}).
+		Summarize(context.Background(), words(500), models.SummaryOptions{})
+	if err == nil {
+		t.Error("Expected an error when no provider accepts the input, got nil")
+	}
+}
+
+func TestRouterRateLimit(t *testing.T) {
+	limited := rate.NewLimiter(rate.Every(time.Hour), 1)
+	router := NewRouter(
+		RouteProvider{Name: "limited", Client: answeringLLM("limited"), Limiter: limited},
+		RouteProvider{Name: "other", Client: answeringLLM("other")},
+	)
+
+	first, _ := router.Summarize(context.Background(), "transcript", models.SummaryOptions{})
+	second, _ := router.Summarize(context.Background(), "transcript", models.SummaryOptions{})
+	if first != "limited" || second != "other" {
+		t.Errorf("Expected the second call to skip the rate-limited provider, got %q then %q", first, second)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := NewRouter(RouteProvider{Name: "limited", Client: answeringLLM("limited"), Limiter: limited}).
+		Summarize(ctx, "transcript", models.SummaryOptions{})
+	if err == nil {
+		t.Error("Expected waiting for the only, exhausted provider to fail with the context, got nil")
+	}
+}
+
+func TestRouterStreamNoFailoverAfterOutput(t *testing.T) {
+	partial := &MockLLMClient{
+		SummarizeStreamFunc: func(ctx context.Context, text string, onChunk func(string) error) (string, error) {
+			onChunk("Half a ")
+			return "", errors.New("connection reset")
+		},
+	}
+	backup := &MockLLMClient{
+		SummarizeStreamFunc: func(ctx context.Context, text string, onChunk func(string) error) (string, error) {
+			t.Error("Expected no failover after text was streamed")
+			return "", nil
+		},
+	}
+	router := NewRouter(
+		RouteProvider{Name: "partial", Client: partial},
+		RouteProvider{Name: "backup", Client: backup},
+	)
+
+	_, err := router.SummarizeStream(context.Background(), "transcript", models.SummaryOptions{}, func(string) error { return nil })
+	if err == nil {
+		t.Error("Expected the streaming error, got nil")
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"videoservice/internal/models"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultMapReduceThresholdTokens = 24000
+	defaultMapReduceChunkTokens     = 6000
+	defaultMapReduceConcurrency     = 4
+)
+
+// MapReduceConfig controls how long transcripts are summarized. Transcripts
+// above ThresholdTokens are split into ChunkTokens-sized parts, summarized
+// concurrently by at most Concurrency workers and then combined. Zero fields
+// fall back to the defaults.
+type MapReduceConfig struct {
+	ThresholdTokens int
+	ChunkTokens     int
+	Concurrency     int
+}
+
+func (c MapReduceConfig) withDefaults() MapReduceConfig {
+	if c.ThresholdTokens <= 0 {
+		c.ThresholdTokens = defaultMapReduceThresholdTokens
+	}
+	if c.ChunkTokens <= 0 {
+		c.ChunkTokens = defaultMapReduceChunkTokens
+	}
+	if c.ChunkTokens > c.ThresholdTokens {
+		c.ChunkTokens = c.ThresholdTokens
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultMapReduceConcurrency
+	}
+	return c
+}
+
+// EstimateTokens approximates the model token count of text. English prose
+// averages about three words per four tokens, which is close enough to decide
+// how to split a transcript without calling the tokenizer.
+func EstimateTokens(text string) int {
+	return (len(strings.Fields(text))*4 + 2) / 3
+}
+
+// SplitByTokens splits text at word boundaries into consecutive parts of at
+// most maxTokens estimated tokens each.
+func SplitByTokens(text string, maxTokens int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	maxWords := maxTokens * 3 / 4
+	if maxWords < 1 {
+		maxWords = 1
+	}
+
+	parts := make([]string, 0, (len(words)+maxWords-1)/maxWords)
+	for start := 0; start < len(words); start += maxWords {
+		end := start + maxWords
+		if end > len(words) {
+			end = len(words)
+		}
+		parts = append(parts, strings.Join(words[start:end], " "))
+	}
+	return parts
+}
+
+// partialSummaryOptions are used for the map and intermediate reduce steps,
+// which should keep as much detail as possible for the final summary.
+var partialSummaryOptions = models.SummaryOptions{
+	Style:  models.SummaryStyleDetailed,
+	Length: models.SummaryLengthMedium,
+}
+
+// summarizeTranscript summarizes a transcript in one LLM call when it fits
+// within the configured threshold, and with map-reduce otherwise. Only the
+// final step uses opts. A non-nil onChunk receives the final summary as it is
+// generated.
+func (s *VideoService) summarizeTranscript(ctx context.Context, transcript string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	cfg := s.mapReduce.withDefaults()
+
+	if EstimateTokens(transcript) <= cfg.ThresholdTokens {
+		if onChunk != nil {
+			return s.llmClient.SummarizeStream(ctx, transcript, opts, onChunk)
+		}
+		return s.llmClient.Summarize(ctx, transcript, opts)
+	}
+
+	parts := SplitByTokens(transcript, cfg.ChunkTokens)
+	log.Printf("Transcript of ~%d tokens exceeds %d, summarizing %d parts with map-reduce", EstimateTokens(transcript), cfg.ThresholdTokens, len(parts))
+
+	partials, err := s.mapSummaries(ctx, parts, cfg.Concurrency)
+	if err != nil {
+		return "", err
+	}
+
+	// Partial summaries of very long transcripts may themselves be too long
+	// to combine in one prompt, so merge them in groups until they fit.
+	for EstimateTokens(strings.Join(partials, "\n\n")) > cfg.ThresholdTokens {
+		groups := groupByTokens(partials, cfg.ChunkTokens)
+		if len(groups) >= len(partials) {
+			// Every partial is already larger than a chunk; grouping cannot
+			// make progress, so let the final step handle what remains.
+			break
+		}
+
+		combined, err := s.reduceGroups(ctx, groups, cfg.Concurrency)
+		if err != nil {
+			return "", err
+		}
+		partials = combined
+	}
+
+	return s.llmClient.CombineSummaries(ctx, partials, opts, onChunk)
+}
+
+// mapSummaries summarizes each part with at most concurrency calls in flight
+// and returns the summaries in the order of the parts.
+func (s *VideoService) mapSummaries(ctx context.Context, parts []string, concurrency int) ([]string, error) {
+	partials := make([]string, len(parts))
+	err := runBounded(ctx, len(parts), concurrency, func(ctx context.Context, i int) error {
+		summary, err := s.llmClient.Summarize(ctx, parts[i], partialSummaryOptions)
+		if err != nil {
+			return fmt.Errorf("failed to summarize part %d of %d: %w", i+1, len(parts), err)
+		}
+		partials[i] = summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return partials, nil
+}
+
+// reduceGroups combines each group of partial summaries into one, keeping the
+// groups in order.
+func (s *VideoService) reduceGroups(ctx context.Context, groups [][]string, concurrency int) ([]string, error) {
+	combined := make([]string, len(groups))
+	err := runBounded(ctx, len(groups), concurrency, func(ctx context.Context, i int) error {
+		if len(groups[i]) == 1 {
+			combined[i] = groups[i][0]
+			return nil
+		}
+		summary, err := s.llmClient.CombineSummaries(ctx, groups[i], partialSummaryOptions, nil)
+		if err != nil {
+			return fmt.Errorf("failed to combine summaries %d of %d: %w", i+1, len(groups), err)
+		}
+		combined[i] = summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return combined, nil
+}
+
+// runBounded calls fn for every index in [0, n) with at most concurrency
+// calls running at once. The first error cancels the context passed to the
+// remaining calls and is returned.
+func runBounded(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for i := 0; i < n; i++ {
+		g.Go(func() error {
+			return fn(ctx, i)
+		})
+	}
+	return g.Wait()
+}
+
+// groupByTokens packs consecutive summaries into groups of at most maxTokens
+// estimated tokens. A summary larger than maxTokens gets a group of its own.
+func groupByTokens(summaries []string, maxTokens int) [][]string {
+	var groups [][]string
+	var current []string
+	size := 0
+	for _, summary := range summaries {
+		tokens := EstimateTokens(summary)
+		if len(current) > 0 && size+tokens > maxTokens {
+			groups = append(groups, current)
+			current, size = nil, 0
+		}
+		current = append(current, summary)
+		size += tokens
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups
+}
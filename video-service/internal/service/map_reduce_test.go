@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"videoservice/internal/models"
+)
+
+func words(n int) string {
+	w := make([]string, n)
+	for i := range w {
+		w[i] = fmt.Sprintf("w%d", i)
+	}
+	return strings.Join(w, " ")
+}
+
+func TestSplitByTokens(t *testing.T) {
+	t.Run("RespectsLimit", func(t *testing.T) {
+		parts := SplitByTokens(words(100), 40)
+		if len(parts) != 4 {
+			t.Fatalf("Expected 4 parts, got %d", len(parts))
+		}
+		for i, p := range parts {
+			if tokens := EstimateTokens(p); tokens > 40 {
+				t.Errorf("Part %d has %d tokens, expected at most 40", i, tokens)
+			}
+		}
+		if strings.Join(parts, " ") != words(100) {
+			t.Error("Expected parts to rejoin into the original text")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if parts := SplitByTokens("   ", 40); len(parts) != 0 {
+			t.Errorf("Expected no parts, got %d", len(parts))
+		}
+	})
+}
+
+func TestSummarizeTranscript(t *testing.T) {
+	cfg := MapReduceConfig{ThresholdTokens: 100, ChunkTokens: 40, Concurrency: 2}
+
+	t.Run("ShortTranscriptUsesSingleCall", func(t *testing.T) {
+		var combined bool
+		svc := &VideoService{
+			mapReduce: cfg,
+			llmClient: &MockLLMClient{
+				CombineFunc: func(ctx context.Context, partials []string, onChunk func(string) error) (string, error) {
+					combined = true
+					return "", nil
+				},
+			},
+		}
+
+		summary, err := svc.summarizeTranscript(context.Background(), words(30), models.SummaryOptions{}, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary != "Mock summary" || combined {
+			t.Errorf("Expected a single Summarize call, got %q (combined=%v)", summary, combined)
+		}
+	})
+
+	t.Run("LongTranscriptUsesMapReduce", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		var combinedPartials []string
+		svc := &VideoService{
+			mapReduce: cfg,
+			llmClient: &MockLLMClient{
+				SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+					n := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						m := atomic.LoadInt32(&maxInFlight)
+						if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					return "summary of " + strings.Fields(text)[0], nil
+				},
+				CombineFunc: func(ctx context.Context, partials []string, onChunk func(string) error) (string, error) {
+					combinedPartials = partials
+					if onChunk != nil {
+						if err := onChunk("combined"); err != nil {
+							return "", err
+						}
+					}
+					return "combined", nil
+				},
+			},
+		}
+
+		var streamed []string
+		summary, err := svc.summarizeTranscript(context.Background(), words(300), models.SummaryOptions{}, func(delta string) error {
+			streamed = append(streamed, delta)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary != "combined" {
+			t.Errorf("Expected combined summary, got %q", summary)
+		}
+		if len(streamed) != 1 {
+			t.Errorf("Expected the reduce step to stream, got %d chunks", len(streamed))
+		}
+
+		// 300 words at 30 words (40 tokens) per part.
+		if len(combinedPartials) != 10 {
+			t.Fatalf("Expected 10 partial summaries, got %d", len(combinedPartials))
+		}
+		for i, p := range combinedPartials {
+			if want := fmt.Sprintf("summary of w%d", i*30); p != want {
+				t.Errorf("Partial %d: expected %q, got %q", i, want, p)
+			}
+		}
+		if maxInFlight > int32(cfg.Concurrency) {
+			t.Errorf("Expected at most %d concurrent calls, got %d", cfg.Concurrency, maxInFlight)
+		}
+	})
+
+	t.Run("ReducesLongPartialsInGroups", func(t *testing.T) {
+		var mu sync.Mutex
+		var groupCalls int
+		svc := &VideoService{
+			mapReduce: cfg,
+			llmClient: &MockLLMClient{
+				// Each partial is 20 tokens, so ten of them exceed the threshold.
+				SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+					return words(15), nil
+				},
+				CombineFunc: func(ctx context.Context, partials []string, onChunk func(string) error) (string, error) {
+					if onChunk == nil {
+						mu.Lock()
+						groupCalls++
+						mu.Unlock()
+					}
+					return words(15), nil
+				},
+			},
+		}
+
+		if _, err := svc.summarizeTranscript(context.Background(), words(300), models.SummaryOptions{}, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if groupCalls == 0 {
+			t.Error("Expected partial summaries to be combined in groups first")
+		}
+	})
+
+	t.Run("PropagatesPartError", func(t *testing.T) {
+		svc := &VideoService{
+			mapReduce: cfg,
+			llmClient: &MockLLMClient{
+				SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+					if strings.HasPrefix(text, "w60 ") {
+						return "", fmt.Errorf("quota exceeded")
+					}
+					return "ok", nil
+				},
+			},
+		}
+
+		_, err := svc.summarizeTranscript(context.Background(), words(300), models.SummaryOptions{}, nil)
+		if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+			t.Fatalf("Expected part error to be returned, got %v", err)
+		}
+	})
+}
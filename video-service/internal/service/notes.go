@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	maxNotesPerVideo = 200
+	maxNoteQuote     = 2000
+	maxNoteText      = 5000
+
+	// askNotes is how many of the user's notes on a video go into a
+	// question's prompt.
+	askNotes = 20
+)
+
+// NoteStore persists the highlights and notes users make on transcripts.
+type NoteStore interface {
+	SaveNote(ctx context.Context, note *models.Note) error
+	// ListNotes returns a user's notes, most recent first, or only those on
+	// one video in transcript order when videoID is set.
+	ListNotes(ctx context.Context, userID, videoID string) ([]models.Note, error)
+	// FindNotes returns a user's notes on the given videos in transcript
+	// order.
+	FindNotes(ctx context.Context, userID string, videoIDs []string) ([]models.Note, error)
+	// DeleteNote reports whether the user had a note with that ID.
+	DeleteNote(ctx context.Context, userID, id string) (bool, error)
+}
+
+// CreateNote highlights a stretch of a video's transcript, with an
+// optional quote of it and the user's own text.
+func (s *VideoService) CreateNote(ctx context.Context, req *pb.CreateNoteRequest) (*pb.Note, error) {
+	if s.notes == nil {
+		return nil, fmt.Errorf("notes are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if !videoIDPattern.MatchString(req.VideoId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid video id %q", req.VideoId)
+	}
+	end := req.EndSeconds
+	if end == 0 {
+		end = req.StartSeconds
+	}
+	if req.StartSeconds < 0 || end < req.StartSeconds {
+		return nil, status.Error(codes.InvalidArgument, "the note must end after it starts and start at or after 0")
+	}
+	quote := strings.TrimSpace(req.Quote)
+	text := strings.TrimSpace(req.Text)
+	if quote == "" && text == "" {
+		return nil, status.Error(codes.InvalidArgument, "a quote or a text is required")
+	}
+	if utf8.RuneCountInString(quote) > maxNoteQuote {
+		return nil, status.Errorf(codes.InvalidArgument, "quote must be at most %d characters", maxNoteQuote)
+	}
+	if utf8.RuneCountInString(text) > maxNoteText {
+		return nil, status.Errorf(codes.InvalidArgument, "text must be at most %d characters", maxNoteText)
+	}
+
+	existing, err := s.notes.ListNotes(ctx, req.UserId, req.VideoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	if len(existing) >= maxNotesPerVideo {
+		return nil, status.Errorf(codes.InvalidArgument, "a video can have at most %d notes", maxNotesPerVideo)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+	note := &models.Note{
+		ID:           id,
+		UserID:       req.UserId,
+		VideoID:      req.VideoId,
+		StartSeconds: req.StartSeconds,
+		EndSeconds:   end,
+		Quote:        quote,
+		Text:         text,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.notes.SaveNote(ctx, note); err != nil {
+		return nil, fmt.Errorf("failed to save note: %w", err)
+	}
+
+	log.Printf("Created note %s on video %s for user: %s", note.ID, note.VideoID, req.UserId)
+	return convertNoteToProto(note), nil
+}
+
+// ListNotes returns a user's notes on one video in transcript order, or all
+// of them most recent first.
+func (s *VideoService) ListNotes(ctx context.Context, req *pb.ListNotesRequest) (*pb.ListNotesResponse, error) {
+	if s.notes == nil {
+		return nil, fmt.Errorf("notes are not configured")
+	}
+	if req.VideoId != "" && !videoIDPattern.MatchString(req.VideoId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid video id %q", req.VideoId)
+	}
+	notes, err := s.notes.ListNotes(ctx, req.UserId, req.VideoId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	resp := &pb.ListNotesResponse{}
+	for i := range notes {
+		resp.Notes = append(resp.Notes, convertNoteToProto(&notes[i]))
+	}
+	return resp, nil
+}
+
+func (s *VideoService) DeleteNote(ctx context.Context, req *pb.DeleteNoteRequest) (*pb.DeleteNoteResponse, error) {
+	if s.notes == nil {
+		return nil, fmt.Errorf("notes are not configured")
+	}
+	deleted, err := s.notes.DeleteNote(ctx, req.UserId, req.NoteId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete note: %w", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "note %s not found", req.NoteId)
+	}
+	log.Printf("Deleted note %s for user: %s", req.NoteId, req.UserId)
+	return &pb.DeleteNoteResponse{}, nil
+}
+
+// videoNotes returns a user's notes on the given videos by video. Notes
+// only add context, so without a note store there are none.
+func (s *VideoService) videoNotes(ctx context.Context, userID string, videoIDs []string) (map[string][]models.Note, error) {
+	if s.notes == nil || userID == "" || len(videoIDs) == 0 {
+		return nil, nil
+	}
+	notes, err := s.notes.FindNotes(ctx, userID, videoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+	byVideo := make(map[string][]models.Note)
+	for _, note := range notes {
+		byVideo[note.VideoID] = append(byVideo[note.VideoID], note)
+	}
+	return byVideo, nil
+}
+
+func convertNoteToProto(note *models.Note) *pb.Note {
+	return &pb.Note{
+		NoteId:       note.ID,
+		VideoId:      note.VideoID,
+		StartSeconds: note.StartSeconds,
+		EndSeconds:   note.EndSeconds,
+		Quote:        note.Quote,
+		Text:         note.Text,
+		CreatedAt:    note.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockNoteStore struct {
+	mu    sync.Mutex
+	Notes map[string]models.Note
+}
+
+func (m *MockNoteStore) SaveNote(ctx context.Context, note *models.Note) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Notes == nil {
+		m.Notes = map[string]models.Note{}
+	}
+	m.Notes[note.ID] = *note
+	return nil
+}
+
+func (m *MockNoteStore) ListNotes(ctx context.Context, userID, videoID string) ([]models.Note, error) {
+	notes := m.find(func(note models.Note) bool {
+		return note.UserID == userID && (videoID == "" || note.VideoID == videoID)
+	})
+	if videoID == "" {
+		sort.Slice(notes, func(i, j int) bool { return notes[i].CreatedAt.After(notes[j].CreatedAt) })
+	}
+	return notes, nil
+}
+
+func (m *MockNoteStore) FindNotes(ctx context.Context, userID string, videoIDs []string) ([]models.Note, error) {
+	return m.find(func(note models.Note) bool {
+		return note.UserID == userID && slices.Contains(videoIDs, note.VideoID)
+	}), nil
+}
+
+func (m *MockNoteStore) DeleteNote(ctx context.Context, userID, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	note, ok := m.Notes[id]
+	if !ok || note.UserID != userID {
+		return false, nil
+	}
+	delete(m.Notes, id)
+	return true, nil
+}
+
+// find returns the matching notes in transcript order.
+func (m *MockNoteStore) find(match func(models.Note) bool) []models.Note {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var found []models.Note
+	for _, note := range m.Notes {
+		if match(note) {
+			found = append(found, note)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].StartSeconds < found[j].StartSeconds })
+	return found
+}
+
+func TestNotes(t *testing.T) {
+	svc := &VideoService{notes: &MockNoteStore{}}
+	ctx := context.Background()
+
+	later, err := svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber1", StartSeconds: 90, EndSeconds: 120, Quote: " the key point ", Text: "Check this"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if later.Quote != "the key point" || later.EndSeconds != 120 {
+		t.Errorf("Expected a note with a trimmed quote, got %v", later)
+	}
+	earlier, err := svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber1", StartSeconds: 30, Text: "Intro"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if earlier.EndSeconds != 30 {
+		t.Errorf("Expected the note to end where it starts, got %v", earlier.EndSeconds)
+	}
+	svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber2", Text: "Other video"})
+	svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-2", VideoId: "talkNumber1", Text: "Other user"})
+
+	resp, err := svc.ListNotes(ctx, &pb.ListNotesRequest{UserId: "user-1", VideoId: "talkNumber1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Notes) != 2 || resp.Notes[0].NoteId != earlier.NoteId || resp.Notes[1].NoteId != later.NoteId {
+		t.Errorf("Expected the user's notes on the video in transcript order, got %v", resp.Notes)
+	}
+	if resp, _ := svc.ListNotes(ctx, &pb.ListNotesRequest{UserId: "user-1"}); len(resp.Notes) != 3 {
+		t.Errorf("Expected all of the user's notes, got %v", resp.Notes)
+	}
+
+	if _, err := svc.DeleteNote(ctx, &pb.DeleteNoteRequest{UserId: "user-2", NoteId: later.NoteId}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound deleting another user's note, got %v", err)
+	}
+	if _, err := svc.DeleteNote(ctx, &pb.DeleteNoteRequest{UserId: "user-1", NoteId: later.NoteId}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp, _ := svc.ListNotes(ctx, &pb.ListNotesRequest{UserId: "user-1", VideoId: "talkNumber1"}); len(resp.Notes) != 1 {
+		t.Errorf("Expected the note deleted, got %v", resp.Notes)
+	}
+}
+
+func TestNotes_InvalidRequests(t *testing.T) {
+	svc := &VideoService{notes: &MockNoteStore{}}
+	ctx := context.Background()
+
+	checks := map[string]error{}
+	_, checks["no user"] = svc.CreateNote(ctx, &pb.CreateNoteRequest{VideoId: "talkNumber1", Text: "x"})
+	_, checks["bad video"] = svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "nope", Text: "x"})
+	_, checks["empty"] = svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber1", Text: "  "})
+	_, checks["negative start"] = svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber1", StartSeconds: -1, Text: "x"})
+	_, checks["ends before start"] = svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber1", StartSeconds: 60, EndSeconds: 30, Text: "x"})
+	_, checks["long text"] = svc.CreateNote(ctx, &pb.CreateNoteRequest{UserId: "user-1", VideoId: "talkNumber1", Text: strings.Repeat("a", maxNoteText+1)})
+	_, checks["list bad video"] = svc.ListNotes(ctx, &pb.ListNotesRequest{UserId: "user-1", VideoId: "nope"})
+	for name, err := range checks {
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+}
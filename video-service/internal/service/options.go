@@ -0,0 +1,134 @@
+package service
+
+// Option enables an optional VideoService feature.
+type Option func(*VideoService)
+
+// WithSemanticSearch enables transcript indexing and the SemanticSearch RPC.
+func WithSemanticSearch(embedder Embedder, store VectorStore) Option {
+	return func(s *VideoService) {
+		s.embedder = embedder
+		s.vectorStore = store
+	}
+}
+
+// WithConversations enables the AskVideo and GetConversation RPCs. They also
+// need semantic search to retrieve transcript passages.
+func WithConversations(store ConversationStore) Option {
+	return func(s *VideoService) {
+		s.conversations = store
+	}
+}
+
+// WithChapters enables the GenerateChapters RPC, caching chapters in store.
+func WithChapters(store ChapterStore) Option {
+	return func(s *VideoService) {
+		s.chapters = store
+	}
+}
+
+// WithSummaryCache caches plain summaries in store and lets digests, such
+// as SummarizeVideos and SummarizeChannel, reuse them.
+func WithSummaryCache(store SummaryStore) Option {
+	return func(s *VideoService) {
+		s.summaries = store
+	}
+}
+
+// WithUsageTracking stores the LLM token usage of every request per user,
+// priced with prices, and enables the GetUsage RPC.
+func WithUsageTracking(store UsageStore, prices PriceTable) Option {
+	return func(s *VideoService) {
+		s.usage = store
+		s.prices = prices
+	}
+}
+
+// WithMapReduce overrides when and how long transcripts are split into parts
+// that are summarized separately and then combined.
+func WithMapReduce(cfg MapReduceConfig) Option {
+	return func(s *VideoService) {
+		s.mapReduce = cfg
+	}
+}
+
+// WithQuotas enforces the daily and monthly summary and transcript quotas of
+// each plan in plans, counting requests per user in store.
+func WithQuotas(store QuotaStore, plans map[string]PlanQuotas) Option {
+	return func(s *VideoService) {
+		s.quotas = store
+		s.planQuotas = plans
+	}
+}
+
+// WithJobs enables the background job RPCs, storing jobs in store. RunJobs
+// must be running for queued jobs to be processed.
+func WithJobs(store JobStore, cfg JobConfig) Option {
+	return func(s *VideoService) {
+		s.jobs = store
+		s.jobConfig = cfg
+	}
+}
+
+// WithWebhooks enables the webhook RPCs and notifies users' webhooks of
+// events, storing webhooks and deliveries in store. RunWebhooks must be
+// running for deliveries to be sent.
+func WithWebhooks(store WebhookStore, cfg WebhookConfig) Option {
+	return func(s *VideoService) {
+		s.webhooks = store
+		s.webhookConfig = cfg
+	}
+}
+
+// WithSubscriptions enables the subscription RPCs, storing subscriptions and
+// feeds in store. RunSubscriptionSync must be running for new uploads to be
+// found.
+func WithSubscriptions(store SubscriptionStore, cfg SyncConfig) Option {
+	return func(s *VideoService) {
+		s.subscriptions = store
+		s.syncConfig = cfg
+	}
+}
+
+// WithPushNotifications has a WebSub hub push the uploads of followed
+// channels instead of polling for them, storing the leases in store. It
+// needs WithSubscriptions, and RunPushLeases must be running for the leases
+// to be kept.
+func WithPushNotifications(store PushLeaseStore, cfg PushConfig) Option {
+	return func(s *VideoService) {
+		s.pushLeases = store
+		s.pushConfig = cfg
+	}
+}
+
+// WithHistory records the videos each user views, reads the transcript of
+// and has summarized in store, and enables the GetFeed RPC.
+func WithHistory(store HistoryStore) Option {
+	return func(s *VideoService) {
+		s.history = store
+	}
+}
+
+// WithCollections enables the collection and tag RPCs, storing collections
+// and tags in store.
+func WithCollections(store CollectionStore) Option {
+	return func(s *VideoService) {
+		s.collections = store
+	}
+}
+
+// WithNotes enables the note RPCs, storing notes in store. A user's notes
+// on a video are also given to AskVideo and included in collection exports.
+func WithNotes(store NoteStore) Option {
+	return func(s *VideoService) {
+		s.notes = store
+	}
+}
+
+// WithSummaryFeeds enables the summary feed RPCs, storing the feed tokens
+// of users in store. A user's feed needs WithSubscriptions and the summaries
+// in it WithSummaryCache.
+func WithSummaryFeeds(store FeedTokenStore) Option {
+	return func(s *VideoService) {
+		s.feedTokens = store
+	}
+}
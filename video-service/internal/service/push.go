@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultPushHubURL   = "https://pubsubhubbub.appspot.com/subscribe"
+	defaultPushLease    = 5 * 24 * time.Hour
+	defaultPushInterval = time.Hour
+
+	// A lease request the hub has not verified is sent again after this.
+	pushRetryAfter = 30 * time.Minute
+	pushTimeout    = 10 * time.Second
+
+	pushSubscribe   = "subscribe"
+	pushUnsubscribe = "unsubscribe"
+
+	// youtubeFeedURL followed by a channel ID is the WebSub topic of the
+	// channel's uploads.
+	youtubeFeedURL = "https://www.youtube.com/xml/feeds/videos.xml?channel_id="
+)
+
+// PushConfig enables WebSub push notifications of uploads from followed
+// channels. The hub at HubURL is asked to post them to CallbackURL, signed
+// with Secret, for Lease at a time. Leases are checked every Interval and
+// renewed in their last fifth. Channels with a verified lease are left out
+// of the polling sync. Zero fields other than CallbackURL and Secret fall
+// back to the defaults.
+type PushConfig struct {
+	HubURL      string
+	CallbackURL string
+	Secret      string
+	Lease       time.Duration
+	Interval    time.Duration
+}
+
+func (c PushConfig) withDefaults() PushConfig {
+	if c.HubURL == "" {
+		c.HubURL = defaultPushHubURL
+	}
+	if c.Lease <= 0 {
+		c.Lease = defaultPushLease
+	}
+	if c.Interval <= 0 {
+		c.Interval = defaultPushInterval
+	}
+	return c
+}
+
+// PushLeaseStore persists the WebSub leases on followed channels.
+type PushLeaseStore interface {
+	// GetLease returns the lease on a channel, or nil if none was requested.
+	GetLease(ctx context.Context, channelID string) (*models.PushLease, error)
+	ListLeases(ctx context.Context) ([]models.PushLease, error)
+	SaveLease(ctx context.Context, lease *models.PushLease) error
+	DeleteLease(ctx context.Context, channelID string) error
+}
+
+// ConfirmPushSubscription answers a hub's verification of intent. A
+// subscription is confirmed only for a followed channel whose lease request
+// is pending, with the verify token sent with it, and for no longer than
+// the lease asked for. An unsubscription is confirmed only for a channel
+// nobody follows.
+func (s *VideoService) ConfirmPushSubscription(ctx context.Context, req *pb.ConfirmPushSubscriptionRequest) (*pb.ConfirmPushSubscriptionResponse, error) {
+	if s.pushLeases == nil {
+		return nil, fmt.Errorf("push notifications are not configured")
+	}
+	if !channelIDPattern.MatchString(req.ChannelId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %q", req.ChannelId)
+	}
+	subs, err := s.subscriptions.ChannelSubscribers(ctx, req.ChannelId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribers: %w", err)
+	}
+
+	switch req.Mode {
+	case pushSubscribe:
+		lease, err := s.pushLeases.GetLease(ctx, req.ChannelId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load lease: %w", err)
+		}
+		if lease == nil || len(subs) == 0 || lease.VerifyToken == "" || time.Since(lease.RequestedAt) > pushRetryAfter {
+			return nil, status.Errorf(codes.NotFound, "no push subscription to channel %s is pending", req.ChannelId)
+		}
+		if subtle.ConstantTimeCompare([]byte(req.VerifyToken), []byte(lease.VerifyToken)) != 1 {
+			return nil, status.Errorf(codes.PermissionDenied, "verify token of channel %s does not match", req.ChannelId)
+		}
+		leaseTime := time.Duration(req.LeaseSeconds) * time.Second
+		if leaseTime <= 0 || leaseTime > s.pushConfig.Lease {
+			leaseTime = s.pushConfig.Lease
+		}
+		lease.ExpiresAt = time.Now().Add(leaseTime)
+		lease.VerifyToken = ""
+		if err := s.pushLeases.SaveLease(ctx, lease); err != nil {
+			return nil, fmt.Errorf("failed to save lease: %w", err)
+		}
+		log.Printf("Uploads of channel %s are pushed until %s", req.ChannelId, lease.ExpiresAt.Format(time.RFC3339))
+	case pushUnsubscribe:
+		if len(subs) > 0 {
+			return nil, status.Errorf(codes.NotFound, "channel %s is still followed", req.ChannelId)
+		}
+		log.Printf("Uploads of channel %s are no longer pushed", req.ChannelId)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown mode %q", req.Mode)
+	}
+	return &pb.ConfirmPushSubscriptionResponse{}, nil
+}
+
+// IngestChannelUploads records uploads pushed by the hub the same way the
+// sync records polled ones. The work is done in the background: the hub
+// expects a quick answer and prefetching can take a while.
+func (s *VideoService) IngestChannelUploads(ctx context.Context, req *pb.IngestChannelUploadsRequest) (*pb.IngestChannelUploadsResponse, error) {
+	if s.subscriptions == nil {
+		return nil, fmt.Errorf("subscriptions are not configured")
+	}
+	if !channelIDPattern.MatchString(req.ChannelId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %q", req.ChannelId)
+	}
+	log.Printf("Ingesting %d pushed uploads of channel: %s", len(req.Videos), req.ChannelId)
+	go s.ingestUploads(context.WithoutCancel(ctx), req.ChannelId, req.Videos)
+	return &pb.IngestChannelUploadsResponse{}, nil
+}
+
+func (s *VideoService) ingestUploads(ctx context.Context, channelID string, uploads []*pb.VideoInfo) {
+	subs, err := s.subscriptions.ChannelSubscribers(ctx, channelID)
+	if err != nil {
+		log.Printf("Error listing subscribers of channel %s: %v", channelID, err)
+		return
+	}
+	if len(subs) == 0 {
+		log.Printf("Ignoring pushed uploads of unfollowed channel: %s", channelID)
+		return
+	}
+
+	var videos []models.Video
+	for _, upload := range uploads {
+		if upload.VideoId != "" {
+			videos = append(videos, s.uploadDetails(ctx, channelID, upload))
+		}
+	}
+	s.recordUploads(ctx, channelID, videos)
+}
+
+// uploadDetails returns a pushed upload's details from the cache or from
+// YouTube, caching them, or as pushed when neither has them.
+func (s *VideoService) uploadDetails(ctx context.Context, channelID string, upload *pb.VideoInfo) models.Video {
+	if s.videoRepo != nil && s.youtubeClient != nil {
+		if cached, err := s.videoRepo.GetCachedVideo(ctx, upload.VideoId, s.cacheMaxAge); err == nil {
+			return *cached
+		}
+		video, err := s.youtubeClient.GetVideoDetails(upload.VideoId)
+		if err == nil {
+			if err := s.videoRepo.CacheVideos(ctx, []models.Video{*video}); err != nil {
+				log.Printf("Error caching video %s: %v", upload.VideoId, err)
+			}
+			return *video
+		}
+		log.Printf("Error fetching details of pushed video %s: %v", upload.VideoId, err)
+	}
+	return models.Video{
+		VideoID:      upload.VideoId,
+		Title:        upload.Title,
+		Thumbnail:    upload.ThumbnailUrl,
+		PublishedAt:  upload.PublishedAt,
+		ChannelID:    channelID,
+		ChannelTitle: upload.ChannelTitle,
+	}
+}
+
+// RunPushLeases keeps a WebSub lease on every followed channel until ctx is
+// cancelled, and gives up the leases on channels nobody follows anymore.
+func (s *VideoService) RunPushLeases(ctx context.Context) {
+	if s.pushLeases == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.pushConfig.Interval)
+	defer ticker.Stop()
+	for {
+		s.renewPushLeases(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *VideoService) renewPushLeases(ctx context.Context) {
+	channels, err := s.subscriptions.SubscribedChannels(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error listing subscribed channels: %v", err)
+		}
+		return
+	}
+	leases, err := s.pushLeases.ListLeases(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error listing push leases: %v", err)
+		}
+		return
+	}
+
+	now := time.Now()
+	current := make(map[string]models.PushLease, len(leases))
+	for _, lease := range leases {
+		current[lease.ChannelID] = lease
+	}
+	followed := make(map[string]bool, len(channels))
+	for _, channelID := range channels {
+		followed[channelID] = true
+		lease, ok := current[channelID]
+		if ok && (lease.ExpiresAt.After(now.Add(s.pushConfig.Lease/5)) || lease.RequestedAt.After(now.Add(-pushRetryAfter))) {
+			continue
+		}
+		token, err := newID()
+		if err != nil {
+			log.Printf("Error generating verify token for channel %s: %v", channelID, err)
+			continue
+		}
+		// The hub may verify before answering, so the lease is saved first.
+		lease.ChannelID = channelID
+		lease.RequestedAt = now
+		lease.VerifyToken = token
+		if err := s.pushLeases.SaveLease(ctx, &lease); err != nil {
+			log.Printf("Error saving push lease on channel %s: %v", channelID, err)
+			continue
+		}
+		if err := s.requestPush(ctx, pushSubscribe, channelID, token); err != nil {
+			log.Printf("Error subscribing to pushed uploads of channel %s: %v", channelID, err)
+		}
+	}
+
+	for _, lease := range leases {
+		if followed[lease.ChannelID] {
+			continue
+		}
+		if err := s.pushLeases.DeleteLease(ctx, lease.ChannelID); err != nil {
+			log.Printf("Error deleting push lease on channel %s: %v", lease.ChannelID, err)
+			continue
+		}
+		if err := s.requestPush(ctx, pushUnsubscribe, lease.ChannelID, ""); err != nil {
+			log.Printf("Error unsubscribing from pushed uploads of channel %s: %v", lease.ChannelID, err)
+		}
+	}
+}
+
+// requestPush asks the hub to subscribe the callback to a channel's uploads
+// or to unsubscribe it. A non-empty verifyToken is sent for the hub to echo
+// when it verifies the request.
+func (s *VideoService) requestPush(ctx context.Context, mode, channelID, verifyToken string) error {
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	form := url.Values{
+		"hub.callback": {s.pushConfig.CallbackURL},
+		"hub.mode":     {mode},
+		"hub.topic":    {youtubeFeedURL + channelID},
+		"hub.verify":   {"async"},
+	}
+	if verifyToken != "" {
+		form.Set("hub.verify_token", verifyToken)
+	}
+	if mode == pushSubscribe {
+		form.Set("hub.lease_seconds", strconv.Itoa(int(s.pushConfig.Lease.Seconds())))
+		if s.pushConfig.Secret != "" {
+			form.Set("hub.secret", s.pushConfig.Secret)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.pushConfig.HubURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.pushClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("hub responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// polledChannels leaves out of channels those whose uploads the hub pushes
+// under a valid lease.
+func (s *VideoService) polledChannels(ctx context.Context, channels []string) []string {
+	if s.pushLeases == nil {
+		return channels
+	}
+	leases, err := s.pushLeases.ListLeases(ctx)
+	if err != nil {
+		log.Printf("Error listing push leases: %v", err)
+		return channels
+	}
+
+	now := time.Now()
+	pushed := make(map[string]bool, len(leases))
+	for _, lease := range leases {
+		if lease.ExpiresAt.After(now) {
+			pushed[lease.ChannelID] = true
+		}
+	}
+	var polled []string
+	for _, channelID := range channels {
+		if !pushed[channelID] {
+			polled = append(polled, channelID)
+		}
+	}
+	return polled
+}
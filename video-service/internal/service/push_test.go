@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockPushLeaseStore struct {
+	mu     sync.Mutex
+	Leases map[string]models.PushLease
+}
+
+func (m *MockPushLeaseStore) GetLease(ctx context.Context, channelID string) (*models.PushLease, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lease, ok := m.Leases[channelID]
+	if !ok {
+		return nil, nil
+	}
+	return &lease, nil
+}
+
+func (m *MockPushLeaseStore) ListLeases(ctx context.Context) ([]models.PushLease, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var leases []models.PushLease
+	for _, lease := range m.Leases {
+		leases = append(leases, lease)
+	}
+	return leases, nil
+}
+
+func (m *MockPushLeaseStore) SaveLease(ctx context.Context, lease *models.PushLease) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Leases == nil {
+		m.Leases = map[string]models.PushLease{}
+	}
+	m.Leases[lease.ChannelID] = *lease
+	return nil
+}
+
+func (m *MockPushLeaseStore) DeleteLease(ctx context.Context, channelID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.Leases, channelID)
+	return nil
+}
+
+// hubStandIn is a WebSub hub that verifies each (un)subscription request by
+// calling the callback with a challenge before accepting it, as
+// pubsubhubbub.appspot.com does for synchronous verification.
+type hubStandIn struct {
+	mu       sync.Mutex
+	requests []url.Values
+}
+
+func (h *hubStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	h.mu.Lock()
+	h.requests = append(h.requests, r.PostForm)
+	h.mu.Unlock()
+
+	verify := url.Values{
+		"hub.mode":      {r.PostForm.Get("hub.mode")},
+		"hub.topic":     {r.PostForm.Get("hub.topic")},
+		"hub.challenge": {"challenge-123"},
+	}
+	if lease := r.PostForm.Get("hub.lease_seconds"); lease != "" {
+		verify.Set("hub.lease_seconds", lease)
+	}
+	if token := r.PostForm.Get("hub.verify_token"); token != "" {
+		verify.Set("hub.verify_token", token)
+	}
+	resp, err := http.Get(r.PostForm.Get("hub.callback") + "?" + verify.Encode())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "challenge-123" {
+		http.Error(w, "verification failed", http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// callbackStandIn answers the hub's verification like the gateway does.
+func callbackStandIn(svc *VideoService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		topic, _ := url.Parse(q.Get("hub.topic"))
+		lease, _ := strconv.Atoi(q.Get("hub.lease_seconds"))
+		_, err := svc.ConfirmPushSubscription(r.Context(), &pb.ConfirmPushSubscriptionRequest{
+			ChannelId:    topic.Query().Get("channel_id"),
+			Mode:         q.Get("hub.mode"),
+			LeaseSeconds: int32(lease),
+			VerifyToken:  q.Get("hub.verify_token"),
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, q.Get("hub.challenge"))
+	}
+}
+
+func TestRenewPushLeases(t *testing.T) {
+	now := time.Now()
+	renewed := "UCrenewedchannel00000000"
+	current := "UCcurrentchannel00000000"
+	dropped := "UCdroppedchannel00000000"
+
+	subs := &MockSubscriptionStore{}
+	for _, channelID := range []string{testChannelID, renewed, current} {
+		subs.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: channelID, CreatedAt: now})
+	}
+	leases := &MockPushLeaseStore{Leases: map[string]models.PushLease{
+		renewed: {ChannelID: renewed, RequestedAt: now.Add(-96 * time.Hour), ExpiresAt: now.Add(12 * time.Hour)},
+		current: {ChannelID: current, RequestedAt: now.Add(-24 * time.Hour), ExpiresAt: now.Add(96 * time.Hour)},
+		dropped: {ChannelID: dropped, RequestedAt: now.Add(-24 * time.Hour), ExpiresAt: now.Add(96 * time.Hour)},
+	}}
+
+	hub := &hubStandIn{}
+	hubServer := httptest.NewServer(hub)
+	defer hubServer.Close()
+	svc := &VideoService{
+		subscriptions: subs,
+		pushLeases:    leases,
+		pushConfig:    PushConfig{HubURL: hubServer.URL, Secret: "s3cret"}.withDefaults(),
+		pushClient:    &http.Client{},
+	}
+	callback := httptest.NewServer(callbackStandIn(svc))
+	defer callback.Close()
+	svc.pushConfig.CallbackURL = callback.URL
+
+	svc.renewPushLeases(context.Background())
+
+	requested := map[string]url.Values{}
+	for _, form := range hub.requests {
+		requested[strings.TrimPrefix(form.Get("hub.topic"), youtubeFeedURL)] = form
+	}
+	if len(requested) != 3 {
+		t.Fatalf("Expected requests for the new, expiring and dropped channels, got %v", hub.requests)
+	}
+	form := requested[testChannelID]
+	if form.Get("hub.mode") != pushSubscribe || form.Get("hub.callback") != callback.URL || form.Get("hub.secret") != "s3cret" || form.Get("hub.lease_seconds") != "432000" {
+		t.Errorf("Expected a subscription with the callback, secret and lease, got %v", form)
+	}
+	if form.Get("hub.verify_token") == "" || form.Get("hub.verify_token") == requested[renewed].Get("hub.verify_token") {
+		t.Errorf("Expected a verify token for each request, got %v", form)
+	}
+	if requested[renewed].Get("hub.mode") != pushSubscribe {
+		t.Errorf("Expected the expiring lease renewed, got %v", requested[renewed])
+	}
+	if requested[dropped].Get("hub.mode") != pushUnsubscribe || requested[dropped].Get("hub.secret") != "" {
+		t.Errorf("Expected the unfollowed channel unsubscribed, got %v", requested[dropped])
+	}
+
+	for _, channelID := range []string{testChannelID, renewed} {
+		if lease := leases.Leases[channelID]; lease.ExpiresAt.Before(now.Add(119*time.Hour)) || lease.VerifyToken != "" {
+			t.Errorf("Expected the verified lease on %s to last 5 days, got %+v", channelID, lease)
+		}
+	}
+	if _, ok := leases.Leases[dropped]; ok {
+		t.Errorf("Expected the lease on the unfollowed channel deleted")
+	}
+
+	hub.requests = nil
+	svc.renewPushLeases(context.Background())
+	if len(hub.requests) != 0 {
+		t.Errorf("Expected no requests while the leases are valid, got %v", hub.requests)
+	}
+}
+
+func TestRenewPushLeases_Unverified(t *testing.T) {
+	subs := &MockSubscriptionStore{}
+	subs.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: testChannelID, CreatedAt: time.Now()})
+	leases := &MockPushLeaseStore{}
+	var requests int
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer hub.Close()
+	svc := &VideoService{
+		subscriptions: subs,
+		pushLeases:    leases,
+		pushConfig:    PushConfig{HubURL: hub.URL, CallbackURL: "https://example.com/websub/youtube"}.withDefaults(),
+		pushClient:    &http.Client{},
+	}
+
+	svc.renewPushLeases(context.Background())
+	svc.renewPushLeases(context.Background())
+	if requests != 1 {
+		t.Errorf("Expected an unverified request not repeated at once, got %d requests", requests)
+	}
+	if polled := svc.polledChannels(context.Background(), []string{testChannelID}); len(polled) != 1 {
+		t.Errorf("Expected the channel polled until the hub verifies, got %v", polled)
+	}
+
+	lease := leases.Leases[testChannelID]
+	lease.RequestedAt = time.Now().Add(-pushRetryAfter - time.Minute)
+	leases.SaveLease(context.Background(), &lease)
+	svc.renewPushLeases(context.Background())
+	if requests != 2 {
+		t.Errorf("Expected the request retried, got %d requests", requests)
+	}
+}
+
+func TestConfirmPushSubscription(t *testing.T) {
+	subs := &MockSubscriptionStore{}
+	subs.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: testChannelID, CreatedAt: time.Now()})
+	leases := &MockPushLeaseStore{}
+	svc := &VideoService{subscriptions: subs, pushLeases: leases, pushConfig: PushConfig{}.withDefaults()}
+
+	confirm := func(channelID, mode string) error {
+		_, err := svc.ConfirmPushSubscription(context.Background(), &pb.ConfirmPushSubscriptionRequest{ChannelId: channelID, Mode: mode, LeaseSeconds: 3600, VerifyToken: "token-1"})
+		return err
+	}
+	if err := confirm(testChannelID, pushSubscribe); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected an unrequested subscription refused, got %v", err)
+	}
+	if err := confirm(testChannelID, pushUnsubscribe); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected unsubscribing from a followed channel refused, got %v", err)
+	}
+	if err := confirm("UCunfollowedchannel00000", pushUnsubscribe); err != nil {
+		t.Errorf("Expected unsubscribing from an unfollowed channel confirmed, got %v", err)
+	}
+	if err := confirm(testChannelID, "denied"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+
+	leases.SaveLease(context.Background(), &models.PushLease{ChannelID: testChannelID, RequestedAt: time.Now().Add(-pushRetryAfter - time.Minute), VerifyToken: "token-1"})
+	if err := confirm(testChannelID, pushSubscribe); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected a verification of a stale request refused, got %v", err)
+	}
+	leases.SaveLease(context.Background(), &models.PushLease{ChannelID: testChannelID, RequestedAt: time.Now(), VerifyToken: "token-2"})
+	if err := confirm(testChannelID, pushSubscribe); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected a verification with another request's token refused, got %v", err)
+	}
+	if polled := svc.polledChannels(context.Background(), []string{testChannelID}); len(polled) != 1 {
+		t.Errorf("Expected the channel still polled after a refused verification, got %v", polled)
+	}
+
+	leases.SaveLease(context.Background(), &models.PushLease{ChannelID: testChannelID, RequestedAt: time.Now(), VerifyToken: "token-1"})
+	_, err := svc.ConfirmPushSubscription(context.Background(), &pb.ConfirmPushSubscriptionRequest{
+		ChannelId:    testChannelID,
+		Mode:         pushSubscribe,
+		LeaseSeconds: 1 << 30,
+		VerifyToken:  "token-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if lease := leases.Leases[testChannelID]; lease.ExpiresAt.After(time.Now().Add(svc.pushConfig.Lease)) || lease.VerifyToken != "" {
+		t.Errorf("Expected a verified lease no longer than requested, got %+v", lease)
+	}
+	if err := confirm(testChannelID, pushSubscribe); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected a second verification of the same request refused, got %v", err)
+	}
+	if polled := svc.polledChannels(context.Background(), []string{testChannelID, "UCotherchannel0000000000"}); len(polled) != 1 || polled[0] != "UCotherchannel0000000000" {
+		t.Errorf("Expected only the channel without a lease polled, got %v", polled)
+	}
+}
+
+func TestIngestUploads(t *testing.T) {
+	now := time.Now()
+	subs := &MockSubscriptionStore{}
+	subs.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: testChannelID, CreatedAt: now.Add(-time.Hour)})
+	svc := &VideoService{subscriptions: subs}
+
+	svc.ingestUploads(context.Background(), testChannelID, []*pb.VideoInfo{
+		{VideoId: "pushed00001", Title: "Pushed", ChannelTitle: "Chan", PublishedAt: now.Format(time.RFC3339)},
+		{Title: "No ID"},
+	})
+	svc.ingestUploads(context.Background(), "UCunfollowedchannel00000", []*pb.VideoInfo{
+		{VideoId: "unfollowed1", PublishedAt: now.Format(time.RFC3339)},
+	})
+
+	item, ok := subs.Feed["user-1:pushed00001"]
+	if len(subs.Feed) != 1 || !ok || item.Title != "Pushed" || item.ChannelID != testChannelID {
+		t.Errorf("Expected only the pushed upload of the followed channel in the feed, got %+v", subs.Feed)
+	}
+
+	if _, err := svc.IngestChannelUploads(context.Background(), &pb.IngestChannelUploadsRequest{ChannelId: "not-a-channel"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Quota kinds, the requests quotas are counted in.
+const (
+	QuotaSummaries   = "summaries"
+	QuotaTranscripts = "transcripts"
+)
+
+// planMetadataKey carries the caller's plan, set by the gateway from the
+// user's token.
+const planMetadataKey = "x-user-plan"
+
+const defaultPlan = "free"
+
+// QuotaLimits caps how many requests of one kind a user may make per UTC day
+// and per calendar month. Zero means unlimited.
+type QuotaLimits struct {
+	Daily   int64
+	Monthly int64
+}
+
+// PlanQuotas are a plan's limits per quota kind. Kinds without limits are
+// unlimited.
+type PlanQuotas map[string]QuotaLimits
+
+// DefaultPlanQuotas are the quotas of the free, pro and admin plans. Callers
+// on an unknown plan, or none, get the free plan's quotas.
+var DefaultPlanQuotas = map[string]PlanQuotas{
+	"free": {
+		QuotaSummaries:   {Daily: 10, Monthly: 100},
+		QuotaTranscripts: {Daily: 50, Monthly: 500},
+	},
+	"pro": {
+		QuotaSummaries:   {Daily: 200, Monthly: 3000},
+		QuotaTranscripts: {Daily: 1000, Monthly: 10000},
+	},
+	"admin": {},
+}
+
+// QuotaStore counts requests per user, quota kind and period.
+type QuotaStore interface {
+	// Add adds delta to the count and returns the new count.
+	Add(ctx context.Context, userID, kind, period string, delta int64) (int64, error)
+}
+
+// quotaState is where a user stands against the tightest of their quotas.
+type quotaState struct {
+	Plan      string
+	Limit     int64
+	Remaining int64
+	Reset     time.Time
+}
+
+// metadata returns the state as x-quota-* gRPC metadata, which the gateway
+// turns into response headers.
+func (q *quotaState) metadata() metadata.MD {
+	if q == nil {
+		return metadata.MD{}
+	}
+	return metadata.Pairs(
+		"x-quota-plan", q.Plan,
+		"x-quota-limit", strconv.FormatInt(q.Limit, 10),
+		"x-quota-remaining", strconv.FormatInt(q.Remaining, 10),
+		"x-quota-reset", strconv.FormatInt(q.Reset.Unix(), 10),
+	)
+}
+
+type quotaWindow struct {
+	name   string
+	period string
+	limit  int64
+	reset  time.Time
+}
+
+func quotaWindows(limits QuotaLimits, now time.Time) []quotaWindow {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return []quotaWindow{
+		{name: "daily", period: day.Format("2006-01-02"), limit: limits.Daily, reset: day.AddDate(0, 0, 1)},
+		{name: "monthly", period: month.Format("2006-01"), limit: limits.Monthly, reset: month.AddDate(0, 1, 0)},
+	}
+}
+
+// consumeQuota counts one request of kind against the user's quotas. It
+// returns the user's quota state, nil when quotas are disabled or the plan
+// has no limit for kind, and a release function that gives the request back,
+// for when it fails. When a quota is used up the error is ResourceExhausted
+// and the returned state is the exhausted quota's.
+func (s *VideoService) consumeQuota(ctx context.Context, userID, kind string) (*quotaState, func(), error) {
+	noop := func() {}
+	if s.quotas == nil {
+		return nil, noop, nil
+	}
+
+	plan := planFromContext(ctx)
+	quotas, ok := s.planQuotas[plan]
+	if !ok {
+		plan = defaultPlan
+		quotas = s.planQuotas[plan]
+	}
+	limits := quotas[kind]
+
+	var (
+		state    *quotaState
+		consumed []quotaWindow
+	)
+	release := func() {
+		// Give the request back even if the caller has gone away.
+		ctx := context.WithoutCancel(ctx)
+		for _, w := range consumed {
+			if _, err := s.quotas.Add(ctx, userID, kind, w.period, -1); err != nil {
+				log.Printf("Error releasing %s %s quota of user %s: %v", w.name, kind, userID, err)
+			}
+		}
+	}
+
+	for _, w := range quotaWindows(limits, time.Now()) {
+		if w.limit <= 0 {
+			continue
+		}
+		count, err := s.quotas.Add(ctx, userID, kind, w.period, 1)
+		if err != nil {
+			release()
+			return nil, noop, fmt.Errorf("failed to check %s quota: %w", kind, err)
+		}
+		consumed = append(consumed, w)
+
+		if count > w.limit {
+			release()
+			exhausted := &quotaState{Plan: plan, Limit: w.limit, Remaining: 0, Reset: w.reset}
+			return exhausted, noop, status.Errorf(codes.ResourceExhausted,
+				"%s %s quota of the %s plan (%d) is used up; it resets at %s",
+				w.name, kind, plan, w.limit, w.reset.Format(time.RFC3339))
+		}
+		if remaining := w.limit - count; state == nil || remaining < state.Remaining {
+			state = &quotaState{Plan: plan, Limit: w.limit, Remaining: remaining, Reset: w.reset}
+		}
+	}
+	return state, release, nil
+}
+
+// checkQuota is consumeQuota for unary RPCs: it also sends the quota state
+// to the caller, in the response headers or, when the quota is used up, the
+// trailers.
+func (s *VideoService) checkQuota(ctx context.Context, userID, kind string) (func(), error) {
+	state, release, err := s.consumeQuota(ctx, userID, kind)
+	if err != nil {
+		if state != nil {
+			grpc.SetTrailer(ctx, state.metadata())
+		}
+		return nil, err
+	}
+	if state != nil {
+		grpc.SetHeader(ctx, state.metadata())
+	}
+	return release, nil
+}
+
+// contextWithPlan has work done outside a request, such as prefetching,
+// count against the quotas of the given plan.
+func contextWithPlan(ctx context.Context, plan string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(planMetadataKey, plan))
+}
+
+func planFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return defaultPlan
+	}
+	if values := md.Get(planMetadataKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return defaultPlan
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockQuotaStore struct {
+	Counts map[string]int64
+}
+
+func (m *MockQuotaStore) Add(ctx context.Context, userID, kind, period string, delta int64) (int64, error) {
+	if m.Counts == nil {
+		m.Counts = map[string]int64{}
+	}
+	key := userID + ":" + kind + ":" + period
+	m.Counts[key] += delta
+	return m.Counts[key], nil
+}
+
+func (m *MockQuotaStore) total() int64 {
+	var total int64
+	for _, count := range m.Counts {
+		total += count
+	}
+	return total
+}
+
+func withPlan(plan string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(planMetadataKey, plan))
+}
+
+func TestConsumeQuota(t *testing.T) {
+	plans := map[string]PlanQuotas{
+		"free":  {QuotaSummaries: {Daily: 2, Monthly: 10}},
+		"pro":   {QuotaSummaries: {Daily: 5, Monthly: 3}},
+		"admin": {},
+	}
+
+	t.Run("Remaining", func(t *testing.T) {
+		svc := &VideoService{quotas: &MockQuotaStore{}, planQuotas: plans}
+		state, _, err := svc.consumeQuota(context.Background(), "user-1", QuotaSummaries)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if state.Plan != "free" || state.Limit != 2 || state.Remaining != 1 {
+			t.Errorf("Expected the free plan's daily quota with 1 remaining, got %+v", state)
+		}
+	})
+
+	t.Run("TightestWindow", func(t *testing.T) {
+		svc := &VideoService{quotas: &MockQuotaStore{}, planQuotas: plans}
+		state, _, err := svc.consumeQuota(withPlan("pro"), "user-1", QuotaSummaries)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if state.Plan != "pro" || state.Limit != 3 || state.Remaining != 2 {
+			t.Errorf("Expected the monthly quota with 2 remaining, got %+v", state)
+		}
+		if state.Reset.Day() != 1 {
+			t.Errorf("Expected the monthly quota to reset on the 1st, got %v", state.Reset)
+		}
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		store := &MockQuotaStore{}
+		svc := &VideoService{quotas: store, planQuotas: plans}
+		for i := 0; i < 2; i++ {
+			if _, _, err := svc.consumeQuota(context.Background(), "user-1", QuotaSummaries); err != nil {
+				t.Fatalf("Expected request %d to be allowed, got %v", i+1, err)
+			}
+		}
+
+		state, _, err := svc.consumeQuota(context.Background(), "user-1", QuotaSummaries)
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected ResourceExhausted, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "resets at") {
+			t.Errorf("Expected the error to give the reset time, got %v", err)
+		}
+		if state == nil || state.Remaining != 0 || state.Limit != 2 {
+			t.Errorf("Expected the exhausted daily quota, got %+v", state)
+		}
+		// Both windows hold the two allowed requests; the rejected one was
+		// rolled back.
+		if total := store.total(); total != 4 {
+			t.Errorf("Expected the rejected request not to be counted, got %v", store.Counts)
+		}
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		store := &MockQuotaStore{}
+		svc := &VideoService{quotas: store, planQuotas: plans}
+		_, release, err := svc.consumeQuota(context.Background(), "user-1", QuotaSummaries)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		release()
+		if total := store.total(); total != 0 {
+			t.Errorf("Expected a released request not to be counted, got %v", store.Counts)
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		store := &MockQuotaStore{}
+		svc := &VideoService{quotas: store, planQuotas: plans}
+		for i := 0; i < 5; i++ {
+			state, _, err := svc.consumeQuota(withPlan("admin"), "admin-1", QuotaSummaries)
+			if err != nil || state != nil {
+				t.Fatalf("Expected admins to be unlimited, got %+v, %v", state, err)
+			}
+		}
+		if len(store.Counts) != 0 {
+			t.Errorf("Expected nothing to be counted for unlimited quotas, got %v", store.Counts)
+		}
+	})
+
+	t.Run("UnknownPlan", func(t *testing.T) {
+		svc := &VideoService{quotas: &MockQuotaStore{}, planQuotas: plans}
+		state, _, err := svc.consumeQuota(withPlan("enterprise"), "user-1", QuotaSummaries)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if state.Plan != "free" {
+			t.Errorf("Expected unknown plans to get the free quotas, got %+v", state)
+		}
+	})
+}
+
+func TestGetVideoTranscriptQuota(t *testing.T) {
+	fail := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	defer ts.Close()
+
+	store := &MockQuotaStore{}
+	svc := &VideoService{
+		quotas:               store,
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaTranscripts: {Daily: 1}}},
+		transcriptServiceURL: ts.URL,
+	}
+	req := &pb.GetVideoTranscriptRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}
+
+	fail = true
+	if _, err := svc.GetVideoTranscript(context.Background(), req); err == nil {
+		t.Fatal("Expected an error from the transcript service, got nil")
+	}
+	if total := store.total(); total != 0 {
+		t.Fatalf("Expected failed requests not to count against the quota, got %v", store.Counts)
+	}
+
+	fail = false
+	if _, err := svc.GetVideoTranscript(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err := svc.GetVideoTranscript(context.Background(), req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestSummarizeVideoStreamQuota(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	defer ts.Close()
+
+	svc := &VideoService{
+		llmClient: &MockLLMClient{
+			SummarizeStreamFunc: func(ctx context.Context, text string, onChunk func(string) error) (string, error) {
+				return "A summary.", onChunk("A summary.")
+			},
+		},
+		quotas:               &MockQuotaStore{},
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaSummaries: {Daily: 1}}},
+		transcriptServiceURL: ts.URL,
+	}
+	req := &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}
+
+	stream := &mockSummaryStream{}
+	if err := svc.SummarizeVideoStream(req, stream); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := stream.header.Get("x-quota-remaining"); len(got) != 1 || got[0] != "0" {
+		t.Errorf("Expected the quota state in the headers, got %v", stream.header)
+	}
+
+	stream = &mockSummaryStream{}
+	err := svc.SummarizeVideoStream(req, stream)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted, got %v", err)
+	}
+	if stream.header != nil || len(stream.chunks) != 0 {
+		t.Errorf("Expected no headers or chunks once the quota is used up, got %v and %d chunks", stream.header, len(stream.chunks))
+	}
+	if got := stream.trailer.Get("x-quota-limit"); len(got) != 1 || got[0] != "1" {
+		t.Errorf("Expected the exhausted quota in the trailers, got %v", stream.trailer)
+	}
+}
+
+// timestampedTranscriptServer serves a transcript with timestamps for every
+// video.
+func timestampedTranscriptServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"transcript": "all about cats and then all about dogs",
+			"segments": []map[string]interface{}{
+				{"text": "all about cats", "start": 0.0, "duration": 5.0},
+				{"text": "and then all about dogs", "start": 5.0, "duration": 5.0},
+			},
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestGenerateChaptersQuota(t *testing.T) {
+	fail := true
+	store := &MockQuotaStore{}
+	svc := &VideoService{
+		llmClient: &MockLLMClient{
+			ChaptersFunc: func(ctx context.Context, transcript string) ([]models.Chapter, error) {
+				if fail {
+					return nil, errors.New("model down")
+				}
+				return []models.Chapter{{Title: "Cats"}}, nil
+			},
+		},
+		chapters:             &MockChapterStore{Chapters: map[string]*models.VideoChapters{}},
+		quotas:               store,
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaTranscripts: {Daily: 1}, QuotaSummaries: {Daily: 1}}},
+		transcriptServiceURL: timestampedTranscriptServer(t).URL,
+	}
+	req := &pb.GenerateChaptersRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}
+
+	if _, err := svc.GenerateChapters(context.Background(), req); err == nil {
+		t.Fatal("Expected an error from the LLM, got nil")
+	}
+	if total := store.total(); total != 0 {
+		t.Fatalf("Expected failed requests not to count against the quotas, got %v", store.Counts)
+	}
+
+	fail = false
+	if _, err := svc.GenerateChapters(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total := store.total(); total != 2 {
+		t.Errorf("Expected a transcript and a summary counted, got %v", store.Counts)
+	}
+	if _, err := svc.GenerateChapters(context.Background(), req); err != nil {
+		t.Errorf("Expected cached chapters without using the quota, got %v", err)
+	}
+	_, err := svc.GenerateChapters(context.Background(), &pb.GenerateChaptersRequest{VideoId: req.VideoId, UserId: req.UserId, Refresh: true})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestAskVideoQuota(t *testing.T) {
+	store := &MockQuotaStore{}
+	svc := &VideoService{
+		llmClient: &MockLLMClient{
+			AnswerFunc: func(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error) {
+				return "Cats come first [0:00].", nil
+			},
+		},
+		embedder:             &MockEmbedder{},
+		vectorStore:          &MockVectorStore{Chunks: map[string][]models.TranscriptChunk{}},
+		conversations:        &MockConversationStore{Conversations: map[string]*models.Conversation{}},
+		quotas:               store,
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaTranscripts: {Daily: 1}, QuotaSummaries: {Daily: 1}}},
+		transcriptServiceURL: timestampedTranscriptServer(t).URL,
+	}
+	req := &pb.AskVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1", Question: "what about cats?"}
+
+	if _, err := svc.AskVideo(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total := store.total(); total != 2 {
+		t.Errorf("Expected the indexed transcript and the answer counted, got %v", store.Counts)
+	}
+	if _, err := svc.AskVideo(context.Background(), req); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestSemanticSearchQuota(t *testing.T) {
+	store := &MockQuotaStore{}
+	vectors := &MockVectorStore{Chunks: map[string][]models.TranscriptChunk{}}
+	svc := &VideoService{
+		embedder:             &MockEmbedder{},
+		vectorStore:          vectors,
+		quotas:               store,
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaTranscripts: {Daily: 1}}},
+		transcriptServiceURL: timestampedTranscriptServer(t).URL,
+	}
+
+	_, err := svc.SemanticSearch(context.Background(), &pb.SemanticSearchRequest{
+		Query:    "cats",
+		UserId:   "user-1",
+		VideoIds: []string{"dQw4w9WgXcQ", "9bZkp7q19f0"},
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted for the second transcript, got %v", err)
+	}
+	if len(vectors.Chunks["dQw4w9WgXcQ"]) == 0 || len(vectors.Chunks["9bZkp7q19f0"]) != 0 {
+		t.Errorf("Expected only the first video indexed, got %v", vectors.Chunks)
+	}
+
+	_, err = svc.SemanticSearch(context.Background(), &pb.SemanticSearchRequest{Query: "cats", UserId: "user-1", VideoIds: []string{"dQw4w9WgXcQ"}})
+	if err != nil {
+		t.Errorf("Expected indexed videos searched without using the quota, got %v", err)
+	}
+}
+
+func TestExportQuota(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("videoId") == "missingVid1" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "no captions"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "all about cats"})
+	}))
+	defer ts.Close()
+
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	store := &MockQuotaStore{}
+	svc := &VideoService{
+		summaries: &MockSummaryStore{Summaries: map[string]*models.CachedSummary{
+			models.SummaryCacheKey("cachedVid01", opts): {Summary: "Cached summary"},
+			models.SummaryCacheKey("cachedVid02", opts): {Summary: "Cached summary"},
+		}},
+		collections: &MockCollectionStore{Collections: map[string]models.Collection{
+			"list": {ID: "list", UserID: "user-1", Name: "Talks", Items: []models.CollectionItem{
+				{VideoID: "cachedVid01", AddedAt: time.Now()},
+				{VideoID: "cachedVid02", AddedAt: time.Now()},
+			}},
+		}},
+		quotas:               store,
+		planQuotas:           map[string]PlanQuotas{"free": {QuotaTranscripts: {Daily: 2}}},
+		transcriptServiceURL: ts.URL,
+	}
+	ctx := context.Background()
+
+	if _, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "missingVid1", Transcript: true}); err == nil {
+		t.Fatal("Expected an error for a missing transcript, got nil")
+	}
+	if total := store.total(); total != 0 {
+		t.Fatalf("Expected failed exports not to count against the quota, got %v", store.Counts)
+	}
+
+	if _, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "cachedVid01", Transcript: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total := store.total(); total != 1 {
+		t.Fatalf("Expected the transcript counted, got %v", store.Counts)
+	}
+	if _, err := svc.ExportVideo(ctx, &pb.ExportVideoRequest{UserId: "user-1", VideoId: "cachedVid01"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total := store.total(); total != 1 {
+		t.Errorf("Expected exports without the transcript not to use the quota, got %v", store.Counts)
+	}
+
+	_, err := svc.ExportCollection(ctx, &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list", Transcript: true})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted for two transcripts, got %v", err)
+	}
+	if total := store.total(); total != 1 {
+		t.Errorf("Expected the refused export not to use the quota, got %v", store.Counts)
+	}
+	if _, err := svc.ExportCollection(ctx, &pb.ExportCollectionRequest{UserId: "user-1", CollectionId: "list"}); err != nil {
+		t.Errorf("Expected an export without transcripts, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultSearchTopK = 5
+	maxSearchTopK     = 50
+	// maxSearchVideos bounds the videos one search can be restricted to, as
+	// each of them may have to be indexed first.
+	maxSearchVideos = 10
+)
+
+// VectorStore persists embedded transcript chunks and ranks them against a
+// query vector.
+type VectorStore interface {
+	UpsertChunks(ctx context.Context, videoID string, chunks []models.TranscriptChunk) error
+	HasVideo(ctx context.Context, videoID string) (bool, error)
+	Search(ctx context.Context, query []float32, topK int, videoIDs []string) ([]models.ScoredChunk, error)
+}
+
+func (s *VideoService) SemanticSearch(ctx context.Context, req *pb.SemanticSearchRequest) (*pb.SemanticSearchResponse, error) {
+	log.Printf("Semantic search for user %s: %q", req.UserId, req.Query)
+	if s.embedder == nil || s.vectorStore == nil {
+		return nil, fmt.Errorf("semantic search is not configured")
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	if len(req.VideoIds) > maxSearchVideos {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d videos can be searched at once", maxSearchVideos)
+	}
+
+	topK := int(req.TopK)
+	if topK <= 0 || topK > maxSearchTopK {
+		topK = defaultSearchTopK
+	}
+
+	for _, videoID := range req.VideoIds {
+		if err := s.ensureIndexed(ctx, req.UserId, videoID); err != nil {
+			log.Printf("Error indexing video %s for semantic search: %v", videoID, err)
+			if status.Code(err) == codes.ResourceExhausted {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to index video %s: %w", videoID, err)
+		}
+	}
+
+	vectors, err := s.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		log.Printf("Error embedding search query: %v", err)
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for 1 query", len(vectors))
+	}
+
+	matches, err := s.vectorStore.Search(ctx, vectors[0], topK, req.VideoIds)
+	if err != nil {
+		log.Printf("Error searching vector store: %v", err)
+		return nil, fmt.Errorf("failed to search transcripts: %w", err)
+	}
+
+	results := make([]*pb.SemanticSearchResult, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, &pb.SemanticSearchResult{
+			VideoId:      m.VideoID,
+			ChunkIndex:   int32(m.Index),
+			Text:         m.Text,
+			StartSeconds: m.StartSeconds,
+			EndSeconds:   m.EndSeconds,
+			Score:        m.Score,
+		})
+	}
+
+	return &pb.SemanticSearchResponse{Results: results}, nil
+}
+
+// ensureIndexed chunks and embeds a video's transcript unless the vector store
+// already holds it. Fetching the transcript counts against the user's
+// transcript quota.
+func (s *VideoService) ensureIndexed(ctx context.Context, userID, videoID string) error {
+	indexed, err := s.vectorStore.HasVideo(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	if indexed {
+		return nil
+	}
+
+	release, err := s.checkQuota(ctx, userID, QuotaTranscripts)
+	if err != nil {
+		return err
+	}
+	if err := s.indexTranscript(ctx, videoID); err != nil {
+		release()
+		return err
+	}
+	return nil
+}
+
+func (s *VideoService) indexTranscript(ctx context.Context, videoID string) error {
+	log.Printf("Indexing transcript for video: %s", videoID)
+	transcript, err := s.fetchTranscript(ctx, videoID)
+	if err != nil {
+		return err
+	}
+
+	chunks := ChunkTranscript(videoID, segmentsOrPlain(transcript.Text, transcript.Segments), defaultChunkWords, defaultChunkOverlap)
+	if len(chunks) == 0 {
+		return fmt.Errorf("transcript is empty, nothing to index")
+	}
+
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		texts[i] = c.Text
+	}
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to embed transcript: %w", err)
+	}
+	if len(vectors) != len(chunks) {
+		return fmt.Errorf("embedder returned %d vectors for %d chunks", len(vectors), len(chunks))
+	}
+	for i := range chunks {
+		chunks[i].Embedding = vectors[i]
+	}
+
+	return s.vectorStore.UpsertChunks(ctx, videoID, chunks)
+}
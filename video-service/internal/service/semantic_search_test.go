@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockEmbedder struct {
+	Calls int
+}
+
+// Embed maps each text to a two-dimensional vector that points towards
+// "cats" or "dogs" depending on which word it mentions.
+func (m *MockEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	m.Calls++
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		switch {
+		case strings.Contains(text, "cats"):
+			vectors[i] = []float32{1, 0}
+		case strings.Contains(text, "dogs"):
+			vectors[i] = []float32{0, 1}
+		default:
+			vectors[i] = []float32{1, 1}
+		}
+	}
+	return vectors, nil
+}
+
+type MockVectorStore struct {
+	Chunks map[string][]models.TranscriptChunk
+}
+
+func (m *MockVectorStore) UpsertChunks(ctx context.Context, videoID string, chunks []models.TranscriptChunk) error {
+	m.Chunks[videoID] = chunks
+	return nil
+}
+
+func (m *MockVectorStore) HasVideo(ctx context.Context, videoID string) (bool, error) {
+	return len(m.Chunks[videoID]) > 0, nil
+}
+
+func (m *MockVectorStore) Search(ctx context.Context, query []float32, topK int, videoIDs []string) ([]models.ScoredChunk, error) {
+	var results []models.ScoredChunk
+	for _, chunks := range m.Chunks {
+		for _, c := range chunks {
+			if c.Embedding[0] == query[0] && c.Embedding[1] == query[1] {
+				results = append(results, models.ScoredChunk{TranscriptChunk: c, Score: 1})
+			}
+		}
+	}
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
+
+func TestSemanticSearch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"transcript": "all about cats and then all about dogs",
+			"segments": []map[string]interface{}{
+				{"text": "all about cats", "start": 0.0, "duration": 5.0},
+				{"text": "and then all about dogs", "start": 5.0, "duration": 5.0},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	embedder := &MockEmbedder{}
+	store := &MockVectorStore{Chunks: map[string][]models.TranscriptChunk{}}
+	svc := &VideoService{
+		transcriptServiceURL: ts.URL,
+		embedder:             embedder,
+		vectorStore:          store,
+	}
+
+	t.Run("IndexesRequestedVideos", func(t *testing.T) {
+		resp, err := svc.SemanticSearch(context.Background(), &pb.SemanticSearchRequest{
+			Query:    "cats",
+			UserId:   "test-user",
+			VideoIds: []string{"dQw4w9WgXcQ"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(store.Chunks["dQw4w9WgXcQ"]) == 0 {
+			t.Fatal("Expected video to be indexed")
+		}
+		if len(resp.Results) == 0 {
+			t.Fatal("Expected at least one result")
+		}
+		if resp.Results[0].VideoId != "dQw4w9WgXcQ" {
+			t.Errorf("Expected result from dQw4w9WgXcQ, got %q", resp.Results[0].VideoId)
+		}
+	})
+
+	t.Run("DoesNotReindex", func(t *testing.T) {
+		calls := embedder.Calls
+		_, err := svc.SemanticSearch(context.Background(), &pb.SemanticSearchRequest{
+			Query:    "dogs",
+			VideoIds: []string{"dQw4w9WgXcQ"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if embedder.Calls != calls+1 {
+			t.Errorf("Expected only the query to be embedded, got %d embed calls", embedder.Calls-calls)
+		}
+	})
+
+	t.Run("EmptyQuery", func(t *testing.T) {
+		_, err := svc.SemanticSearch(context.Background(), &pb.SemanticSearchRequest{Query: "  "})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument for empty query, got %v", err)
+		}
+	})
+
+	t.Run("TooManyVideos", func(t *testing.T) {
+		videoIDs := make([]string, maxSearchVideos+1)
+		for i := range videoIDs {
+			videoIDs[i] = fmt.Sprintf("video%06d", i)
+		}
+		calls := embedder.Calls
+		_, err := svc.SemanticSearch(context.Background(), &pb.SemanticSearchRequest{Query: "cats", VideoIds: videoIDs})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument for %d videos, got %v", len(videoIDs), err)
+		}
+		if embedder.Calls != calls {
+			t.Errorf("Expected nothing indexed, got %d embed calls", embedder.Calls-calls)
+		}
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, err := (&VideoService{}).SemanticSearch(context.Background(), &pb.SemanticSearchRequest{Query: "cats"})
+		if err == nil {
+			t.Fatal("Expected error when semantic search is not configured, got nil")
+		}
+	})
+}
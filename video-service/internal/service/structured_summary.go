@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"videoservice/internal/client/helpers"
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+// timestampLineSeconds is how much of the video each [m:ss]-prefixed line of
+// a timestamped transcript covers.
+const timestampLineSeconds = 30
+
+// timestampedTranscript prefixes the transcript with [m:ss] markers roughly
+// every timestampLineSeconds so that the LLM can place chapters. Without timed
+// segments the plain text is returned.
+func timestampedTranscript(text string, segments []models.TranscriptSegment) string {
+	if len(segments) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	lineStart := -float64(timestampLineSeconds)
+	for _, seg := range segments {
+		seg.Text = strings.TrimSpace(seg.Text)
+		if seg.Text == "" {
+			continue
+		}
+		if seg.Start-lineStart >= timestampLineSeconds {
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "[%s]", helpers.FormatTimestamp(seg.Start))
+			lineStart = seg.Start
+		}
+		b.WriteString(" ")
+		b.WriteString(seg.Text)
+	}
+	return b.String()
+}
+
+// summarizeStructured returns a structured summary of a timestamped
+// transcript. Long transcripts are split into parts that are summarized
+// concurrently and merged; the merged TL;DR is written from the parts' ones.
+func (s *VideoService) summarizeStructured(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+	cfg := s.mapReduce.withDefaults()
+	if EstimateTokens(transcript) <= cfg.ThresholdTokens {
+		return s.llmClient.SummarizeStructured(ctx, transcript, opts)
+	}
+
+	parts := SplitByTokens(transcript, cfg.ChunkTokens)
+	log.Printf("Transcript of ~%d tokens exceeds %d, summarizing %d parts into structured output", EstimateTokens(transcript), cfg.ThresholdTokens, len(parts))
+
+	results := make([]*models.StructuredSummary, len(parts))
+	err := runBounded(ctx, len(parts), cfg.Concurrency, func(ctx context.Context, i int) error {
+		result, err := s.llmClient.SummarizeStructured(ctx, parts[i], opts)
+		if err != nil {
+			return fmt.Errorf("failed to summarize part %d of %d: %w", i+1, len(parts), err)
+		}
+		results[i] = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergeStructured(results)
+
+	tldrs := make([]string, len(results))
+	for i, r := range results {
+		tldrs[i] = r.TLDR
+	}
+	tldrOpts := opts
+	tldrOpts.Style = models.SummaryStyleTLDR
+	merged.TLDR, err = s.llmClient.CombineSummaries(ctx, tldrs, tldrOpts, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine summaries: %w", err)
+	}
+	return merged, nil
+}
+
+// mergeStructured concatenates the structured summaries of consecutive parts,
+// dropping repeated list items. The title is taken from the first part.
+func mergeStructured(parts []*models.StructuredSummary) *models.StructuredSummary {
+	merged := &models.StructuredSummary{Title: parts[0].Title}
+	seen := make(map[string]bool)
+	add := func(kind, key string) bool {
+		key = kind + "\x00" + strings.ToLower(key)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+
+	for _, p := range parts {
+		merged.Chapters = append(merged.Chapters, p.Chapters...)
+		for _, t := range p.KeyTakeaways {
+			if add("takeaway", t) {
+				merged.KeyTakeaways = append(merged.KeyTakeaways, t)
+			}
+		}
+		for _, e := range p.Entities {
+			if add("entity", e.Name) {
+				merged.Entities = append(merged.Entities, e)
+			}
+		}
+		for _, r := range p.Resources {
+			if add("resource", r.Title) {
+				merged.Resources = append(merged.Resources, r)
+			}
+		}
+		for _, a := range p.ActionItems {
+			if add("action", a) {
+				merged.ActionItems = append(merged.ActionItems, a)
+			}
+		}
+	}
+	return merged
+}
+
+// renderStructuredMarkdown renders a structured summary as the Markdown
+// returned alongside it.
+func renderStructuredMarkdown(summary *models.StructuredSummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n**TL;DR:** %s\n", summary.Title, summary.TLDR)
+
+	if len(summary.Chapters) > 0 {
+		b.WriteString("\n## Chapters\n\n")
+		for _, c := range summary.Chapters {
+			fmt.Fprintf(&b, "- **%s** %s", helpers.FormatTimestamp(c.StartSeconds), c.Title)
+			if c.Summary != "" {
+				fmt.Fprintf(&b, ": %s", c.Summary)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	writeList(&b, "Key Takeaways", summary.KeyTakeaways)
+
+	if len(summary.Entities) > 0 {
+		b.WriteString("\n## Mentioned\n\n")
+		for _, e := range summary.Entities {
+			fmt.Fprintf(&b, "- %s (%s)\n", e.Name, e.Type)
+		}
+	}
+
+	if len(summary.Resources) > 0 {
+		b.WriteString("\n## Resources\n\n")
+		for _, r := range summary.Resources {
+			if r.URL != "" {
+				fmt.Fprintf(&b, "- [%s](%s)\n", r.Title, r.URL)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", r.Title)
+			}
+		}
+	}
+
+	writeList(&b, "Action Items", summary.ActionItems)
+
+	return b.String()
+}
+
+func writeList(b *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+}
+
+// convertStructuredToProto converts a structured summary, returning nil for
+// summaries that were not requested as structured.
+func convertStructuredToProto(summary *models.StructuredSummary) *pb.StructuredSummary {
+	if summary == nil {
+		return nil
+	}
+	result := &pb.StructuredSummary{
+		Title:        summary.Title,
+		Tldr:         summary.TLDR,
+		KeyTakeaways: summary.KeyTakeaways,
+		ActionItems:  summary.ActionItems,
+	}
+	for _, c := range summary.Chapters {
+		result.Chapters = append(result.Chapters, &pb.SummaryChapter{
+			Title:        c.Title,
+			StartSeconds: c.StartSeconds,
+			Summary:      c.Summary,
+		})
+	}
+	for _, e := range summary.Entities {
+		result.Entities = append(result.Entities, &pb.SummaryEntity{Name: e.Name, Type: e.Type})
+	}
+	for _, r := range summary.Resources {
+		result.Resources = append(result.Resources, &pb.SummaryResource{Title: r.Title, Url: r.URL})
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+func TestTimestampedTranscript(t *testing.T) {
+	segments := []models.TranscriptSegment{
+		{Text: "welcome", Start: 0},
+		{Text: "to the show", Start: 10},
+		{Text: "first topic", Start: 45},
+		{Text: "  ", Start: 50},
+		{Text: "second topic", Start: 3700},
+	}
+
+	got := timestampedTranscript("ignored", segments)
+	want := "[0:00] welcome to the show\n[0:45] first topic\n[1:01:40] second topic"
+	if got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	if got := timestampedTranscript("plain text", nil); got != "plain text" {
+		t.Errorf("Expected plain text without segments, got %q", got)
+	}
+}
+
+func TestSummarizeVideoStructured(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"transcript": "intro then the main part",
+			"segments": []map[string]interface{}{
+				{"text": "intro", "start": 0.0, "duration": 5.0},
+				{"text": "then the main part", "start": 75.0, "duration": 5.0},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	var gotTranscript string
+	mockLLM := &MockLLMClient{
+		StructuredFunc: func(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+			gotTranscript = transcript
+			return &models.StructuredSummary{
+				Title:        "A Talk",
+				TLDR:         "It has an intro and a main part.",
+				Chapters:     []models.SummaryChapter{{Title: "Intro", StartSeconds: 0}, {Title: "Main part", StartSeconds: 75}},
+				KeyTakeaways: []string{"Intros matter"},
+				Entities:     []models.SummaryEntity{{Name: "Go", Type: "product"}},
+				Resources:    []models.SummaryResource{{Title: "Go website", URL: "https://go.dev"}},
+				ActionItems:  []string{"Try it"},
+			}, nil
+		},
+	}
+	svc := &VideoService{
+		llmClient:            mockLLM,
+		transcriptServiceURL: ts.URL,
+	}
+
+	resp, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{
+		VideoId:    "dQw4w9WgXcQ",
+		UserId:     "test-user",
+		Structured: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(gotTranscript, "[1:15] then the main part") {
+		t.Errorf("Expected a timestamped transcript, got %q", gotTranscript)
+	}
+	if resp.Structured == nil || len(resp.Structured.Chapters) != 2 {
+		t.Fatalf("Expected structured summary with 2 chapters, got %+v", resp.Structured)
+	}
+	if resp.Structured.Chapters[1].StartSeconds != 75 || resp.Structured.Resources[0].Url != "https://go.dev" {
+		t.Errorf("Unexpected structured summary: %+v", resp.Structured)
+	}
+	for _, want := range []string{"# A Talk", "**1:15** Main part", "- [Go website](https://go.dev)", "## Action Items"} {
+		if !strings.Contains(resp.Summary, want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, resp.Summary)
+		}
+	}
+}
+
+func TestSummarizeStructuredMapReduce(t *testing.T) {
+	mockLLM := &MockLLMClient{
+		StructuredFunc: func(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+			first := strings.Fields(transcript)[0]
+			return &models.StructuredSummary{
+				Title:        "Part starting " + first,
+				TLDR:         "about " + first,
+				Chapters:     []models.SummaryChapter{{Title: first}},
+				KeyTakeaways: []string{"shared point", "point from " + first},
+			}, nil
+		},
+		CombineFunc: func(ctx context.Context, partials []string, onChunk func(string) error) (string, error) {
+			return strings.Join(partials, "; "), nil
+		},
+	}
+	svc := &VideoService{
+		llmClient: mockLLM,
+		mapReduce: MapReduceConfig{ThresholdTokens: 100, ChunkTokens: 40, Concurrency: 3},
+	}
+
+	summary, err := svc.summarizeStructured(context.Background(), words(90), models.SummaryOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if summary.Title != "Part starting w0" {
+		t.Errorf("Expected title of the first part, got %q", summary.Title)
+	}
+	if summary.TLDR != "about w0; about w30; about w60" {
+		t.Errorf("Expected combined TL;DR, got %q", summary.TLDR)
+	}
+	if last := mockLLM.Options[len(mockLLM.Options)-1]; last.Style != models.SummaryStyleTLDR {
+		t.Errorf("Expected TL;DR to be combined in the tldr style, got %s", last.Style)
+	}
+	if len(summary.Chapters) != 3 || summary.Chapters[2].Title != "w60" {
+		t.Errorf("Expected chapters of every part in order, got %+v", summary.Chapters)
+	}
+	if len(summary.KeyTakeaways) != 4 {
+		t.Errorf("Expected repeated takeaways to be merged, got %v", summary.KeyTakeaways)
+	}
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultSyncInterval    = 15 * time.Minute
+	defaultSyncConcurrency = 2
+	defaultSyncMaxVideos   = 15
+
+	maxSubscriptionsPerUser = 200
+	maxNewVideos            = 100
+)
+
+var channelIDPattern = regexp.MustCompile(`^UC[a-zA-Z0-9_-]{22}$`)
+
+// SyncConfig controls the background sync of subscribed channels. Every
+// Interval the latest MaxVideos uploads of each channel are fetched, at most
+// Concurrency channels at a time. Zero fields fall back to the defaults.
+type SyncConfig struct {
+	Interval    time.Duration
+	Concurrency int
+	MaxVideos   int
+}
+
+func (c SyncConfig) withDefaults() SyncConfig {
+	if c.Interval <= 0 {
+		c.Interval = defaultSyncInterval
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultSyncConcurrency
+	}
+	if c.MaxVideos <= 0 {
+		c.MaxVideos = defaultSyncMaxVideos
+	}
+	return c
+}
+
+// SubscriptionStore persists the channels users follow and the feed of
+// uploads found for them.
+type SubscriptionStore interface {
+	SaveSubscription(ctx context.Context, sub *models.Subscription) error
+	// GetSubscription returns a user's subscription to a channel, or nil if
+	// they do not follow it.
+	GetSubscription(ctx context.Context, userID, channelID string) (*models.Subscription, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]models.Subscription, error)
+	// DeleteSubscription reports whether the user followed the channel.
+	DeleteSubscription(ctx context.Context, userID, channelID string) (bool, error)
+	// SubscribedChannels returns the IDs of the channels anyone follows.
+	SubscribedChannels(ctx context.Context) ([]string, error)
+	ChannelSubscribers(ctx context.Context, channelID string) ([]models.Subscription, error)
+	// MarkVisited sets LastVisitedAt on all of a user's subscriptions.
+	MarkVisited(ctx context.Context, userID string, at time.Time) error
+
+	// AddFeedItem adds an upload to a user's feed unless it is already
+	// there, reporting whether it was added.
+	AddFeedItem(ctx context.Context, item *models.FeedItem) (bool, error)
+	// ListFeed returns up to limit of the items added to a user's feed after
+	// since, most recently added first.
+	ListFeed(ctx context.Context, userID string, since time.Time, limit int) ([]models.FeedItem, error)
+}
+
+// channelVideoEvent is the data of a channel.video_published event.
+type channelVideoEvent struct {
+	ChannelID string        `json:"channel_id"`
+	Video     *pb.VideoInfo `json:"video"`
+}
+
+func (s *VideoService) Subscribe(ctx context.Context, req *pb.SubscribeRequest) (*pb.Subscription, error) {
+	if s.subscriptions == nil {
+		return nil, fmt.Errorf("subscriptions are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if !channelIDPattern.MatchString(req.ChannelId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid channel id %q", req.ChannelId)
+	}
+	if !slices.Contains(models.PrefetchLevels, req.Prefetch) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown prefetch %q", req.Prefetch)
+	}
+
+	sub, err := s.subscriptions.GetSubscription(ctx, req.UserId, req.ChannelId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscription: %w", err)
+	}
+	if sub == nil {
+		existing, err := s.subscriptions.ListSubscriptions(ctx, req.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		if len(existing) >= maxSubscriptionsPerUser {
+			return nil, status.Errorf(codes.InvalidArgument, "at most %d channels can be followed", maxSubscriptionsPerUser)
+		}
+		now := time.Now()
+		sub = &models.Subscription{
+			UserID:        req.UserId,
+			ChannelID:     req.ChannelId,
+			ChannelTitle:  s.channelTitle(ctx, req.ChannelId),
+			CreatedAt:     now,
+			LastVisitedAt: now,
+		}
+	}
+	sub.Prefetch = req.Prefetch
+	sub.Plan = planFromContext(ctx)
+	if err := s.subscriptions.SaveSubscription(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to save subscription: %w", err)
+	}
+
+	log.Printf("User %s subscribed to channel: %s", req.UserId, req.ChannelId)
+	return convertSubscriptionToProto(sub, 0), nil
+}
+
+func (s *VideoService) Unsubscribe(ctx context.Context, req *pb.UnsubscribeRequest) (*pb.UnsubscribeResponse, error) {
+	if s.subscriptions == nil {
+		return nil, fmt.Errorf("subscriptions are not configured")
+	}
+	deleted, err := s.subscriptions.DeleteSubscription(ctx, req.UserId, req.ChannelId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "not subscribed to channel %s", req.ChannelId)
+	}
+	log.Printf("User %s unsubscribed from channel: %s", req.UserId, req.ChannelId)
+	return &pb.UnsubscribeResponse{}, nil
+}
+
+// ListSubscriptions returns the channels a user follows and the uploads
+// found since their last visit.
+func (s *VideoService) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	if s.subscriptions == nil {
+		return nil, fmt.Errorf("subscriptions are not configured")
+	}
+	subs, err := s.subscriptions.ListSubscriptions(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	uploads, err := s.newUploads(ctx, req.UserId, subs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListSubscriptionsResponse{}
+	newVideos := make(map[string]int32)
+	for i := range uploads {
+		newVideos[uploads[i].ChannelID]++
+		resp.NewVideos = append(resp.NewVideos, convertFeedItemToProto(&uploads[i]))
+	}
+	for i := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, convertSubscriptionToProto(&subs[i], newVideos[subs[i].ChannelID]))
+	}
+
+	if req.MarkVisited {
+		if err := s.subscriptions.MarkVisited(ctx, req.UserId, time.Now()); err != nil {
+			log.Printf("Error recording visit of user %s: %v", req.UserId, err)
+		}
+	}
+	return resp, nil
+}
+
+// newUploads returns the items added to a user's feed since they last
+// visited the subscription of each item's channel, most recently added
+// first. Items from channels they no longer follow are left out.
+func (s *VideoService) newUploads(ctx context.Context, userID string, subs []models.Subscription) ([]models.FeedItem, error) {
+	if len(subs) == 0 {
+		return nil, nil
+	}
+	lastVisits := make(map[string]time.Time, len(subs))
+	since := subs[0].LastVisitedAt
+	for _, sub := range subs {
+		lastVisits[sub.ChannelID] = sub.LastVisitedAt
+		if sub.LastVisitedAt.Before(since) {
+			since = sub.LastVisitedAt
+		}
+	}
+	feed, err := s.subscriptions.ListFeed(ctx, userID, since, maxNewVideos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feed: %w", err)
+	}
+
+	var uploads []models.FeedItem
+	for _, item := range feed {
+		if lastVisit, ok := lastVisits[item.ChannelID]; ok && item.AddedAt.After(lastVisit) {
+			uploads = append(uploads, item)
+		}
+	}
+	return uploads, nil
+}
+
+// channelTitle returns a channel's title from its latest uploads, or "" if
+// they are unavailable.
+func (s *VideoService) channelTitle(ctx context.Context, channelID string) string {
+	if s.videoRepo == nil || s.youtubeClient == nil {
+		return ""
+	}
+	resp, err := s.GetChannelVideos(ctx, &pb.GetChannelVideosRequest{ChannelId: channelID, MaxResults: 1})
+	if err != nil || len(resp.Videos) == 0 {
+		return ""
+	}
+	return resp.Videos[0].ChannelTitle
+}
+
+// RunSubscriptionSync polls the channels users follow for new uploads until
+// ctx is cancelled.
+func (s *VideoService) RunSubscriptionSync(ctx context.Context) {
+	if s.subscriptions == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.syncConfig.Interval)
+	defer ticker.Stop()
+	for {
+		s.syncSubscriptions(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *VideoService) syncSubscriptions(ctx context.Context) {
+	channels, err := s.subscriptions.SubscribedChannels(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error listing subscribed channels: %v", err)
+		}
+		return
+	}
+	channels = s.polledChannels(ctx, channels)
+	runBounded(ctx, len(channels), s.syncConfig.Concurrency, func(ctx context.Context, i int) error {
+		s.syncChannel(ctx, channels[i])
+		return nil
+	})
+}
+
+// syncChannel fetches a channel's latest uploads from YouTube, bypassing
+// the cache, caches them and adds the new ones to its subscribers' feeds.
+func (s *VideoService) syncChannel(ctx context.Context, channelID string) {
+	videos, _, err := s.youtubeClient.GetChannelVideos(channelID, s.syncConfig.MaxVideos, "")
+	if err != nil {
+		log.Printf("Error syncing channel %s: %v", channelID, err)
+		return
+	}
+	if err := s.videoRepo.CacheVideos(ctx, videos); err != nil {
+		log.Printf("Error caching videos of channel %s: %v", channelID, err)
+	}
+	s.recordUploads(ctx, channelID, videos)
+}
+
+// recordUploads adds each upload published after a user subscribed to
+// their feed, notifies them of it and prefetches what the subscriptions ask
+// for. Uploads already in a feed are skipped, so recording the same uploads
+// again does nothing.
+func (s *VideoService) recordUploads(ctx context.Context, channelID string, videos []models.Video) {
+	subs, err := s.subscriptions.ChannelSubscribers(ctx, channelID)
+	if err != nil {
+		log.Printf("Error listing subscribers of channel %s: %v", channelID, err)
+		return
+	}
+
+	for i := range videos {
+		video := &videos[i]
+		publishedAt, err := time.Parse(time.RFC3339, video.PublishedAt)
+		if err != nil {
+			continue
+		}
+
+		// The subscriber who asked for the most prefetching pays for it.
+		var prefetcher *models.Subscription
+		for j := range subs {
+			sub := &subs[j]
+			if publishedAt.Before(sub.CreatedAt) {
+				continue
+			}
+			added, err := s.subscriptions.AddFeedItem(ctx, &models.FeedItem{
+				UserID:       sub.UserID,
+				VideoID:      video.VideoID,
+				ChannelID:    channelID,
+				Title:        video.Title,
+				ChannelTitle: video.ChannelTitle,
+				Thumbnail:    video.Thumbnail,
+				PublishedAt:  video.PublishedAt,
+				AddedAt:      time.Now(),
+			})
+			if err != nil {
+				log.Printf("Error adding video %s to the feed of user %s: %v", video.VideoID, sub.UserID, err)
+				continue
+			}
+			if !added {
+				continue
+			}
+			s.notify(ctx, sub.UserID, models.EventChannelVideo, channelVideoEvent{
+				ChannelID: channelID,
+				Video:     s.convertVideoToProto(video),
+			})
+			if prefetcher == nil || slices.Index(models.PrefetchLevels, sub.Prefetch) > slices.Index(models.PrefetchLevels, prefetcher.Prefetch) {
+				prefetcher = sub
+			}
+		}
+		if prefetcher != nil && prefetcher.Prefetch != models.PrefetchNone {
+			s.prefetch(ctx, prefetcher, video.VideoID)
+		}
+	}
+}
+
+// prefetch does ahead of time what a subscription asks for: indexing a new
+// upload's transcript for search and questions, when they are enabled, and
+// caching its default summary, which count against the subscriber's
+// quotas. Failures are only logged; the work is redone on demand.
+func (s *VideoService) prefetch(ctx context.Context, sub *models.Subscription, videoID string) {
+	log.Printf("Prefetching %s of video %s for user: %s", sub.Prefetch, videoID, sub.UserID)
+	ctx = contextWithPlan(ctx, sub.Plan)
+	if s.vectorStore != nil && s.embedder != nil {
+		if err := s.ensureIndexed(ctx, sub.UserID, videoID); err != nil {
+			log.Printf("Error prefetching transcript of video %s: %v", videoID, err)
+		}
+	}
+	if sub.Prefetch != models.PrefetchSummary {
+		return
+	}
+
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	if s.summaries == nil || s.cachedSummary(ctx, videoID, opts) != "" {
+		return
+	}
+	_, release, err := s.consumeQuota(ctx, sub.UserID, QuotaSummaries)
+	if err != nil {
+		log.Printf("Not prefetching summary of video %s for user %s: %v", videoID, sub.UserID, err)
+		return
+	}
+	transcript, err := s.transcriptForSummary(ctx, &pb.SummarizeVideoRequest{VideoId: videoID})
+	if err != nil {
+		release()
+		return
+	}
+
+	llmCtx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, sub.UserID, videoID, models.OperationSummarize, calls)
+	summary, err := s.summarizeTranscript(llmCtx, transcript.Text, opts, nil)
+	if err != nil {
+		log.Printf("Error prefetching summary of video %s: %v", videoID, err)
+		release()
+		return
+	}
+	s.cacheSummary(ctx, videoID, opts, summary)
+}
+
+func convertSubscriptionToProto(sub *models.Subscription, newVideos int32) *pb.Subscription {
+	return &pb.Subscription{
+		ChannelId:    sub.ChannelID,
+		ChannelTitle: sub.ChannelTitle,
+		Prefetch:     sub.Prefetch,
+		CreatedAt:    sub.CreatedAt.UTC().Format(time.RFC3339),
+		NewVideos:    newVideos,
+	}
+}
+
+func convertFeedItemToProto(item *models.FeedItem) *pb.FeedItem {
+	return &pb.FeedItem{
+		VideoId:      item.VideoID,
+		Title:        item.Title,
+		ChannelId:    item.ChannelID,
+		ChannelTitle: item.ChannelTitle,
+		ThumbnailUrl: item.Thumbnail,
+		PublishedAt:  item.PublishedAt,
+		AddedAt:      item.AddedAt.UTC().Format(time.RFC3339),
+	}
+}
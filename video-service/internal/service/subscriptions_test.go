@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockSubscriptionStore struct {
+	mu            sync.Mutex
+	Subscriptions map[string]models.Subscription
+	Feed          map[string]models.FeedItem
+}
+
+func (m *MockSubscriptionStore) SaveSubscription(ctx context.Context, sub *models.Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Subscriptions == nil {
+		m.Subscriptions = map[string]models.Subscription{}
+	}
+	m.Subscriptions[sub.UserID+":"+sub.ChannelID] = *sub
+	return nil
+}
+
+func (m *MockSubscriptionStore) GetSubscription(ctx context.Context, userID, channelID string) (*models.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.Subscriptions[userID+":"+channelID]
+	if !ok {
+		return nil, nil
+	}
+	return &sub, nil
+}
+
+func (m *MockSubscriptionStore) ListSubscriptions(ctx context.Context, userID string) ([]models.Subscription, error) {
+	return m.find(func(sub models.Subscription) bool { return sub.UserID == userID }), nil
+}
+
+func (m *MockSubscriptionStore) DeleteSubscription(ctx context.Context, userID, channelID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.Subscriptions[userID+":"+channelID]
+	delete(m.Subscriptions, userID+":"+channelID)
+	return ok, nil
+}
+
+func (m *MockSubscriptionStore) SubscribedChannels(ctx context.Context) ([]string, error) {
+	seen := map[string]bool{}
+	var channels []string
+	for _, sub := range m.find(func(models.Subscription) bool { return true }) {
+		if !seen[sub.ChannelID] {
+			seen[sub.ChannelID] = true
+			channels = append(channels, sub.ChannelID)
+		}
+	}
+	return channels, nil
+}
+
+func (m *MockSubscriptionStore) ChannelSubscribers(ctx context.Context, channelID string) ([]models.Subscription, error) {
+	return m.find(func(sub models.Subscription) bool { return sub.ChannelID == channelID }), nil
+}
+
+func (m *MockSubscriptionStore) MarkVisited(ctx context.Context, userID string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, sub := range m.Subscriptions {
+		if sub.UserID == userID {
+			sub.LastVisitedAt = at
+			m.Subscriptions[id] = sub
+		}
+	}
+	return nil
+}
+
+func (m *MockSubscriptionStore) find(match func(models.Subscription) bool) []models.Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var subs []models.Subscription
+	for _, sub := range m.Subscriptions {
+		if match(sub) {
+			subs = append(subs, sub)
+		}
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].CreatedAt.Before(subs[j].CreatedAt) })
+	return subs
+}
+
+func (m *MockSubscriptionStore) AddFeedItem(ctx context.Context, item *models.FeedItem) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Feed == nil {
+		m.Feed = map[string]models.FeedItem{}
+	}
+	item.ID = item.UserID + ":" + item.VideoID
+	if _, ok := m.Feed[item.ID]; ok {
+		return false, nil
+	}
+	m.Feed[item.ID] = *item
+	return true, nil
+}
+
+func (m *MockSubscriptionStore) ListFeed(ctx context.Context, userID string, since time.Time, limit int) ([]models.FeedItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var items []models.FeedItem
+	for _, item := range m.Feed {
+		if item.UserID == userID && item.AddedAt.After(since) {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].AddedAt.After(items[j].AddedAt) })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+const testChannelID = "UCabcdefghijklmnopqrstuv"
+
+func TestSubscribe(t *testing.T) {
+	store := &MockSubscriptionStore{}
+	svc := &VideoService{subscriptions: store}
+
+	for _, req := range []*pb.SubscribeRequest{
+		{UserId: "user-1", ChannelId: "not-a-channel"},
+		{UserId: "user-1", ChannelId: testChannelID, Prefetch: "everything"},
+		{ChannelId: testChannelID},
+	} {
+		if _, err := svc.Subscribe(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %+v, got %v", req, err)
+		}
+	}
+
+	sub, err := svc.Subscribe(withPlan("pro"), &pb.SubscribeRequest{UserId: "user-1", ChannelId: testChannelID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	saved, _ := store.GetSubscription(context.Background(), "user-1", testChannelID)
+	if saved == nil || saved.Plan != "pro" || saved.CreatedAt.IsZero() {
+		t.Fatalf("Expected the subscription saved with the user's plan, got %+v", saved)
+	}
+
+	again, err := svc.Subscribe(context.Background(), &pb.SubscribeRequest{UserId: "user-1", ChannelId: testChannelID, Prefetch: models.PrefetchSummary})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if again.Prefetch != models.PrefetchSummary || again.CreatedAt != sub.CreatedAt {
+		t.Errorf("Expected subscribing again to only change prefetch, got %+v", again)
+	}
+
+	if _, err := svc.Unsubscribe(context.Background(), &pb.UnsubscribeRequest{UserId: "user-1", ChannelId: testChannelID}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := svc.Unsubscribe(context.Background(), &pb.UnsubscribeRequest{UserId: "user-1", ChannelId: testChannelID}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
+
+func TestRecordUploads(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "Talk " + r.URL.Query().Get("videoId")})
+	}))
+	defer ts.Close()
+
+	now := time.Now()
+	store := &MockSubscriptionStore{}
+	store.SaveSubscription(context.Background(), &models.Subscription{UserID: "early", ChannelID: testChannelID, Prefetch: models.PrefetchSummary, Plan: "pro", CreatedAt: now.Add(-48 * time.Hour), LastVisitedAt: now.Add(-48 * time.Hour)})
+	store.SaveSubscription(context.Background(), &models.Subscription{UserID: "late", ChannelID: testChannelID, CreatedAt: now.Add(-time.Hour), LastVisitedAt: now.Add(-time.Hour)})
+	webhooks := &MockWebhookStore{}
+	webhooks.SaveWebhook(context.Background(), &models.Webhook{ID: "hook-1", UserID: "early", URL: "https://example.com/hook"})
+	summaries := &MockSummaryStore{Summaries: map[string]*models.CachedSummary{}}
+	quotas := &MockQuotaStore{}
+	var summarized int
+	svc := &VideoService{
+		llmClient: &MockLLMClient{SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			summarized++
+			return "Summary of " + text, nil
+		}},
+		subscriptions:        store,
+		webhooks:             webhooks,
+		summaries:            summaries,
+		quotas:               quotas,
+		planQuotas:           DefaultPlanQuotas,
+		transcriptServiceURL: ts.URL,
+	}
+
+	videos := []models.Video{
+		{VideoID: "yesterday01", Title: "Yesterday", ChannelTitle: "Chan", PublishedAt: now.Add(-24 * time.Hour).Format(time.RFC3339)},
+		{VideoID: "lastweek001", Title: "Last week", PublishedAt: now.AddDate(0, 0, -7).Format(time.RFC3339)},
+	}
+	svc.recordUploads(context.Background(), testChannelID, videos)
+	svc.recordUploads(context.Background(), testChannelID, videos)
+
+	if len(store.Feed) != 1 {
+		t.Fatalf("Expected only the upload after subscribing recorded, once, got %+v", store.Feed)
+	}
+	if _, ok := store.Feed["early:yesterday01"]; !ok {
+		t.Errorf("Expected the upload in the early subscriber's feed, got %+v", store.Feed)
+	}
+	if len(webhooks.Deliveries) != 1 {
+		t.Errorf("Expected one channel.video_published delivery, got %d", len(webhooks.Deliveries))
+	}
+	for _, d := range webhooks.Deliveries {
+		if d.Event != models.EventChannelVideo {
+			t.Errorf("Expected a %s event, got %s", models.EventChannelVideo, d.Event)
+		}
+	}
+	opts, _ := models.ParseSummaryOptions("", "", "")
+	if cached, _ := summaries.Get(context.Background(), "yesterday01", opts); cached == nil || summarized != 1 {
+		t.Errorf("Expected the summary prefetched once, got %+v after %d calls", cached, summarized)
+	}
+	if quotas.Counts["early:summaries:"+now.UTC().Format("2006-01-02")] != 1 {
+		t.Errorf("Expected the prefetch counted against the subscriber's quota, got %+v", quotas.Counts)
+	}
+}
+
+func TestListSubscriptions(t *testing.T) {
+	now := time.Now()
+	otherChannel := "UCzyxwvutsrqponmlkjihgfe"
+	store := &MockSubscriptionStore{}
+	store.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: testChannelID, CreatedAt: now.Add(-72 * time.Hour), LastVisitedAt: now.Add(-2 * time.Hour)})
+	store.SaveSubscription(context.Background(), &models.Subscription{UserID: "user-1", ChannelID: otherChannel, CreatedAt: now.Add(-48 * time.Hour), LastVisitedAt: now.Add(-2 * time.Hour)})
+	for _, item := range []models.FeedItem{
+		{UserID: "user-1", VideoID: "seen0000001", ChannelID: testChannelID, AddedAt: now.Add(-3 * time.Hour)},
+		{UserID: "user-1", VideoID: "new00000001", ChannelID: testChannelID, AddedAt: now.Add(-time.Hour)},
+		{UserID: "user-1", VideoID: "new00000002", ChannelID: otherChannel, AddedAt: now.Add(-time.Minute)},
+		{UserID: "user-1", VideoID: "unfollowed1", ChannelID: "UCunfollowedchannel00000", AddedAt: now.Add(-time.Minute)},
+	} {
+		store.AddFeedItem(context.Background(), &item)
+	}
+	svc := &VideoService{subscriptions: store}
+
+	resp, err := svc.ListSubscriptions(context.Background(), &pb.ListSubscriptionsRequest{UserId: "user-1", MarkVisited: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Subscriptions) != 2 || resp.Subscriptions[0].NewVideos != 1 || resp.Subscriptions[1].NewVideos != 1 {
+		t.Errorf("Expected one new video per channel, got %+v", resp.Subscriptions)
+	}
+	if len(resp.NewVideos) != 2 || resp.NewVideos[0].VideoId != "new00000002" {
+		t.Errorf("Expected the new videos from followed channels, newest first, got %+v", resp.NewVideos)
+	}
+
+	resp, err = svc.ListSubscriptions(context.Background(), &pb.ListSubscriptionsRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.NewVideos) != 0 {
+		t.Errorf("Expected nothing new after the visit, got %+v", resp.NewVideos)
+	}
+}
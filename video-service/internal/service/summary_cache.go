@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"videoservice/internal/models"
+)
+
+// SummaryStore caches generated Markdown summaries per video and options, so
+// digests can reuse them.
+type SummaryStore interface {
+	Get(ctx context.Context, videoID string, opts models.SummaryOptions) (*models.CachedSummary, error)
+	Save(ctx context.Context, summary *models.CachedSummary) error
+}
+
+// cachedSummary returns the cached summary of a video written with opts, or
+// "" if there is none.
+func (s *VideoService) cachedSummary(ctx context.Context, videoID string, opts models.SummaryOptions) string {
+	if s.summaries == nil {
+		return ""
+	}
+	cached, err := s.summaries.Get(ctx, videoID, opts)
+	if err != nil {
+		log.Printf("Error loading cached summary of video %s: %v", videoID, err)
+		return ""
+	}
+	if cached == nil {
+		return ""
+	}
+	log.Printf("Cache hit for summary of video: %s", videoID)
+	return cached.Summary
+}
+
+// cacheSummary stores a summary for reuse. Failures are only logged; the
+// summary has already been generated.
+func (s *VideoService) cacheSummary(ctx context.Context, videoID string, opts models.SummaryOptions, summary string) {
+	if s.summaries == nil {
+		return
+	}
+	if err := s.summaries.Save(ctx, &models.CachedSummary{
+		VideoID:     videoID,
+		Options:     opts,
+		Summary:     summary,
+		GeneratedAt: time.Now(),
+	}); err != nil {
+		log.Printf("Error caching summary of video %s: %v", videoID, err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultUsageDays = 30
+	maxUsageDays     = 366
+)
+
+// ModelPrice is what a model costs, in US dollars per million tokens.
+type ModelPrice struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+// PriceTable maps model names to their prices. Models missing from the table
+// are recorded with a cost of zero.
+type PriceTable map[string]ModelPrice
+
+// DefaultPrices are the providers' list prices for the default models.
+var DefaultPrices = PriceTable{
+	"gemini-2.5-flash":        {InputPerMillion: 0.30, OutputPerMillion: 2.50},
+	"gemini-2.5-flash-lite":   {InputPerMillion: 0.10, OutputPerMillion: 0.40},
+	"gemini-2.5-pro":          {InputPerMillion: 1.25, OutputPerMillion: 10.00},
+	"gpt-4o-mini":             {InputPerMillion: 0.15, OutputPerMillion: 0.60},
+	"gpt-4o":                  {InputPerMillion: 2.50, OutputPerMillion: 10.00},
+	"claude-3-5-haiku-latest": {InputPerMillion: 0.80, OutputPerMillion: 4.00},
+}
+
+// Cost estimates the cost of a call to model in US dollars.
+func (t PriceTable) Cost(model string, promptTokens, completionTokens int) float64 {
+	price, ok := t[model]
+	if !ok {
+		return 0
+	}
+	return (float64(promptTokens)*price.InputPerMillion + float64(completionTokens)*price.OutputPerMillion) / 1e6
+}
+
+// UsageStore persists LLM token usage per user.
+type UsageStore interface {
+	Record(ctx context.Context, records ...models.UsageRecord) error
+	Totals(ctx context.Context, userID string, since time.Time) ([]models.ModelUsage, error)
+}
+
+var (
+	llmMeter     = otel.Meter("videoservice")
+	llmCalls, _  = llmMeter.Int64Counter("llm.calls", metric.WithDescription("LLM calls that reported token usage"), metric.WithUnit("{call}"))
+	llmTokens, _ = llmMeter.Int64Counter("llm.tokens", metric.WithDescription("Tokens consumed by LLM calls"), metric.WithUnit("{token}"))
+	llmCost, _   = llmMeter.Float64Counter("llm.cost", metric.WithDescription("Estimated cost of LLM calls"), metric.WithUnit("USD"))
+)
+
+// saveUsage prices the LLM usage recorded while serving a request, exports
+// it as metrics and, with usage tracking enabled, stores it for the user.
+func (s *VideoService) saveUsage(ctx context.Context, userID, videoID, operation string, calls *callRecorder) {
+	records := calls.usageRecords()
+	if len(records) == 0 {
+		return
+	}
+	// The usage was incurred even if the caller has gone away.
+	ctx = context.WithoutCancel(ctx)
+
+	now := time.Now()
+	for i := range records {
+		r := &records[i]
+		r.UserID = userID
+		r.VideoID = videoID
+		r.Operation = operation
+		r.CostUSD = s.prices.Cost(r.Model, r.PromptTokens, r.CompletionTokens)
+		r.CreatedAt = now
+
+		attrs := metric.WithAttributes(
+			attribute.String("provider", r.Provider),
+			attribute.String("model", r.Model),
+			attribute.String("operation", operation),
+		)
+		llmCalls.Add(ctx, int64(r.Calls), attrs)
+		llmTokens.Add(ctx, int64(r.PromptTokens), attrs, metric.WithAttributes(attribute.String("token.type", "input")))
+		llmTokens.Add(ctx, int64(r.CompletionTokens), attrs, metric.WithAttributes(attribute.String("token.type", "output")))
+		llmCost.Add(ctx, r.CostUSD, attrs)
+	}
+
+	if s.usage == nil || userID == "" {
+		return
+	}
+	if err := s.usage.Record(ctx, records...); err != nil {
+		log.Printf("Error recording LLM usage for user %s: %v", userID, err)
+	}
+}
+
+func (s *VideoService) GetUsage(ctx context.Context, req *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	if s.usage == nil {
+		return nil, fmt.Errorf("usage tracking is not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	days := int(req.Days)
+	if days == 0 {
+		days = defaultUsageDays
+	}
+	if days < 0 || days > maxUsageDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be between 1 and %d", maxUsageDays)
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	totals, err := s.usage.Totals(ctx, req.UserId, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+
+	resp := &pb.GetUsageResponse{
+		UserId: req.UserId,
+		Since:  since.UTC().Format(time.RFC3339),
+	}
+	for _, t := range totals {
+		resp.Calls += t.Calls
+		resp.PromptTokens += t.PromptTokens
+		resp.CompletionTokens += t.CompletionTokens
+		resp.CostUsd += t.CostUSD
+		resp.ByModel = append(resp.ByModel, &pb.ModelUsage{
+			Provider:         t.Provider,
+			Model:            t.Model,
+			Calls:            t.Calls,
+			PromptTokens:     t.PromptTokens,
+			CompletionTokens: t.CompletionTokens,
+			CostUsd:          t.CostUSD,
+		})
+	}
+	return resp, nil
+}
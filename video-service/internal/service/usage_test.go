@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/client"
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+type MockUsageStore struct {
+	Records []models.UsageRecord
+	Since   time.Time
+	Result  []models.ModelUsage
+}
+
+func (m *MockUsageStore) Record(ctx context.Context, records ...models.UsageRecord) error {
+	m.Records = append(m.Records, records...)
+	return nil
+}
+
+func (m *MockUsageStore) Totals(ctx context.Context, userID string, since time.Time) ([]models.ModelUsage, error) {
+	m.Since = since
+	return m.Result, nil
+}
+
+func TestPriceTableCost(t *testing.T) {
+	prices := PriceTable{"cheap": {InputPerMillion: 1, OutputPerMillion: 4}}
+	if got := prices.Cost("cheap", 1_000_000, 500_000); got != 3 {
+		t.Errorf("Expected $3, got %v", got)
+	}
+	if got := prices.Cost("unknown", 1000, 1000); got != 0 {
+		t.Errorf("Expected unpriced models to cost nothing, got %v", got)
+	}
+}
+
+func TestSummarizeVideoRecordsUsage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	defer ts.Close()
+
+	llm := &MockLLMClient{
+		SummarizeFunc: func(ctx context.Context, text string) (string, error) {
+			client.ReportUsage(ctx, client.Usage{PromptTokens: 1000, CompletionTokens: 200})
+			return "A summary.", nil
+		},
+	}
+	store := &MockUsageStore{}
+	svc := &VideoService{
+		llmClient:            NewRouter(RouteProvider{Name: "test", Model: "priced", Client: llm}),
+		usage:                store,
+		prices:               PriceTable{"priced": {InputPerMillion: 1, OutputPerMillion: 10}},
+		transcriptServiceURL: ts.URL,
+	}
+
+	if _, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(store.Records) != 1 {
+		t.Fatalf("Expected one usage record, got %+v", store.Records)
+	}
+	rec := store.Records[0]
+	if rec.UserID != "user-1" || rec.VideoID != "dQw4w9WgXcQ" || rec.Operation != models.OperationSummarize {
+		t.Errorf("Expected the record to identify the request, got %+v", rec)
+	}
+	if rec.Provider != "test" || rec.Model != "priced" || rec.PromptTokens != 1000 || rec.CompletionTokens != 200 {
+		t.Errorf("Expected the reported tokens for the routed model, got %+v", rec)
+	}
+	if math.Abs(rec.CostUSD-0.003) > 1e-9 {
+		t.Errorf("Expected a cost of $0.003, got %v", rec.CostUSD)
+	}
+}
+
+func TestGetUsage(t *testing.T) {
+	store := &MockUsageStore{Result: []models.ModelUsage{
+		{Provider: "gemini", Model: "gemini-2.5-pro", Calls: 2, PromptTokens: 3000, CompletionTokens: 500, CostUSD: 0.5},
+		{Provider: "openai", Model: "gpt-4o-mini", Calls: 1, PromptTokens: 1000, CompletionTokens: 100, CostUSD: 0.25},
+	}}
+	svc := &VideoService{usage: store}
+
+	t.Run("Totals", func(t *testing.T) {
+		resp, err := svc.GetUsage(context.Background(), &pb.GetUsageRequest{UserId: "user-1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Calls != 3 || resp.PromptTokens != 4000 || resp.CompletionTokens != 600 || resp.CostUsd != 0.75 {
+			t.Errorf("Expected totals across models, got %+v", resp)
+		}
+		if len(resp.ByModel) != 2 || resp.ByModel[0].Model != "gemini-2.5-pro" {
+			t.Errorf("Expected the per-model breakdown, got %+v", resp.ByModel)
+		}
+		if days := time.Since(store.Since).Hours() / 24; math.Round(days) != defaultUsageDays {
+			t.Errorf("Expected the default period of %d days, got %.1f", defaultUsageDays, days)
+		}
+	})
+
+	t.Run("InvalidDays", func(t *testing.T) {
+		_, err := svc.GetUsage(context.Background(), &pb.GetUsageRequest{UserId: "user-1", Days: -1})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, err := (&VideoService{}).GetUsage(context.Background(), &pb.GetUsageRequest{UserId: "user-1"})
+		if err == nil {
+			t.Error("Expected an error without a usage store, got nil")
+		}
+	})
+}
@@ -15,6 +15,9 @@ import (
 	"videoservice/internal/repository"
 
 	pb "shared/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type VideoService struct {
@@ -22,23 +25,71 @@ type VideoService struct {
 	videoRepo            *repository.VideoRepository
 	youtubeClient        *client.YouTubeClient
 	llmClient            LLMClient
+	embedder             Embedder
+	vectorStore          VectorStore
+	conversations        ConversationStore
+	chapters             ChapterStore
+	summaries            SummaryStore
+	usage                UsageStore
+	prices               PriceTable
+	quotas               QuotaStore
+	planQuotas           map[string]PlanQuotas
+	mapReduce            MapReduceConfig
+	jobs                 JobStore
+	jobConfig            JobConfig
+	jobQueue             *jobQueue
+	webhooks             WebhookStore
+	webhookConfig        WebhookConfig
+	webhookClient        *http.Client
+	webhookWake          chan struct{}
+	subscriptions        SubscriptionStore
+	syncConfig           SyncConfig
+	pushLeases           PushLeaseStore
+	pushConfig           PushConfig
+	pushClient           *http.Client
+	history              HistoryStore
+	collections          CollectionStore
+	notes                NoteStore
+	feedTokens           FeedTokenStore
 	cacheMaxAge          time.Duration
 	transcriptServiceURL string
 }
 
-func NewVideoService(videoRepo *repository.VideoRepository, youtubeClient *client.YouTubeClient, llmClient LLMClient) *VideoService {
+func NewVideoService(videoRepo *repository.VideoRepository, youtubeClient *client.YouTubeClient, llmClient LLMClient, opts ...Option) *VideoService {
 	transcriptURL := os.Getenv("TRANSCRIPT_SERVICE_URL")
 	if transcriptURL == "" {
 		transcriptURL = "http://localhost:8081"
 	}
 
-	return &VideoService{
+	s := &VideoService{
 		videoRepo:            videoRepo,
 		youtubeClient:        youtubeClient,
 		llmClient:            llmClient,
+		prices:               DefaultPrices,
+		planQuotas:           DefaultPlanQuotas,
 		cacheMaxAge:          30 * time.Minute, // Cache for 30 minutes
 		transcriptServiceURL: transcriptURL,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.jobs != nil {
+		s.jobConfig = s.jobConfig.withDefaults()
+		s.jobQueue = &jobQueue{pending: make(chan *models.Job, s.jobConfig.QueueSize)}
+	}
+	if s.subscriptions != nil {
+		s.syncConfig = s.syncConfig.withDefaults()
+	}
+	if s.pushLeases != nil {
+		s.pushConfig = s.pushConfig.withDefaults()
+		s.pushClient = &http.Client{}
+	}
+	if s.webhooks != nil {
+		s.webhookConfig = s.webhookConfig.withDefaults()
+		s.webhookClient = newWebhookClient(s.webhookConfig.AllowPrivateNetworks)
+		s.webhookWake = make(chan struct{}, 1)
+	}
+	return s
 }
 
 func (s *VideoService) SearchChannel(ctx context.Context, req *pb.SearchChannelRequest) (*pb.SearchChannelResponse, error) {
@@ -117,6 +168,7 @@ func (s *VideoService) GetVideoDetails(ctx context.Context, req *pb.GetVideoDeta
 	cachedVideo, err := s.videoRepo.GetCachedVideo(ctx, req.VideoId, s.cacheMaxAge)
 	if err == nil {
 		log.Printf("Cache hit for video details: %s", req.VideoId)
+		s.recordHistory(ctx, req.UserId, req.VideoId, models.HistoryViewed, cachedVideo)
 		return &pb.GetVideoDetailsResponse{
 			Video: s.convertVideoToProto(cachedVideo),
 		}, nil
@@ -132,6 +184,7 @@ func (s *VideoService) GetVideoDetails(ctx context.Context, req *pb.GetVideoDeta
 
 	// Cache video
 	s.videoRepo.CacheVideos(ctx, []models.Video{*video})
+	s.recordHistory(ctx, req.UserId, req.VideoId, models.HistoryViewed, video)
 
 	return &pb.GetVideoDetailsResponse{
 		Video: s.convertVideoToProto(video),
@@ -140,14 +193,40 @@ func (s *VideoService) GetVideoDetails(ctx context.Context, req *pb.GetVideoDeta
 
 func (s *VideoService) GetVideoTranscript(ctx context.Context, req *pb.GetVideoTranscriptRequest) (*pb.GetVideoTranscriptResponse, error) {
 	log.Printf("Getting transcript for video: %s", req.VideoId)
+	release, err := s.checkQuota(ctx, req.UserId, QuotaTranscripts)
+	if err != nil {
+		return nil, err
+	}
+	transcript, err := s.fetchTranscript(ctx, req.VideoId)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	log.Printf("Successfully fetched transcript for video: %s", req.VideoId)
+	s.recordHistory(ctx, req.UserId, req.VideoId, models.HistoryTranscriptRead, nil)
+
+	return &pb.GetVideoTranscriptResponse{
+		Transcript: transcript.Text,
+		VideoId:    req.VideoId,
+		Segments:   convertSegmentsToProto(transcript.Segments),
+	}, nil
+}
+
+type transcriptResult struct {
+	Text     string
+	Segments []models.TranscriptSegment
+}
+
+func (s *VideoService) fetchTranscript(ctx context.Context, videoID string) (*transcriptResult, error) {
 	// Validate video ID format
-	matched, _ := regexp.MatchString(`^[a-zA-Z0-9_-]{11}$`, req.VideoId)
+	matched, _ := regexp.MatchString(`^[a-zA-Z0-9_-]{11}$`, videoID)
 	if !matched {
-		log.Printf("Invalid video ID format: %s", req.VideoId)
+		log.Printf("Invalid video ID format: %s", videoID)
 		return nil, fmt.Errorf("invalid video id")
 	}
 
-	transcriptURL := fmt.Sprintf("%s/transcript?videoId=%s", s.transcriptServiceURL, req.VideoId)
+	transcriptURL := fmt.Sprintf("%s/transcript?videoId=%s", s.transcriptServiceURL, videoID)
 	log.Printf("Fetching transcript from: %s", transcriptURL)
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, transcriptURL, nil)
@@ -164,8 +243,9 @@ func (s *VideoService) GetVideoTranscript(ctx context.Context, req *pb.GetVideoT
 	defer resp.Body.Close()
 
 	var result struct {
-		Transcript string `json:"transcript"`
-		Error      string `json:"error"`
+		Transcript string                     `json:"transcript"`
+		Segments   []models.TranscriptSegment `json:"segments"`
+		Error      string                     `json:"error"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		log.Printf("Failed to decode transcript response: %v", err)
@@ -176,45 +256,170 @@ func (s *VideoService) GetVideoTranscript(ctx context.Context, req *pb.GetVideoT
 		return nil, fmt.Errorf("transcript service error: %s", result.Error)
 	}
 
-	log.Printf("Successfully fetched transcript for video: %s", req.VideoId)
-
-	return &pb.GetVideoTranscriptResponse{
-		Transcript: result.Transcript,
-		VideoId:    req.VideoId,
-	}, nil
+	return &transcriptResult{Text: result.Transcript, Segments: result.Segments}, nil
 }
 
 func (s *VideoService) SummarizeVideo(ctx context.Context, req *pb.SummarizeVideoRequest) (*pb.SummarizeVideoResponse, error) {
 	log.Printf("Summarizing video: %s for user: %s", req.VideoId, req.UserId)
-	// First, fetch the transcript
-	transcriptResp, err := s.GetVideoTranscript(ctx, &pb.GetVideoTranscriptRequest{
-		VideoId: req.VideoId,
-		UserId:  req.UserId,
-	})
+	opts, err := summaryOptions(req)
 	if err != nil {
-		log.Printf("Error getting transcript for summarization of %s: %v", req.VideoId, err)
-		return nil, fmt.Errorf("failed to fetch transcript for summarization: %w", err)
+		return nil, err
+	}
+	release, err := s.checkQuota(ctx, req.UserId, QuotaSummaries)
+	if err != nil {
+		return nil, err
 	}
 
-	if transcriptResp.Transcript == "" {
-		log.Printf("Empty transcript for video %s, cannot summarize", req.VideoId)
-		return nil, fmt.Errorf("transcript is empty, cannot generate summary")
+	transcript, err := s.transcriptForSummary(ctx, req)
+	if err != nil {
+		release()
+		return nil, err
 	}
 
 	// Then, call LLM to summarize
-	log.Printf("Calling LLM to summarize video: %s", req.VideoId)
-	summary, err := s.llmClient.Summarize(ctx, transcriptResp.Transcript)
+	log.Printf("Calling LLM to summarize video: %s (style %s, length %s)", req.VideoId, opts.Style, opts.Length)
+	ctx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, req.UserId, req.VideoId, models.OperationSummarize, calls)
+	summary, structured, err := s.generateSummary(ctx, req, transcript, opts, nil)
 	if err != nil {
 		log.Printf("Error summarizing video %s with LLM: %v", req.VideoId, err)
-		return nil, fmt.Errorf("failed to generate summary: %w", err)
+		release()
+		return nil, llmError("failed to generate summary", err)
 	}
 
 	log.Printf("Successfully summarized video: %s", req.VideoId)
 
-	return &pb.SummarizeVideoResponse{
-		Summary: summary,
-		VideoId: req.VideoId,
-	}, nil
+	resp := &pb.SummarizeVideoResponse{
+		Summary:     summary,
+		VideoId:     req.VideoId,
+		Style:       string(opts.Style),
+		Length:      string(opts.Length),
+		Language:    opts.Language,
+		Structured:  convertStructuredToProto(structured),
+		GeneratedBy: calls.generatedBy(),
+	}
+	s.notify(ctx, req.UserId, models.EventSummaryCompleted, summaryEvent{VideoID: req.VideoId, Summary: resp})
+	s.recordHistory(ctx, req.UserId, req.VideoId, models.HistorySummarized, nil)
+	return resp, nil
+}
+
+// SummarizeVideoStream sends the summary as it is generated, followed by a
+// final message carrying the complete text.
+func (s *VideoService) SummarizeVideoStream(req *pb.SummarizeVideoRequest, stream pb.VideoService_SummarizeVideoStreamServer) error {
+	ctx := stream.Context()
+	log.Printf("Streaming summary of video: %s for user: %s", req.VideoId, req.UserId)
+	opts, err := summaryOptions(req)
+	if err != nil {
+		return err
+	}
+	// Headers, with the quota state, go out before the summary is generated.
+	// An exhausted quota is reported in the trailers of a response without
+	// headers, so the gateway can tell it apart before it starts streaming.
+	quota, release, err := s.consumeQuota(ctx, req.UserId, QuotaSummaries)
+	if err != nil {
+		if quota != nil {
+			stream.SetTrailer(quota.metadata())
+		}
+		return err
+	}
+	if err := stream.SendHeader(quota.metadata()); err != nil {
+		release()
+		return err
+	}
+
+	transcript, err := s.transcriptForSummary(ctx, req)
+	if err != nil {
+		release()
+		return err
+	}
+
+	ctx, calls := withCallRecorder(ctx)
+	defer s.saveUsage(ctx, req.UserId, req.VideoId, models.OperationSummarize, calls)
+	summary, structured, err := s.generateSummary(ctx, req, transcript, opts, func(delta string) error {
+		return stream.Send(&pb.SummarizeVideoChunk{
+			VideoId: req.VideoId,
+			Delta:   delta,
+		})
+	})
+	if err != nil {
+		log.Printf("Error streaming summary of video %s with LLM: %v", req.VideoId, err)
+		release()
+		return llmError("failed to generate summary", err)
+	}
+
+	log.Printf("Successfully streamed summary of video: %s", req.VideoId)
+
+	done := &pb.SummarizeVideoChunk{
+		VideoId:     req.VideoId,
+		Done:        true,
+		Summary:     summary,
+		Structured:  convertStructuredToProto(structured),
+		GeneratedBy: calls.generatedBy(),
+	}
+	if err := stream.Send(done); err != nil {
+		return err
+	}
+	s.notify(ctx, req.UserId, models.EventSummaryCompleted, summaryEvent{
+		VideoID: req.VideoId,
+		Summary: &pb.SummarizeVideoResponse{
+			Summary:     done.Summary,
+			VideoId:     req.VideoId,
+			Style:       string(opts.Style),
+			Length:      string(opts.Length),
+			Language:    opts.Language,
+			Structured:  done.Structured,
+			GeneratedBy: done.GeneratedBy,
+		},
+	})
+	s.recordHistory(ctx, req.UserId, req.VideoId, models.HistorySummarized, nil)
+	return nil
+}
+
+// generateSummary summarizes a transcript as requested. Structured summaries
+// are generated in one piece, so onChunk is not called for them; their
+// Markdown is rendered from the structured data. Plain summaries are cached
+// for digests to reuse.
+func (s *VideoService) generateSummary(ctx context.Context, req *pb.SummarizeVideoRequest, transcript *transcriptResult, opts models.SummaryOptions, onChunk func(string) error) (string, *models.StructuredSummary, error) {
+	if !req.Structured {
+		summary, err := s.summarizeTranscript(ctx, transcript.Text, opts, onChunk)
+		if err != nil {
+			return "", nil, err
+		}
+		s.cacheSummary(ctx, req.VideoId, opts, summary)
+		return summary, nil, nil
+	}
+
+	structured, err := s.summarizeStructured(ctx, timestampedTranscript(transcript.Text, transcript.Segments), opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return renderStructuredMarkdown(structured), structured, nil
+}
+
+// summaryOptions validates the style, length and language of a request.
+func summaryOptions(req *pb.SummarizeVideoRequest) (models.SummaryOptions, error) {
+	opts, err := models.ParseSummaryOptions(req.Style, req.Length, req.Language)
+	if err != nil {
+		return models.SummaryOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
+}
+
+func (s *VideoService) transcriptForSummary(ctx context.Context, req *pb.SummarizeVideoRequest) (*transcriptResult, error) {
+	// First, fetch the transcript
+	log.Printf("Getting transcript for video: %s", req.VideoId)
+	transcript, err := s.fetchTranscript(ctx, req.VideoId)
+	if err != nil {
+		log.Printf("Error getting transcript for summarization of %s: %v", req.VideoId, err)
+		return nil, fmt.Errorf("failed to fetch transcript for summarization: %w", err)
+	}
+
+	if transcript.Text == "" {
+		log.Printf("Empty transcript for video %s, cannot summarize", req.VideoId)
+		return nil, fmt.Errorf("transcript is empty, cannot generate summary")
+	}
+
+	return transcript, nil
 }
 
 func cleanWhitespace(s string) string {
@@ -244,16 +449,29 @@ func (s *VideoService) convertVideosToProto(videos []models.Video) []*pb.VideoIn
 	return result
 }
 
+func convertSegmentsToProto(segments []models.TranscriptSegment) []*pb.TranscriptSegment {
+	result := make([]*pb.TranscriptSegment, 0, len(segments))
+	for _, seg := range segments {
+		result = append(result, &pb.TranscriptSegment{
+			Text:            seg.Text,
+			StartSeconds:    seg.Start,
+			DurationSeconds: seg.Duration,
+		})
+	}
+	return result
+}
+
 func (s *VideoService) convertVideoToProto(video *models.Video) *pb.VideoInfo {
 	return &pb.VideoInfo{
-		VideoId:      video.VideoID,
-		Title:        video.Title,
-		Description:  video.Description,
-		ThumbnailUrl: video.Thumbnail,
-		PublishedAt:  video.PublishedAt,
-		ChannelId:    video.ChannelID,
-		ChannelTitle: video.ChannelTitle,
-		ViewCount:    video.ViewCount,
-		LikeCount:    video.LikeCount,
+		VideoId:         video.VideoID,
+		Title:           video.Title,
+		Description:     video.Description,
+		ThumbnailUrl:    video.Thumbnail,
+		PublishedAt:     video.PublishedAt,
+		ChannelId:       video.ChannelID,
+		ChannelTitle:    video.ChannelTitle,
+		ViewCount:       video.ViewCount,
+		LikeCount:       video.LikeCount,
+		DurationSeconds: video.DurationSeconds,
 	}
 }
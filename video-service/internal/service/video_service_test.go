@@ -6,22 +6,104 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
+	"videoservice/internal/client"
+	"videoservice/internal/models"
+
 	pb "shared/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type MockLLMClient struct {
-	SummarizeFunc func(ctx context.Context, text string) (string, error)
+	SummarizeFunc       func(ctx context.Context, text string) (string, error)
+	SummarizeStreamFunc func(ctx context.Context, text string, onChunk func(string) error) (string, error)
+	CombineFunc         func(ctx context.Context, partials []string, onChunk func(string) error) (string, error)
+	StructuredFunc      func(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error)
+	ChaptersFunc        func(ctx context.Context, transcript string) ([]models.Chapter, error)
+	AnswerFunc          func(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error)
+	CompareFunc         func(ctx context.Context, videos []models.VideoSummary, focus string) (string, error)
+	ChannelDigestFunc   func(ctx context.Context, channel string, videos []models.VideoSummary) (string, error)
+
+	mu      sync.Mutex
+	Options []models.SummaryOptions // options of every summarization call
 }
 
-func (m *MockLLMClient) Summarize(ctx context.Context, text string) (string, error) {
+func (m *MockLLMClient) recordOptions(opts models.SummaryOptions) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Options = append(m.Options, opts)
+}
+
+func (m *MockLLMClient) Summarize(ctx context.Context, text string, opts models.SummaryOptions) (string, error) {
+	m.recordOptions(opts)
 	if m.SummarizeFunc != nil {
 		return m.SummarizeFunc(ctx, text)
 	}
 	return "Mock summary", nil
 }
 
+func (m *MockLLMClient) SummarizeStream(ctx context.Context, text string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	m.recordOptions(opts)
+	if m.SummarizeStreamFunc != nil {
+		return m.SummarizeStreamFunc(ctx, text, onChunk)
+	}
+	if err := onChunk("Mock summary"); err != nil {
+		return "", err
+	}
+	return "Mock summary", nil
+}
+
+func (m *MockLLMClient) CombineSummaries(ctx context.Context, partials []string, opts models.SummaryOptions, onChunk func(string) error) (string, error) {
+	m.recordOptions(opts)
+	if m.CombineFunc != nil {
+		return m.CombineFunc(ctx, partials, onChunk)
+	}
+	return "Mock combined summary", nil
+}
+
+func (m *MockLLMClient) SummarizeStructured(ctx context.Context, transcript string, opts models.SummaryOptions) (*models.StructuredSummary, error) {
+	m.recordOptions(opts)
+	if m.StructuredFunc != nil {
+		return m.StructuredFunc(ctx, transcript, opts)
+	}
+	return &models.StructuredSummary{Title: "Mock title", TLDR: "Mock TL;DR"}, nil
+}
+
+func (m *MockLLMClient) GenerateChapters(ctx context.Context, transcript string) ([]models.Chapter, error) {
+	if m.ChaptersFunc != nil {
+		return m.ChaptersFunc(ctx, transcript)
+	}
+	return []models.Chapter{{Title: "Mock chapter"}}, nil
+}
+
+func (m *MockLLMClient) Answer(ctx context.Context, question string, passages []models.TranscriptChunk, notes []models.Note, history []models.ChatMessage) (string, error) {
+	if m.AnswerFunc != nil {
+		return m.AnswerFunc(ctx, question, passages, notes, history)
+	}
+	return "Mock answer", nil
+}
+
+func (m *MockLLMClient) CompareVideos(ctx context.Context, videos []models.VideoSummary, focus string, opts models.SummaryOptions) (string, error) {
+	m.recordOptions(opts)
+	if m.CompareFunc != nil {
+		return m.CompareFunc(ctx, videos, focus)
+	}
+	return "Mock comparison", nil
+}
+
+func (m *MockLLMClient) ChannelDigest(ctx context.Context, channel string, videos []models.VideoSummary, opts models.SummaryOptions) (string, error) {
+	m.recordOptions(opts)
+	if m.ChannelDigestFunc != nil {
+		return m.ChannelDigestFunc(ctx, channel, videos)
+	}
+	return "Mock channel digest", nil
+}
+
 func TestSummarizeVideo(t *testing.T) {
 	// 1. Mock the transcript service
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -74,6 +156,38 @@ func TestSummarizeVideo(t *testing.T) {
 		}
 	})
 
+	t.Run("StyleOptions", func(t *testing.T) {
+		resp, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{
+			VideoId:  "dQw4w9WgXcQ",
+			UserId:   "test-user",
+			Style:    "Study_Guide",
+			Length:   "short",
+			Language: "Spanish",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		want := models.SummaryOptions{Style: models.SummaryStyleStudyGuide, Length: models.SummaryLengthShort, Language: "Spanish"}
+		if got := mockLLM.Options[len(mockLLM.Options)-1]; got != want {
+			t.Errorf("Expected options %+v, got %+v", want, got)
+		}
+		if resp.Style != "study_guide" || resp.Length != "short" || resp.Language != "Spanish" {
+			t.Errorf("Expected resolved options in response, got %q/%q/%q", resp.Style, resp.Length, resp.Language)
+		}
+	})
+
+	t.Run("InvalidStyle", func(t *testing.T) {
+		_, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{
+			VideoId: "dQw4w9WgXcQ",
+			UserId:  "test-user",
+			Style:   "haiku",
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
 	// 5. Test Failure - Transcript Not Found
 	t.Run("TranscriptNotFound", func(t *testing.T) {
 		req := &pb.SummarizeVideoRequest{
@@ -103,6 +217,20 @@ func TestSummarizeVideo(t *testing.T) {
 		}
 	})
 
+	t.Run("Blocked", func(t *testing.T) {
+		mockLLM.SummarizeFunc = func(ctx context.Context, text string) (string, error) {
+			return "", fmt.Errorf("failed to generate content from Gemini: %w", client.ErrSafetyBlocked)
+		}
+
+		_, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{
+			VideoId: "dQw4w9WgXcQ",
+			UserId:  "test-user",
+		})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("Expected FailedPrecondition for blocked content, got %v", err)
+		}
+	})
+
 	// 7. Test Failure - Empty Transcript
 	t.Run("EmptyTranscript", func(t *testing.T) {
 		// Create a server that returns empty transcript
@@ -128,3 +256,86 @@ func TestSummarizeVideo(t *testing.T) {
 	})
 }
 
+type mockSummaryStream struct {
+	pb.VideoService_SummarizeVideoStreamServer
+	chunks  []*pb.SummarizeVideoChunk
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (m *mockSummaryStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockSummaryStream) SendHeader(md metadata.MD) error {
+	m.header = md
+	return nil
+}
+
+func (m *mockSummaryStream) SetTrailer(md metadata.MD) {
+	m.trailer = md
+}
+
+func (m *mockSummaryStream) Send(chunk *pb.SummarizeVideoChunk) error {
+	m.chunks = append(m.chunks, chunk)
+	return nil
+}
+
+func TestSummarizeVideoStream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	defer ts.Close()
+
+	mockLLM := &MockLLMClient{
+		SummarizeStreamFunc: func(ctx context.Context, text string, onChunk func(string) error) (string, error) {
+			for _, part := range []string{"Point 1", "\nPoint 2"} {
+				if err := onChunk(part); err != nil {
+					return "", err
+				}
+			}
+			return "Point 1\nPoint 2", nil
+		},
+	}
+
+	svc := &VideoService{
+		llmClient:            mockLLM,
+		transcriptServiceURL: ts.URL,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		stream := &mockSummaryStream{}
+		err := svc.SummarizeVideoStream(&pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ"}, stream)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(stream.chunks) != 3 {
+			t.Fatalf("Expected 2 deltas and a final message, got %d messages", len(stream.chunks))
+		}
+		if stream.chunks[0].Delta != "Point 1" || stream.chunks[0].Done {
+			t.Errorf("Unexpected first chunk %+v", stream.chunks[0])
+		}
+		last := stream.chunks[2]
+		if !last.Done || last.Summary != "Point 1\nPoint 2" {
+			t.Errorf("Expected final message with full summary, got %+v", last)
+		}
+	})
+
+	t.Run("LLMError", func(t *testing.T) {
+		mockLLM.SummarizeStreamFunc = func(ctx context.Context, text string, onChunk func(string) error) (string, error) {
+			return "", fmt.Errorf("llm failed")
+		}
+
+		stream := &mockSummaryStream{}
+		err := svc.SummarizeVideoStream(&pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ"}, stream)
+		if err == nil {
+			t.Fatal("Expected error for LLM failure, got nil")
+		}
+		for _, c := range stream.chunks {
+			if c.Done {
+				t.Error("Expected no final message after a failure")
+			}
+		}
+	})
+}
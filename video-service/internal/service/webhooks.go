@@ -0,0 +1,530 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+)
+
+const (
+	defaultWebhookMaxAttempts  = 6
+	defaultWebhookRetryDelay   = 30 * time.Second
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookConcurrency  = 4
+	defaultWebhookPollInterval = 5 * time.Second
+
+	maxWebhooksPerUser   = 10
+	webhookDeliveryBatch = 50
+
+	defaultDeliveryListLimit = 20
+	maxDeliveryListLimit     = 100
+
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the
+	// request body, keyed with the webhook's secret.
+	SignatureHeader = "X-TextTube-Signature"
+)
+
+// WebhookConfig controls webhook delivery. A delivery that fails is retried
+// after RetryDelay, doubling for each further attempt, until MaxAttempts
+// have been made. Each attempt gives up after Timeout. Due deliveries are
+// looked for every PollInterval and sent at most Concurrency at a time.
+// Zero fields fall back to the defaults. Webhooks may only point at public
+// addresses unless AllowPrivateNetworks is set.
+type WebhookConfig struct {
+	MaxAttempts          int
+	RetryDelay           time.Duration
+	Timeout              time.Duration
+	Concurrency          int
+	PollInterval         time.Duration
+	AllowPrivateNetworks bool
+}
+
+func (c WebhookConfig) withDefaults() WebhookConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = defaultWebhookRetryDelay
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultWebhookTimeout
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultWebhookConcurrency
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultWebhookPollInterval
+	}
+	return c
+}
+
+// WebhookStore persists webhooks and their deliveries.
+type WebhookStore interface {
+	SaveWebhook(ctx context.Context, hook *models.Webhook) error
+	// GetWebhook returns a webhook, or nil if there is none with that ID.
+	GetWebhook(ctx context.Context, id string) (*models.Webhook, error)
+	ListWebhooks(ctx context.Context, userID string) ([]models.Webhook, error)
+	// DeleteWebhook reports whether the user had a webhook with that ID.
+	DeleteWebhook(ctx context.Context, userID, id string) (bool, error)
+
+	SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// GetDelivery returns a delivery, or nil if there is none with that ID.
+	GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	// ListDeliveries returns a user's deliveries, most recent first,
+	// optionally only those to one webhook.
+	ListDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]models.WebhookDelivery, error)
+	// DueDeliveries returns pending deliveries whose next attempt is due by
+	// now, oldest first.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+}
+
+// webhookPayload is the JSON body posted to webhooks. Replays of a delivery
+// post the same body, so receivers can use the ID to ignore duplicates.
+type webhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	UserID    string      `json:"user_id"`
+	CreatedAt string      `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// summaryEvent is the data of a summary.completed event.
+type summaryEvent struct {
+	VideoID string                     `json:"video_id"`
+	JobID   string                     `json:"job_id,omitempty"`
+	Summary *pb.SummarizeVideoResponse `json:"summary"`
+}
+
+func (s *VideoService) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.Webhook, error) {
+	if s.webhooks == nil {
+		return nil, fmt.Errorf("webhooks are not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if err := validateWebhookURL(ctx, req.Url, s.webhookConfig.AllowPrivateNetworks); err != nil {
+		return nil, err
+	}
+	var events []string
+	for _, event := range req.Events {
+		if !slices.Contains(models.WebhookEvents, event) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown event %q", event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	existing, err := s.webhooks.ListWebhooks(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d webhooks can be registered", maxWebhooksPerUser)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	secret, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	hook := &models.Webhook{
+		ID:        id,
+		UserID:    req.UserId,
+		URL:       req.Url,
+		Events:    events,
+		Secret:    "whsec_" + secret,
+		CreatedAt: time.Now(),
+	}
+	if err := s.webhooks.SaveWebhook(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	log.Printf("Created webhook %s for user: %s", hook.ID, req.UserId)
+	resp := convertWebhookToProto(hook)
+	resp.Secret = hook.Secret
+	return resp, nil
+}
+
+func (s *VideoService) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	if s.webhooks == nil {
+		return nil, fmt.Errorf("webhooks are not configured")
+	}
+	hooks, err := s.webhooks.ListWebhooks(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	resp := &pb.ListWebhooksResponse{}
+	for i := range hooks {
+		resp.Webhooks = append(resp.Webhooks, convertWebhookToProto(&hooks[i]))
+	}
+	return resp, nil
+}
+
+func (s *VideoService) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	if s.webhooks == nil {
+		return nil, fmt.Errorf("webhooks are not configured")
+	}
+	deleted, err := s.webhooks.DeleteWebhook(ctx, req.UserId, req.WebhookId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "webhook %s not found", req.WebhookId)
+	}
+	log.Printf("Deleted webhook %s for user: %s", req.WebhookId, req.UserId)
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+func (s *VideoService) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	if s.webhooks == nil {
+		return nil, fmt.Errorf("webhooks are not configured")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultDeliveryListLimit
+	}
+	if limit > maxDeliveryListLimit {
+		limit = maxDeliveryListLimit
+	}
+
+	deliveries, err := s.webhooks.ListDeliveries(ctx, req.UserId, req.WebhookId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	resp := &pb.ListWebhookDeliveriesResponse{}
+	for i := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, convertDeliveryToProto(&deliveries[i]))
+	}
+	return resp, nil
+}
+
+// ReplayWebhookDelivery posts the payload of an earlier delivery again, as a
+// new delivery with its own retries.
+func (s *VideoService) ReplayWebhookDelivery(ctx context.Context, req *pb.ReplayWebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	if s.webhooks == nil {
+		return nil, fmt.Errorf("webhooks are not configured")
+	}
+	original, err := s.webhooks.GetDelivery(ctx, req.DeliveryId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load delivery: %w", err)
+	}
+	if original == nil || original.UserID != req.UserId {
+		return nil, status.Errorf(codes.NotFound, "delivery %s not found", req.DeliveryId)
+	}
+	hook, err := s.webhooks.GetWebhook(ctx, original.WebhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook: %w", err)
+	}
+	if hook == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook %s has been deleted", original.WebhookID)
+	}
+
+	delivery, err := s.queueDelivery(ctx, hook, original.Event, original.Payload)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Replaying delivery %s as %s for user: %s", original.ID, delivery.ID, req.UserId)
+	s.wakeWebhooks()
+	return convertDeliveryToProto(delivery), nil
+}
+
+// notify queues an event for delivery to every webhook of the user that
+// subscribes to it. Notifications are best effort: failures are only
+// logged.
+func (s *VideoService) notify(ctx context.Context, userID, event string, data interface{}) {
+	if s.webhooks == nil || userID == "" {
+		return
+	}
+	// Deliver the event even if the request that caused it has gone away.
+	ctx = context.WithoutCancel(ctx)
+
+	hooks, err := s.webhooks.ListWebhooks(ctx, userID)
+	if err != nil {
+		log.Printf("Error listing webhooks of user %s: %v", userID, err)
+		return
+	}
+	hooks = slices.DeleteFunc(hooks, func(h models.Webhook) bool { return !h.Subscribes(event) })
+	if len(hooks) == 0 {
+		return
+	}
+
+	id, err := newID()
+	if err != nil {
+		log.Printf("Error creating %s event: %v", event, err)
+		return
+	}
+	payload, err := json.Marshal(webhookPayload{
+		ID:        id,
+		Event:     event,
+		UserID:    userID,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("Error encoding %s event: %v", event, err)
+		return
+	}
+
+	for i := range hooks {
+		if _, err := s.queueDelivery(ctx, &hooks[i], event, string(payload)); err != nil {
+			log.Printf("Error queueing %s event for webhook %s: %v", event, hooks[i].ID, err)
+		}
+	}
+	s.wakeWebhooks()
+}
+
+func (s *VideoService) queueDelivery(ctx context.Context, hook *models.Webhook, event, payload string) (*models.WebhookDelivery, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delivery: %w", err)
+	}
+	now := time.Now()
+	delivery := &models.WebhookDelivery{
+		ID:            id,
+		WebhookID:     hook.ID,
+		UserID:        hook.UserID,
+		Event:         event,
+		Payload:       payload,
+		Status:        models.DeliveryStatusPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if err := s.webhooks.SaveDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to save delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// wakeWebhooks has RunWebhooks look for due deliveries without waiting for
+// the next poll.
+func (s *VideoService) wakeWebhooks() {
+	select {
+	case s.webhookWake <- struct{}{}:
+	default:
+	}
+}
+
+// RunWebhooks sends due webhook deliveries until ctx is cancelled. Pending
+// deliveries are stored, so those left by a previous run are sent too.
+func (s *VideoService) RunWebhooks(ctx context.Context) {
+	if s.webhooks == nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.webhookConfig.PollInterval)
+	defer ticker.Stop()
+	for {
+		s.deliverDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.webhookWake:
+		}
+	}
+}
+
+func (s *VideoService) deliverDue(ctx context.Context) {
+	due, err := s.webhooks.DueDeliveries(ctx, time.Now(), webhookDeliveryBatch)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Error loading due webhook deliveries: %v", err)
+		}
+		return
+	}
+	runBounded(ctx, len(due), s.webhookConfig.Concurrency, func(ctx context.Context, i int) error {
+		s.deliver(ctx, &due[i])
+		return nil
+	})
+}
+
+// deliver makes one attempt at a delivery and records the outcome.
+func (s *VideoService) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	hook, err := s.webhooks.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		log.Printf("Error loading webhook %s: %v", delivery.WebhookID, err)
+		return
+	}
+	if hook == nil {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = "the webhook has been deleted"
+		s.saveDelivery(ctx, delivery)
+		return
+	}
+
+	code, err := s.postWebhook(ctx, hook, delivery)
+	if ctx.Err() != nil {
+		// Shutting down: try again on the next run.
+		return
+	}
+	delivery.Attempts++
+	delivery.ResponseCode = code
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.Error = ""
+	case delivery.Attempts >= s.webhookConfig.MaxAttempts:
+		log.Printf("Webhook delivery %s failed after %d attempts: %v", delivery.ID, delivery.Attempts, err)
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = err.Error()
+	default:
+		delivery.Error = err.Error()
+		delivery.NextAttemptAt = time.Now().Add(s.webhookConfig.RetryDelay << (delivery.Attempts - 1))
+	}
+	s.saveDelivery(ctx, delivery)
+}
+
+// postWebhook posts a delivery's payload, returning the response status.
+func (s *VideoService) postWebhook(ctx context.Context, hook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.webhookConfig.Timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TextTube-Webhooks/1.0")
+	req.Header.Set("X-TextTube-Event", delivery.Event)
+	req.Header.Set("X-TextTube-Delivery", delivery.ID)
+	req.Header.Set(SignatureHeader, SignPayload(hook.Secret, body))
+
+	resp, err := s.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignPayload returns the signature header value of a webhook body.
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *VideoService) saveDelivery(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := s.webhooks.SaveDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		log.Printf("Error saving webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// validateWebhookURL checks that a webhook URL is absolute http or https
+// and, unless private networks are allowed, that its host only resolves to
+// public addresses, so webhooks cannot be used to reach internal services.
+func validateWebhookURL(ctx context.Context, raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return status.Errorf(codes.InvalidArgument, "url host %q cannot be resolved", u.Hostname())
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return status.Error(codes.InvalidArgument, "url must not point at a private or local address")
+		}
+	}
+	return nil
+}
+
+// isPublicIP reports whether ip is routable on the internet rather than a
+// loopback, private, link-local or unspecified address.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// newWebhookClient returns the client deliveries are posted with. It does
+// not follow redirects, and unless private networks are allowed it checks
+// every address it connects to, as a host can resolve differently than when
+// the webhook was created.
+func newWebhookClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("refusing to connect to private address %s", host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would make the dialer check the proxy instead of the target.
+	transport.Proxy = nil
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func convertWebhookToProto(hook *models.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		WebhookId: hook.ID,
+		UserId:    hook.UserID,
+		Url:       hook.URL,
+		Events:    hook.Events,
+		CreatedAt: hook.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func convertDeliveryToProto(delivery *models.WebhookDelivery) *pb.WebhookDelivery {
+	result := &pb.WebhookDelivery{
+		DeliveryId:   delivery.ID,
+		WebhookId:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Status:       delivery.Status,
+		Attempts:     int32(delivery.Attempts),
+		ResponseCode: int32(delivery.ResponseCode),
+		Error:        delivery.Error,
+		Payload:      delivery.Payload,
+		CreatedAt:    delivery.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if delivery.Status == models.DeliveryStatusPending {
+		result.NextAttemptAt = delivery.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	return result
+}
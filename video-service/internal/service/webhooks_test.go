@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"videoservice/internal/models"
+
+	pb "shared/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockWebhookStore struct {
+	mu         sync.Mutex
+	Webhooks   map[string]models.Webhook
+	Deliveries map[string]models.WebhookDelivery
+}
+
+func (m *MockWebhookStore) SaveWebhook(ctx context.Context, hook *models.Webhook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Webhooks == nil {
+		m.Webhooks = map[string]models.Webhook{}
+	}
+	m.Webhooks[hook.ID] = *hook
+	return nil
+}
+
+func (m *MockWebhookStore) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hook, ok := m.Webhooks[id]
+	if !ok {
+		return nil, nil
+	}
+	return &hook, nil
+}
+
+func (m *MockWebhookStore) ListWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var hooks []models.Webhook
+	for _, hook := range m.Webhooks {
+		if hook.UserID == userID {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks, nil
+}
+
+func (m *MockWebhookStore) DeleteWebhook(ctx context.Context, userID, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hook, ok := m.Webhooks[id]
+	if !ok || hook.UserID != userID {
+		return false, nil
+	}
+	delete(m.Webhooks, id)
+	return true, nil
+}
+
+func (m *MockWebhookStore) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Deliveries == nil {
+		m.Deliveries = map[string]models.WebhookDelivery{}
+	}
+	m.Deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (m *MockWebhookStore) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery, ok := m.Deliveries[id]
+	if !ok {
+		return nil, nil
+	}
+	return &delivery, nil
+}
+
+func (m *MockWebhookStore) ListDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	return m.findDeliveries(func(d models.WebhookDelivery) bool {
+		return d.UserID == userID && (webhookID == "" || d.WebhookID == webhookID)
+	}), nil
+}
+
+func (m *MockWebhookStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	return m.findDeliveries(func(d models.WebhookDelivery) bool {
+		return d.Status == models.DeliveryStatusPending && !d.NextAttemptAt.After(now)
+	}), nil
+}
+
+func (m *MockWebhookStore) findDeliveries(match func(models.WebhookDelivery) bool) []models.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []models.WebhookDelivery
+	for _, d := range m.Deliveries {
+		if match(d) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+	return deliveries
+}
+
+// webhookReceiver records the requests posted to it, answering with the
+// status returned by respond.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	respond  func() int
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rcv.mu.Lock()
+	rcv.requests = append(rcv.requests, r)
+	rcv.bodies = append(rcv.bodies, body)
+	rcv.mu.Unlock()
+	code := http.StatusOK
+	if rcv.respond != nil {
+		code = rcv.respond()
+	}
+	w.WriteHeader(code)
+}
+
+func newWebhookTestService(t *testing.T, store WebhookStore, cfg WebhookConfig) *VideoService {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"transcript": "This is a test transcript."})
+	}))
+	t.Cleanup(ts.Close)
+
+	// The receivers in these tests listen on loopback.
+	cfg.AllowPrivateNetworks = true
+	return NewVideoService(nil, nil, &MockLLMClient{}, WithWebhooks(store, cfg), func(s *VideoService) {
+		s.transcriptServiceURL = ts.URL
+	})
+}
+
+func TestCreateWebhook(t *testing.T) {
+	svc := newWebhookTestService(t, &MockWebhookStore{}, WebhookConfig{})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		_, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{UserId: "user-1", Url: "ftp://example.com/hook"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("UnknownEvent", func(t *testing.T) {
+		_, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{
+			UserId: "user-1",
+			Url:    "https://example.com/hook",
+			Events: []string{"video.deleted"},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		hook, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{
+			UserId: "user-1",
+			Url:    "https://example.com/hook",
+			Events: []string{models.EventJobFailed, models.EventJobFailed},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if hook.Secret == "" {
+			t.Error("Expected the secret on creation, got none")
+		}
+		if len(hook.Events) != 1 {
+			t.Errorf("Expected duplicate events to be dropped, got %v", hook.Events)
+		}
+
+		resp, err := svc.ListWebhooks(context.Background(), &pb.ListWebhooksRequest{UserId: "user-1"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.Webhooks) != 1 || resp.Webhooks[0].Secret != "" {
+			t.Errorf("Expected 1 webhook without its secret, got %v", resp.Webhooks)
+		}
+	})
+}
+
+func TestWebhookPrivateNetworks(t *testing.T) {
+	svc := NewVideoService(nil, nil, &MockLLMClient{}, WithWebhooks(&MockWebhookStore{}, WebhookConfig{}))
+
+	for _, url := range []string{
+		"http://localhost:8080/hook",
+		"http://127.0.0.1/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hook",
+	} {
+		_, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{UserId: "user-1", Url: url})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %s, got %v", url, err)
+		}
+	}
+
+	receiver := &webhookReceiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+	if _, err := svc.webhookClient.Post(ts.URL, "application/json", nil); err == nil {
+		t.Error("Expected the client to refuse connecting to a loopback address, got nil")
+	}
+	if len(receiver.requests) != 0 {
+		t.Errorf("Expected no requests, got %d", len(receiver.requests))
+	}
+}
+
+func TestWebhookClientRedirects(t *testing.T) {
+	receiver := &webhookReceiver{}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+
+	resp, err := newWebhookClient(true).Post(redirect.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTemporaryRedirect || len(receiver.requests) != 0 {
+		t.Errorf("Expected the redirect not to be followed, got %d and %d requests", resp.StatusCode, len(receiver.requests))
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	receiver := &webhookReceiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	store := &MockWebhookStore{}
+	svc := newWebhookTestService(t, store, WebhookConfig{})
+	hook, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{
+		UserId: "user-1",
+		Url:    ts.URL,
+		Events: []string{models.EventSummaryCompleted},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Another user's summary is not delivered.
+	if _, err := svc.SummarizeVideo(context.Background(), &pb.SummarizeVideoRequest{VideoId: "dQw4w9WgXcQ", UserId: "user-2"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	svc.deliverDue(context.Background())
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(receiver.requests))
+	}
+	req, body := receiver.requests[0], receiver.bodies[0]
+	if got, want := req.Header.Get(SignatureHeader), SignPayload(hook.Secret, body); got != want {
+		t.Errorf("Expected signature %q, got %q", want, got)
+	}
+	if req.Header.Get("X-TextTube-Event") != models.EventSummaryCompleted {
+		t.Errorf("Expected the event header, got %q", req.Header.Get("X-TextTube-Event"))
+	}
+
+	var payload struct {
+		ID    string `json:"id"`
+		Event string `json:"event"`
+		Data  struct {
+			VideoID string `json:"video_id"`
+			Summary struct {
+				Summary string `json:"summary"`
+			} `json:"summary"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Expected a JSON payload, got %v", err)
+	}
+	if payload.ID == "" || payload.Event != models.EventSummaryCompleted || payload.Data.VideoID != "dQw4w9WgXcQ" || payload.Data.Summary.Summary != "Mock summary" {
+		t.Errorf("Unexpected payload %s", body)
+	}
+
+	resp, err := svc.ListWebhookDeliveries(context.Background(), &pb.ListWebhookDeliveriesRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Deliveries) != 1 || resp.Deliveries[0].Status != models.DeliveryStatusDelivered || resp.Deliveries[0].ResponseCode != 200 {
+		t.Errorf("Expected 1 delivered delivery, got %v", resp.Deliveries)
+	}
+}
+
+func TestWebhookRetryAndReplay(t *testing.T) {
+	var mu sync.Mutex
+	code := http.StatusInternalServerError
+	receiver := &webhookReceiver{respond: func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return code
+	}}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	store := &MockWebhookStore{}
+	svc := newWebhookTestService(t, store, WebhookConfig{MaxAttempts: 2, RetryDelay: time.Millisecond})
+	if _, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{UserId: "user-1", Url: ts.URL}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	svc.notify(context.Background(), "user-1", models.EventJobFailed, map[string]string{"job_id": "job-1"})
+	svc.deliverDue(context.Background())
+	time.Sleep(5 * time.Millisecond)
+	svc.deliverDue(context.Background())
+
+	resp, err := svc.ListWebhookDeliveries(context.Background(), &pb.ListWebhookDeliveriesRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Deliveries) != 1 {
+		t.Fatalf("Expected 1 delivery, got %d", len(resp.Deliveries))
+	}
+	failed := resp.Deliveries[0]
+	if failed.Status != models.DeliveryStatusFailed || failed.Attempts != 2 || failed.ResponseCode != 500 {
+		t.Errorf("Expected a failed delivery after 2 attempts with status 500, got %+v", failed)
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		mu.Lock()
+		code = http.StatusNoContent
+		mu.Unlock()
+
+		replay, err := svc.ReplayWebhookDelivery(context.Background(), &pb.ReplayWebhookDeliveryRequest{UserId: "user-1", DeliveryId: failed.DeliveryId})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if replay.DeliveryId == failed.DeliveryId || replay.Payload != failed.Payload {
+			t.Errorf("Expected a new delivery of the same payload, got %+v", replay)
+		}
+		svc.deliverDue(context.Background())
+
+		delivery, _ := store.GetDelivery(context.Background(), replay.DeliveryId)
+		if delivery.Status != models.DeliveryStatusDelivered {
+			t.Errorf("Expected the replay to be delivered, got %q (%s)", delivery.Status, delivery.Error)
+		}
+	})
+
+	t.Run("ReplayOtherUser", func(t *testing.T) {
+		_, err := svc.ReplayWebhookDelivery(context.Background(), &pb.ReplayWebhookDeliveryRequest{UserId: "user-2", DeliveryId: failed.DeliveryId})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+	})
+}
+
+func TestWebhookDeleted(t *testing.T) {
+	receiver := &webhookReceiver{}
+	ts := httptest.NewServer(receiver)
+	defer ts.Close()
+
+	store := &MockWebhookStore{}
+	svc := newWebhookTestService(t, store, WebhookConfig{})
+	hook, err := svc.CreateWebhook(context.Background(), &pb.CreateWebhookRequest{UserId: "user-1", Url: ts.URL})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	svc.notify(context.Background(), "user-1", models.EventJobFailed, nil)
+
+	if _, err := svc.DeleteWebhook(context.Background(), &pb.DeleteWebhookRequest{UserId: "user-2", WebhookId: hook.WebhookId}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for another user's webhook, got %v", err)
+	}
+	if _, err := svc.DeleteWebhook(context.Background(), &pb.DeleteWebhookRequest{UserId: "user-1", WebhookId: hook.WebhookId}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	svc.deliverDue(context.Background())
+
+	if len(receiver.requests) != 0 {
+		t.Errorf("Expected nothing to be posted to a deleted webhook, got %d requests", len(receiver.requests))
+	}
+	resp, _ := svc.ListWebhookDeliveries(context.Background(), &pb.ListWebhookDeliveriesRequest{UserId: "user-1"})
+	if len(resp.Deliveries) != 1 || resp.Deliveries[0].Status != models.DeliveryStatusFailed {
+		t.Errorf("Expected the pending delivery to fail, got %v", resp.Deliveries)
+	}
+}